@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/db"
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor/logs"
+	"github.com/spf13/cobra"
+)
+
+var (
+	runName   string
+	runDir    string
+	runDetach bool
+)
+
+var runCmd = &cobra.Command{
+	Use:   "run [flags] -- <command> [args...]",
+	Short: "Run a dev process and capture its output",
+	Long: `run starts a command in a project directory and captures its stdout/stderr
+into rotating files under ~/.devmon/proc-logs/, so the Node.js/Python panels can
+show its logs and restart it with the same command.
+
+Example:
+  devmon run -- npm run dev
+  devmon run --name api --dir ./backend -- uvicorn main:app --reload`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		os.Exit(runManagedProcess(args))
+	},
+}
+
+func init() {
+	runCmd.Flags().StringVar(&runName, "name", "", "display name (default: directory name)")
+	runCmd.Flags().StringVar(&runDir, "dir", "", "directory to run the command in (default: current directory)")
+	runCmd.Flags().BoolVar(&runDetach, "detach", false, "do not attach to the terminal (used by the TUI)")
+	runCmd.Flags().MarkHidden("detach")
+	// コマンド側のフラグを devmon のフラグとして解釈しない（-- を省略しても動くように）
+	runCmd.Flags().SetInterspersed(false)
+	rootCmd.AddCommand(runCmd)
+}
+
+// runManagedProcess runs the command, tees its output to the log file and returns its exit code
+func runManagedProcess(command []string) int {
+	dir := runDir
+	if dir == "" {
+		dir, _ = os.Getwd()
+	}
+
+	process, err := monitor.NewManagedProcess(runName, dir, command)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "devmon run: %v\n", err)
+		return 1
+	}
+
+	logFile, err := logs.NewRotatingWriter(process.LogFile, monitor.ManagedLogMaxBytes, monitor.ManagedLogBackups)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "devmon run: ログファイルを開けません: %v\n", err)
+		return 1
+	}
+	defer logFile.Close()
+
+	// ログが保存できなくてもプロセスは起動する（TUIに表示されないだけ）
+	store, err := db.NewStore()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "devmon run: データベースを開けません（一覧には表示されません）: %v\n", err)
+		store = nil
+	} else {
+		defer store.Close()
+	}
+
+	stdoutLog := logs.NewLineWriter(logFile)
+	stderrLog := logs.NewLineWriter(logFile)
+
+	child := exec.Command(command[0], command[1:]...)
+	child.Dir = process.Dir
+	if runDetach {
+		// TUIから起動した場合は端末がないため、子プロセスごと停止できるようにグループを分ける
+		child.Stdout = stdoutLog
+		child.Stderr = stderrLog
+		monitor.SetProcessGroup(child)
+	} else {
+		child.Stdin = os.Stdin
+		child.Stdout = io.MultiWriter(os.Stdout, stdoutLog)
+		child.Stderr = io.MultiWriter(os.Stderr, stderrLog)
+	}
+
+	fmt.Fprintf(logFile, "--- devmon run: %s (%s) ---\n", process.CommandLine(), time.Now().Format("2006-01-02 15:04:05"))
+
+	// Ctrl+C は端末から子プロセスにも届くため、ラッパーは終了を待って記録する
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	defer signal.Stop(signals)
+
+	if err := child.Start(); err != nil {
+		fmt.Fprintf(logFile, "--- 起動失敗: %v ---\n", err)
+		fmt.Fprintf(os.Stderr, "devmon run: %v\n", err)
+		return 127
+	}
+
+	process.PID = child.Process.Pid
+	process.ProcessStart = monitor.ProcessStartTime(process.PID)
+	process.OwnGroup = runDetach
+	process.StartedAt = time.Now()
+	if store != nil {
+		if err := store.SaveManagedProcess(process); err != nil {
+			fmt.Fprintf(os.Stderr, "devmon run: プロセスを登録できません: %v\n", err)
+		}
+	}
+	if !runDetach {
+		fmt.Fprintf(os.Stderr, "devmon run: PID %d（ログ: %s）\n", process.PID, process.LogFile)
+	}
+
+	go func() {
+		for sig := range signals {
+			if sig == os.Interrupt && !runDetach {
+				continue
+			}
+			child.Process.Signal(sig)
+		}
+	}()
+
+	exitCode := 0
+	if err := child.Wait(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			exitCode = exitErr.ExitCode()
+		} else {
+			exitCode = 1
+		}
+	}
+
+	stdoutLog.Flush()
+	stderrLog.Flush()
+	if exitCode < 0 {
+		fmt.Fprintf(logFile, "--- シグナルで終了しました (%s) ---\n", time.Now().Format("2006-01-02 15:04:05"))
+	} else {
+		fmt.Fprintf(logFile, "--- 終了しました（終了コード: %d） (%s) ---\n", exitCode, time.Now().Format("2006-01-02 15:04:05"))
+	}
+
+	if store != nil {
+		if err := store.FinishManagedProcess(process.Key, process.PID, exitCode, time.Now()); err != nil {
+			fmt.Fprintf(os.Stderr, "devmon run: 終了を記録できません: %v\n", err)
+		}
+	}
+
+	if exitCode < 0 {
+		return 1
+	}
+	return exitCode
+}
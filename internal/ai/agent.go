@@ -0,0 +1,177 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/llm"
+)
+
+// AgentMaxSteps はツールを呼び出す往復の上限（超えたらツールなしで回答させる）
+const AgentMaxSteps = 5
+
+// toolResultMaxBytes はモデルに返すツールの結果の最大サイズ
+const toolResultMaxBytes = 6000
+
+// agentPrompt はツールを使う場合にシステムプロンプトへ追加する指示
+const agentPrompt = `
+5. **ツールの利用**:
+   - レポートだけでは原因が分からない場合は、ツールで必要な情報（コンテナのログ、プロセスの詳細、メトリクスの履歴、ポート、PostgreSQLのテーブル）を取得してから回答してください。
+   - ツールは読み取り専用です。問題がありそうな箇所に絞って呼び出してください。
+`
+
+// ToolTrace is a record of one tool call made by the model
+type ToolTrace struct {
+	Name     string
+	Args     string // 引数（表示用）
+	Summary  string // 結果の要約（表示用）
+	Err      string
+	Duration time.Duration
+}
+
+// AgentEvent is sent while the agent runs: a tool call, or the final answer
+type AgentEvent struct {
+	Trace   *ToolTrace
+	Answer  string
+	History []llm.Message // 回答後の会話（ツールの呼び出しと結果を含む）
+	Done    bool
+	Err     error
+}
+
+// RunAgent answers the last question of the conversation, letting the model call read-only tools
+// ツールの呼び出しごとにイベントを送り、最後に回答を送ってチャネルを閉じる
+func (s *Service) RunAgent(ctx context.Context, history []llm.Message) <-chan AgentEvent {
+	events := make(chan AgentEvent)
+
+	go func() {
+		defer close(events)
+
+		// UIが読み取りをやめた場合（キャンセルなど）に送信で止まり続けないようにする
+		send := func(event AgentEvent) bool {
+			select {
+			case events <- event:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		messages := append([]llm.Message{}, history...)
+		tools := toolDefinitions()
+		for step := 0; ; step++ {
+			request := withAgentPrompt(trimHistory(messages))
+			if step == AgentMaxSteps {
+				// 上限の指示は会話に残さない
+				tools = nil
+				request = append(request, llm.Message{
+					Role:    "system",
+					Content: "ツールの呼び出し回数の上限に達しました。これまでに取得した情報から回答してください。",
+				})
+			}
+
+			reply, err := s.client.ChatWithTools(ctx, request, s.Model, tools)
+			if err != nil {
+				send(AgentEvent{Err: err})
+				return
+			}
+			reply.Role = "assistant"
+
+			if len(reply.ToolCalls) == 0 || tools == nil {
+				// 上限に達してもツールを呼ぼうとした場合は、結果のない呼び出しを会話に残さない
+				if len(reply.ToolCalls) > 0 {
+					reply.ToolCalls = nil
+					if reply.Content == "" {
+						reply.Content = "ツールの呼び出し回数の上限に達したため、調査を打ち切りました。質問を絞って再度お試しください。"
+					}
+				}
+				messages = append(messages, reply)
+				send(AgentEvent{Answer: reply.Content, History: messages, Done: true})
+				return
+			}
+			messages = append(messages, reply)
+
+			for _, call := range reply.ToolCalls {
+				start := time.Now()
+				result, err := s.runTool(call)
+				trace := &ToolTrace{
+					Name:     call.Function.Name,
+					Args:     formatToolArgs(call.Function.Arguments),
+					Duration: time.Since(start),
+				}
+				if err != nil {
+					// エラーにもコマンドの出力などが含まれる場合があるためマスクする
+					trace.Err = err.Error()
+					result = "error: " + s.redactor.Redact(err.Error())
+				} else {
+					// ツールの結果（ログなど）もレポートと同じようにマスクする
+					result = truncateToolResult(s.redactor.Redact(result))
+					trace.Summary = summarizeToolResult(result)
+				}
+				messages = append(messages, llm.Message{Role: "tool", ToolName: call.Function.Name, ToolCallID: call.ID, Content: result})
+
+				if !send(AgentEvent{Trace: trace}) {
+					return
+				}
+			}
+		}
+	}()
+
+	return events
+}
+
+// withAgentPrompt adds the tool instructions to the system prompt of the request
+// 保存する会話には含めない（ツールを使わない質問にも同じ会話を使うため）
+func withAgentPrompt(messages []llm.Message) []llm.Message {
+	if len(messages) == 0 || messages[0].Role != "system" {
+		return messages
+	}
+	request := append([]llm.Message{}, messages...)
+	request[0].Content += agentPrompt
+	return request
+}
+
+// formatToolArgs formats tool arguments for display
+func formatToolArgs(args map[string]any) string {
+	if len(args) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(args)
+	if err != nil {
+		return fmt.Sprint(args)
+	}
+	return string(data)
+}
+
+// truncateToolResult keeps the end of a long tool result (ログは新しい行ほど重要なため末尾を残す)
+func truncateToolResult(result string) string {
+	if len(result) <= toolResultMaxBytes {
+		return result
+	}
+	// マルチバイト文字の途中で切らないよう、文字の先頭まで進める
+	start := len(result) - toolResultMaxBytes
+	for start < len(result) && !utf8.RuneStart(result[start]) {
+		start++
+	}
+	tail := result[start:]
+	if i := strings.IndexByte(tail, '\n'); i >= 0 {
+		tail = tail[i+1:]
+	}
+	return "(truncated)\n" + tail
+}
+
+// summarizeToolResult returns the first line and the number of lines of a result
+func summarizeToolResult(result string) string {
+	lines := strings.Split(strings.TrimRight(result, "\n"), "\n")
+	first := lines[0]
+	if runes := []rune(first); len(runes) > 60 {
+		first = string(runes[:57]) + "..."
+	}
+	if len(lines) == 1 {
+		return first
+	}
+	return fmt.Sprintf("%s（%d行）", first, len(lines))
+}
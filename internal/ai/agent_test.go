@@ -0,0 +1,148 @@
+package ai
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/llm"
+	"github.com/Masahide-S/bho_hacka_go/internal/llm/mockollama"
+)
+
+// withTestTool adds a tool that returns result for the duration of the test
+func withTestTool(t *testing.T, name, result string) {
+	t.Helper()
+	saved := agentTools
+	agentTools = append(append([]agentTool{}, agentTools...), agentTool{
+		def: llm.NewTool(name, "test tool", nil),
+		run: func(*Service, map[string]any) (string, error) { return result, nil },
+	})
+	t.Cleanup(func() { agentTools = saved })
+}
+
+// newMockService returns a service talking to the mock server through the OpenAI-compatible API
+func newMockService(t *testing.T, srv *mockollama.Server) *Service {
+	t.Helper()
+	provider, err := llm.NewProvider(llm.ProviderOpenAI, "mock", srv.URL, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewServiceWithProviders(provider)
+}
+
+// runAgent collects the events of the agent until the final answer
+func runAgent(t *testing.T, s *Service) ([]ToolTrace, AgentEvent) {
+	t.Helper()
+	history := []llm.Message{
+		{Role: "system", Content: "system prompt"},
+		{Role: "user", Content: "report"},
+		{Role: "user", Content: "why is api slow?"},
+	}
+	var traces []ToolTrace
+	for event := range s.RunAgent(context.Background(), history) {
+		switch {
+		case event.Err != nil:
+			t.Fatalf("agent error: %v", event.Err)
+		case event.Trace != nil:
+			traces = append(traces, *event.Trace)
+		case event.Done:
+			return traces, event
+		}
+	}
+	t.Fatal("agent finished without an answer")
+	return nil, AgentEvent{}
+}
+
+func TestRunAgentFeedsToolResultsBack(t *testing.T) {
+	withTestTool(t, "echo_env", "DB_PASSWORD=hunter2\nready")
+	srv := mockollama.NewServer([]string{"mock-model"},
+		mockollama.ToolCallReply("echo_env", map[string]any{"name": "api"}),
+		mockollama.Reply("api is waiting for the database"),
+	)
+	defer srv.Close()
+
+	traces, final := runAgent(t, newMockService(t, srv))
+
+	if len(traces) != 1 || traces[0].Name != "echo_env" || traces[0].Err != "" {
+		t.Fatalf("traces = %+v, want one successful echo_env call", traces)
+	}
+	if final.Answer != "api is waiting for the database" {
+		t.Errorf("answer = %q", final.Answer)
+	}
+
+	requests := srv.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("got %d requests, want 2", len(requests))
+	}
+	second := requests[1].Messages
+	call, result := second[len(second)-2], second[len(second)-1]
+	if len(call.ToolCalls) != 1 || call.ToolCalls[0].ID == "" {
+		t.Fatalf("tool call message = %+v, want one call with an ID", call)
+	}
+	if result.Role != "tool" || result.ToolCallID != call.ToolCalls[0].ID {
+		t.Errorf("tool result = %+v, want role tool with tool_call_id %q", result, call.ToolCalls[0].ID)
+	}
+	if strings.Contains(result.Content, "hunter2") || !strings.Contains(result.Content, "[REDACTED]") {
+		t.Errorf("tool result was not redacted: %q", result.Content)
+	}
+
+	// 保存する会話にはツールの呼び出しと結果、回答が残る
+	if n := len(final.History); n != 6 || final.History[n-1].Content != final.Answer {
+		t.Errorf("history has %d messages: %+v", n, final.History)
+	}
+}
+
+func TestRunAgentStopsAtMaxSteps(t *testing.T) {
+	withTestTool(t, "echo_env", "ok")
+	var replies []llm.Message
+	for i := 0; i <= AgentMaxSteps; i++ {
+		replies = append(replies, mockollama.ToolCallReply("echo_env", nil))
+	}
+	srv := mockollama.NewServer([]string{"mock-model"}, replies...)
+	defer srv.Close()
+
+	traces, final := runAgent(t, newMockService(t, srv))
+
+	if len(traces) != AgentMaxSteps {
+		t.Errorf("got %d tool calls, want %d", len(traces), AgentMaxSteps)
+	}
+	requests := srv.Requests()
+	if len(requests) != AgentMaxSteps+1 {
+		t.Fatalf("got %d requests, want %d", len(requests), AgentMaxSteps+1)
+	}
+	for i, req := range requests[:AgentMaxSteps] {
+		if len(req.Tools) == 0 {
+			t.Errorf("request %d has no tools", i)
+		}
+	}
+	last := requests[AgentMaxSteps]
+	if len(last.Tools) != 0 {
+		t.Errorf("last request has %d tools, want none", len(last.Tools))
+	}
+	if msg := last.Messages[len(last.Messages)-1]; msg.Role != "system" || !strings.Contains(msg.Content, "上限") {
+		t.Errorf("last request does not end with the limit instruction: %+v", msg)
+	}
+
+	// ツールの呼び出しだけの応答には代わりの回答を返し、結果のない呼び出しは会話に残さない
+	if !strings.Contains(final.Answer, "上限に達したため") {
+		t.Errorf("answer = %q, want the fallback text", final.Answer)
+	}
+	if msg := final.History[len(final.History)-1]; len(msg.ToolCalls) != 0 || msg.Content != final.Answer {
+		t.Errorf("last message of the history = %+v", msg)
+	}
+}
+
+func TestTruncateToolResultKeepsRunes(t *testing.T) {
+	result := strings.Repeat("エラー", toolResultMaxBytes) + "!" // 改行のない長い結果（切る位置が文字の途中になる）
+	truncated := truncateToolResult(result)
+	if !strings.HasPrefix(truncated, "(truncated)\n") {
+		t.Fatalf("result was not truncated: %q", truncated[:40])
+	}
+	if !utf8.ValidString(truncated) {
+		t.Error("truncated result is not valid UTF-8")
+	}
+	if len(truncated) > toolResultMaxBytes+len("(truncated)\n") {
+		t.Errorf("truncated result is %d bytes", len(truncated))
+	}
+}
@@ -0,0 +1,94 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/db"
+	"github.com/Masahide-S/bho_hacka_go/internal/llm"
+)
+
+// ChatHistoryLimit はシステム状況レポートの後ろに残す会話のメッセージ数（超えた分は古い順に送らない）
+const ChatHistoryLimit = 20
+
+// 会話の先頭はシステムプロンプトとシステム状況レポート
+const (
+	chatSystemIndex   = 0
+	chatSnapshotIndex = 1
+	chatHeaderLen     = 2
+)
+
+// NewConversation はシステムプロンプトと現在のシステム状況レポートから会話を始めます
+func (s *Service) NewConversation() []llm.Message {
+	history, _ := s.NewConversationWithReport()
+	return history
+}
+
+// NewConversationWithReport は会話を始め、レポートでマスクした値の件数も返します
+func (s *Service) NewConversationWithReport() ([]llm.Message, llm.RedactionReport) {
+	systemPrompt, userContext, report := s.buildSystemContext()
+	return s.buildMessages(systemPrompt, userContext), report
+}
+
+// PreviewRequest は会話を送る時に実際にLLMへ渡すメッセージを返します（古い会話の切り詰めとツールの指示を反映）
+func (s *Service) PreviewRequest(history []llm.Message, agent bool) []llm.Message {
+	request := trimHistory(history)
+	if agent {
+		request = withAgentPrompt(request)
+	}
+	return request
+}
+
+// RefreshSnapshot は会話中のシステム状況レポートを現在の状態に差し替えます
+// レポートを追記すると会話のたびにコンテキストが大きくなるため、先頭のレポートを置き換えて更新したことだけを会話に残す
+func (s *Service) RefreshSnapshot(history []llm.Message) []llm.Message {
+	fresh := s.NewConversation()
+	if len(history) < chatHeaderLen {
+		return fresh
+	}
+
+	refreshed := append([]llm.Message{}, history...)
+	refreshed[chatSystemIndex] = fresh[chatSystemIndex]
+	refreshed[chatSnapshotIndex] = fresh[chatSnapshotIndex]
+	return append(refreshed, llm.Message{
+		Role:    "system",
+		Content: fmt.Sprintf("(%s) システム状況レポートを最新の状態に更新しました。以降は更新後のレポートに基づいて回答してください。", time.Now().Format("15:04:05")),
+	})
+}
+
+// ChatStream は会話の続きをストリーミングで問い合わせます
+func (s *Service) ChatStream(ctx context.Context, history []llm.Message) (<-chan llm.GenerateResponseStream, error) {
+	return s.client.GenerateStream(ctx, trimHistory(history), s.Model)
+}
+
+// SaveConversation は会話をストアに保存し、会話のIDを返します
+func (s *Service) SaveConversation(title string, history []llm.Message) (int64, error) {
+	if s.store == nil {
+		return 0, fmt.Errorf("データベースが使えないため保存できません")
+	}
+	messages := make([]db.ConversationMessage, 0, len(history))
+	for _, msg := range history {
+		messages = append(messages, db.ConversationMessage{Role: msg.Role, Content: msg.Content})
+	}
+	return s.store.SaveConversation(title, s.Model, messages)
+}
+
+// trimHistory はシステムプロンプトとレポートを残したまま、古い会話を ChatHistoryLimit 件に切り詰めます
+func trimHistory(history []llm.Message) []llm.Message {
+	if len(history) <= chatHeaderLen+ChatHistoryLimit {
+		return history
+	}
+	// 回答やツールの結果だけが残らないよう、質問から始まるようにする
+	start := len(history) - ChatHistoryLimit
+	for start < len(history) && history[start].Role != "user" {
+		start++
+	}
+	// ツールの呼び出しが続いて質問が範囲外になった場合は、最後の質問から残す
+	if start == len(history) {
+		for start = len(history) - 1; start > chatHeaderLen && history[start].Role != "user"; start-- {
+		}
+	}
+	trimmed := append([]llm.Message{}, history[:chatHeaderLen]...)
+	return append(trimmed, history[start:]...)
+}
@@ -2,8 +2,13 @@ package ai
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/Masahide-S/bho_hacka_go/internal/config"
+	"github.com/Masahide-S/bho_hacka_go/internal/db"
 	"github.com/Masahide-S/bho_hacka_go/internal/llm"
+	"github.com/Masahide-S/bho_hacka_go/internal/logger"
 	// monitorパッケージの直接参照は削除し、llmパッケージ経由でデータ取得します
 )
 
@@ -12,27 +17,103 @@ const (
 	OllamaEndpoint = "http://localhost:11434"
 	// デフォルトモデル名
 	DefaultModelName = "llama3.2"
+	// ログのエラーを集計する期間
+	RecentErrorsWindow = 30 * time.Minute
 )
 
 // Service はAI機能を提供します
 type Service struct {
-	client *llm.OllamaClient
-	Model  string // 現在選択中のモデル
+	providers []llm.Provider // 設定したLLMサーバー（モデル選択に表示する順）
+	client    llm.Provider   // 現在選択中のプロバイダー
+	Model     string         // 現在選択中のモデル
+	store     *db.Store      // 保存したログのエラーをコンテキストに含めるため（nilなら含めない）
+	redactor  *llm.Redactor  // LLMに送る前にシークレットをマスクする
 }
 
-// NewService は新しいAIサービスを作成します
+// ProviderModels はプロバイダーごとの利用可能なモデルです
+type ProviderModels struct {
+	Provider string
+	Models   []string
+	Err      error
+}
+
+// NewService は設定（ai.providers）のLLMサーバーを使うAIサービスを作成します
 func NewService() *Service {
+	cfg := config.Load().AI
+
+	var providers []llm.Provider
+	for _, p := range cfg.Providers {
+		provider, err := llm.NewProvider(p.Type, p.Name, p.Endpoint, p.APIKey)
+		if err != nil {
+			logger.LogIssue("CONFIG_ERROR", err.Error())
+			continue
+		}
+		providers = append(providers, provider)
+	}
+	if len(providers) == 0 {
+		providers = append(providers, llm.NewOllamaClient(OllamaEndpoint))
+	}
+
+	s := NewServiceWithProviders(providers...)
+	redactor, err := llm.NewRedactor(cfg.Redaction.Patterns, cfg.Redaction.HashHomePaths)
+	if err != nil {
+		logger.LogIssue("CONFIG_ERROR", err.Error())
+	}
+	s.redactor = redactor
+	if cfg.Provider != "" {
+		if err := s.SelectModel(cfg.Provider, s.Model); err != nil {
+			logger.LogIssue("CONFIG_ERROR", err.Error())
+		}
+	}
+	if cfg.Model != "" {
+		s.Model = cfg.Model
+	}
+	return s
+}
+
+// NewServiceWithEndpoint は指定したOllamaのURLに接続するAIサービスを作成します
+func NewServiceWithEndpoint(endpoint string) *Service {
+	return NewServiceWithProviders(llm.NewOllamaClient(endpoint))
+}
+
+// NewServiceWithProviders は指定したプロバイダーを使うAIサービスを作成します（先頭のプロバイダーを選択）
+func NewServiceWithProviders(providers ...llm.Provider) *Service {
+	redactor, _ := llm.NewRedactor(nil, false)
 	return &Service{
-		client: llm.NewOllamaClient(OllamaEndpoint),
-		Model:  DefaultModelName,
+		providers: providers,
+		client:    providers[0],
+		Model:     DefaultModelName,
+		redactor:  redactor,
 	}
 }
 
-// SetModel は使用するモデルを変更します
+// SetModel は使用するモデルを変更します（プロバイダーはそのまま）
 func (s *Service) SetModel(model string) {
 	s.Model = model
 }
 
+// SelectModel は使用するプロバイダーとモデルを変更します
+func (s *Service) SelectModel(provider, model string) error {
+	for _, p := range s.providers {
+		if p.Name() == provider {
+			s.client = p
+			s.Model = model
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown AI provider: %s", provider)
+}
+
+// GetProvider は現在使用中のプロバイダー名を取得します
+func (s *Service) GetProvider() string {
+	return s.client.Name()
+}
+
+// SetStore はログのエラーを読み出すストアを設定します
+func (s *Service) SetStore(store *db.Store) {
+	s.store = store
+}
+
 // GetModel は現在使用中のモデル名を取得します
 func (s *Service) GetModel() string {
 	return s.Model
@@ -41,6 +122,12 @@ func (s *Service) GetModel() string {
 // BuildSystemContext は現在のシステム状態を収集し、システムプロンプトとユーザーコンテキストを返します
 // 変更点: internal/llm/data.go の CollectAllContext を使用し、JSON形式で情報を渡すように変更
 func (s *Service) BuildSystemContext() (string, string) {
+	systemPrompt, userContext, _ := s.buildSystemContext()
+	return systemPrompt, userContext
+}
+
+// buildSystemContext はシステムプロンプトと、シークレットをマスクしたユーザーコンテキスト、マスクした件数を返します
+func (s *Service) buildSystemContext() (string, string, llm.RedactionReport) {
 	// 1. システムプロンプト（デモ用・強力なコマンド誘導）
 	systemPrompt := `あなたは開発環境のトラブルシューティングを行う優秀なDevOpsアシスタントです。
 ユーザーから提供される「システム状況レポート(JSON形式)」を分析し、以下の手順で回答してください。
@@ -49,6 +136,7 @@ func (s *Service) BuildSystemContext() (string, string) {
    - 停止しているコンテナ (Status: Exitedなど)
    - エラーが出ているデータベース
    - 異常にCPU/メモリを消費しているプロセス
+   - ログに出ている最近のエラー (recent_errors、"new": true は初めて出たエラー)
    これらがないか確認してください。
 
 2. **報告**:
@@ -58,6 +146,10 @@ func (s *Service) BuildSystemContext() (string, string) {
    - 問題を解決するために実行すべきコマンドを **1つだけ** 提案してください。
    - **コマンドは必ず <cmd> と </cmd> のタグで囲んでください。** これによりUIが自動的に実行ボタンを表示します。
 
+4. **追加の質問**:
+   - 続けて質問された場合は、レポートとそれまでの会話を踏まえて **日本語で簡潔に** 答えてください。
+   - コマンドが必要な場合のみ、同じように <cmd> タグで1つだけ提案してください。
+
 ---
 **回答例1 (コンテナ停止時):**
 PostgreSQLのコンテナが停止しています。再起動が必要です。
@@ -75,12 +167,27 @@ PostgreSQLのコンテナが停止しています。再起動が必要です。
 	fullCtx, err := llm.CollectAllContext()
 
 	var userContext string
+	var report llm.RedactionReport
 	if err != nil {
 		// 万が一収集に失敗した場合のフォールバック
 		userContext = "システム情報の取得に失敗しました: " + err.Error()
 	} else {
+		// 保存しているログから直近のエラーを加える（LLMがログの内容を見られるように）
+		if s.store != nil {
+			if recent, err := llm.CollectRecentErrorsContext(s.store, RecentErrorsWindow); err == nil && len(recent.Sources) > 0 {
+				fullCtx.RecentErrors = recent
+			}
+		}
+
+		// トークンやパスワードをLLMに送らないよう、マスクしてからJSON化する（失敗した場合は送らない）
+		redacted, redactions, err := s.redactor.RedactContext(fullCtx)
+		if err != nil {
+			return systemPrompt, "システム情報のマスク処理に失敗しました: " + err.Error(), nil
+		}
+		report = redactions
+
 		// JSON化してLLMに渡す（構造化データの方がLLMの理解度が高い）
-		jsonStr, err := llm.FormatAsJSON(fullCtx)
+		jsonStr, err := llm.FormatAsJSON(redacted)
 		if err != nil {
 			userContext = "コンテキストのJSON変換に失敗しました"
 		} else {
@@ -88,7 +195,7 @@ PostgreSQLのコンテナが停止しています。再起動が必要です。
 		}
 	}
 
-	return systemPrompt, userContext
+	return systemPrompt, userContext, report
 }
 
 // buildMessages はシステムプロンプトとユーザー入力をチャットメッセージ形式に変換します
@@ -122,12 +229,22 @@ func (s *Service) AnalyzeStream(ctx context.Context, systemPrompt, userPrompt st
 	return s.client.GenerateStream(ctx, msgs, s.Model)
 }
 
-// CheckHealth はOllamaサーバーへの接続を確認します
+// CheckHealth は選択中のプロバイダーへの接続を確認します
 func (s *Service) CheckHealth(ctx context.Context) error {
 	return s.client.CheckHealth(ctx)
 }
 
-// ListModels は利用可能なモデル一覧を取得します
+// ListModels は選択中のプロバイダーの利用可能なモデル一覧を取得します
 func (s *Service) ListModels(ctx context.Context) ([]string, error) {
 	return s.client.ListModels(ctx)
 }
+
+// ListProviderModels はすべてのプロバイダーの利用可能なモデル一覧を取得します
+func (s *Service) ListProviderModels(ctx context.Context) []ProviderModels {
+	results := make([]ProviderModels, len(s.providers))
+	for i, p := range s.providers {
+		models, err := p.ListModels(ctx)
+		results[i] = ProviderModels{Provider: p.Name(), Models: models, Err: err}
+	}
+	return results
+}
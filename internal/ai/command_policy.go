@@ -0,0 +1,796 @@
+package ai
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/config"
+)
+
+// RiskLevel is the risk of running an AI-suggested command
+type RiskLevel int
+
+const (
+	RiskSafe      RiskLevel = iota // 読み取りのみ、または設定で許可されたコマンド
+	RiskCaution                    // システムの状態を変更する可能性がある
+	RiskDangerous                  // データの削除など取り消せない操作（"yes" の入力が必要）
+)
+
+// String returns the label shown in the confirm UI
+func (r RiskLevel) String() string {
+	switch r {
+	case RiskSafe:
+		return "安全"
+	case RiskDangerous:
+		return "危険"
+	}
+	return "注意"
+}
+
+// CommandAssessment is the result of classifying a command
+type CommandAssessment struct {
+	Command string
+	Risk    RiskLevel
+	Reasons []string
+	DryRun  string // 変更を加えずに対象を確認するコマンド（用意できない場合は空）
+}
+
+// commandSegment は ; && || | で区切られた1つのコマンド
+type commandSegment struct {
+	words []string
+	piped bool // 前のコマンドの出力をパイプで受け取る
+}
+
+// parsedCommand はシェルのコマンド文字列を解析した結果
+type parsedCommand struct {
+	segments      []commandSegment
+	substitutions []string // $(...)、`...`、<(...) の中のコマンド
+	writes        []string // > や >> の書き込み先
+	unterminated  bool     // クォートや置換が閉じていない
+}
+
+// 組み込みの判定に使うコマンド
+var (
+	// シェルやインタプリタ（パイプで受け取った内容を実行すると危険）
+	shellPrograms = map[string]bool{
+		"sh": true, "bash": true, "zsh": true, "dash": true, "ksh": true, "fish": true,
+		"python": true, "python3": true, "perl": true, "ruby": true, "node": true,
+	}
+	// 先頭に付けても実行するコマンドは変わらないもの（オプションと数値の引数の後が実際のコマンド）
+	wrapperPrograms = map[string]bool{
+		"env": true, "nohup": true, "time": true, "command": true, "nice": true,
+		"xargs": true, "timeout": true, "exec": true, "sudo": true, "doas": true,
+	}
+	// ラッパーのオプションのうち、次の語を値として取るもの
+	wrapperValueFlags = map[string]map[string]bool{
+		"env":     {"-u": true, "--unset": true, "-C": true, "--chdir": true, "-S": true, "--split-string": true},
+		"nice":    {"-n": true, "--adjustment": true},
+		"timeout": {"-s": true, "--signal": true, "-k": true, "--kill-after": true},
+		"xargs":   {"-I": true, "-n": true, "-P": true, "-L": true, "-d": true, "-E": true, "-s": true, "-a": true, "--max-args": true, "--max-procs": true, "--delimiter": true, "--arg-file": true},
+		"exec":    {"-a": true},
+		"sudo":    {"-u": true, "--user": true, "-g": true, "--group": true, "-U": true, "-C": true, "-h": true, "--host": true, "-p": true, "--prompt": true, "-D": true, "--chdir": true, "-r": true, "-t": true},
+		"doas":    {"-u": true, "-C": true},
+	}
+	// timeout 5 や timeout 1.5m のような数値の引数
+	wrapperNumericArg = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?[smhd]?$`)
+	// 読み取りのみのコマンド
+	readOnlyPrograms = map[string]bool{
+		"ls": true, "cat": true, "head": true, "tail": true, "grep": true, "wc": true,
+		"df": true, "du": true, "free": true, "uptime": true, "ps": true, "pgrep": true,
+		"lsof": true, "netstat": true, "ss": true, "whoami": true, "id": true, "date": true,
+		"echo": true, "pwd": true, "which": true, "uname": true, "stat": true, "file": true,
+		"vm_stat": true, "journalctl": true, "printenv": true, "find": true, "sort": true,
+		"uniq": true, "jq": true, "nslookup": true, "dig": true, "ping": true,
+	}
+	// サブコマンドが読み取りのみのもの
+	readOnlySubcommands = map[string]map[string]bool{
+		"docker":         {"ps": true, "logs": true, "inspect": true, "images": true, "top": true, "port": true, "version": true, "info": true, "stats": true, "config": true, "ls": true},
+		"podman":         {"ps": true, "logs": true, "inspect": true, "images": true, "top": true, "port": true, "version": true, "info": true, "stats": true},
+		"docker-compose": {"ps": true, "logs": true, "config": true, "top": true, "ls": true},
+		"git":            {"status": true, "log": true, "diff": true, "show": true},
+		"systemctl":      {"status": true, "is-active": true, "list-units": true},
+		"brew":           {"list": true, "info": true},
+		"kubectl":        {"get": true, "describe": true, "logs": true, "top": true},
+	}
+
+	destructiveSQLRegex = regexp.MustCompile(`(?i)\b(drop\s+(table|database|schema|index|user|role)|truncate\b|delete\s+from\b|dropdatabase\s*\()`)
+)
+
+// AssessCommand classifies an AI-suggested command with the rules in the config
+func AssessCommand(command string) CommandAssessment {
+	return assessCommand(command, config.Load().AI.Commands)
+}
+
+// assessCommand classifies a command
+// 拒否ルールは常に優先し、許可ルールは「注意」のコマンドだけを「安全」にする
+func assessCommand(command string, policy config.AICommandPolicy) CommandAssessment {
+	assessment := CommandAssessment{Command: command, Risk: RiskSafe}
+	raise := func(risk RiskLevel, reason string) {
+		if risk > assessment.Risk {
+			assessment.Risk = risk
+		}
+		if reason == "" {
+			return
+		}
+		for _, r := range assessment.Reasons {
+			if r == reason {
+				return
+			}
+		}
+		assessment.Reasons = append(assessment.Reasons, reason)
+	}
+
+	parsed := parseCommand(command)
+	if len(parsed.segments) == 0 {
+		raise(RiskCaution, "実行するコマンドがありません")
+		return assessment
+	}
+	if parsed.unterminated {
+		raise(RiskCaution, "クォートが閉じていないため正しく解析できません")
+	}
+	// 置換の中のコマンドも実行されるため、同じルールで判定する
+	for _, body := range parsed.substitutions {
+		raise(RiskCaution, "コマンド置換の結果を引数として使います")
+		if strings.TrimSpace(body) == "" {
+			continue
+		}
+		innerAssessment := assessCommand(body, policy)
+		raise(innerAssessment.Risk, "")
+		for _, reason := range innerAssessment.Reasons {
+			raise(innerAssessment.Risk, reason)
+		}
+	}
+	for _, target := range parsed.writes {
+		if target != "/dev/null" {
+			raise(RiskCaution, "リダイレクトでファイルに書き込みます: "+target)
+		}
+	}
+
+	for i, segment := range parsed.segments {
+		wrappers, words := splitCommandWrappers(segment.words)
+		if containsWord(wrappers, "sudo") || containsWord(wrappers, "doas") {
+			raise(RiskDangerous, "管理者権限で実行します")
+		}
+		if len(words) == 0 {
+			continue
+		}
+		program := words[0]
+
+		if isSubstitutionWord(program) {
+			raise(RiskDangerous, "コマンド置換の結果をコマンドとして実行します")
+			continue
+		}
+		if (shellPrograms[program] || program == "source" || program == ".") && containsProcessSubstitution(words[1:]) {
+			raise(RiskDangerous, "プロセス置換の出力を "+program+" で実行します")
+			continue
+		}
+
+		if rule := matchCommandRule(words, policy.Deny); rule != "" {
+			raise(RiskDangerous, "設定で拒否されているコマンドです (deny: "+rule+")")
+			continue
+		}
+		if segment.piped && shellPrograms[program] && i > 0 {
+			if prev := normalizeCommandWords(parsed.segments[i-1].words); len(prev) > 0 && (prev[0] == "curl" || prev[0] == "wget") {
+				raise(RiskDangerous, "ダウンロードしたスクリプトをそのまま実行します")
+			} else {
+				raise(RiskDangerous, "パイプで受け取った内容を "+program+" で実行します")
+			}
+			continue
+		}
+		if inner, ok := shellScriptArg(words); ok {
+			// sh -c "..." は中身を同じルールで判定する
+			innerAssessment := assessCommand(inner, policy)
+			raise(innerAssessment.Risk, "")
+			for _, reason := range innerAssessment.Reasons {
+				raise(innerAssessment.Risk, reason)
+			}
+			continue
+		}
+		if reason := dangerousCommandReason(words); reason != "" {
+			raise(RiskDangerous, reason)
+			continue
+		}
+		if rule := matchCommandRule(words, policy.Allow); rule != "" {
+			continue
+		}
+		if !isReadOnlyCommand(words) {
+			raise(RiskCaution, "システムの状態を変更する可能性があります: "+program)
+		}
+	}
+
+	if assessment.Risk != RiskSafe && len(parsed.segments) == 1 && len(parsed.substitutions) == 0 && len(parsed.writes) == 0 {
+		assessment.DryRun = dryRunCommand(normalizeCommandWords(parsed.segments[0].words))
+	}
+	return assessment
+}
+
+// parseCommand splits a shell command into commands, honouring quotes
+func parseCommand(command string) parsedCommand {
+	runes := []rune(command)
+	var (
+		parsed        parsedCommand
+		words         []string
+		word          strings.Builder
+		inWord        bool
+		quote         rune
+		piped         bool
+		nextPiped     bool
+		redirect      bool // 次の語はリダイレクト先
+		redirectWrite bool
+	)
+
+	endWord := func() {
+		if !inWord {
+			return
+		}
+		w := word.String()
+		word.Reset()
+		inWord = false
+		if redirect {
+			if redirectWrite {
+				parsed.writes = append(parsed.writes, w)
+			}
+			redirect = false
+			return
+		}
+		words = append(words, w)
+	}
+	// substitute reads the substitution starting at start into the current word and returns the index of its end
+	substitute := func(start, bodyStart int, closing rune) int {
+		end := substitutionEnd(runes, bodyStart, closing)
+		if end < 0 {
+			parsed.unterminated = true
+			end = len(runes)
+		}
+		parsed.substitutions = append(parsed.substitutions, string(runes[bodyStart:end]))
+		word.WriteString(string(runes[start:min(end+1, len(runes))]))
+		inWord = true
+		return end
+	}
+
+	endSegment := func() {
+		endWord()
+		if len(words) > 0 {
+			parsed.segments = append(parsed.segments, commandSegment{words: words, piped: piped})
+		}
+		words = nil
+		piped = nextPiped
+		nextPiped = false
+	}
+
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		next := rune(0)
+		if i+1 < len(runes) {
+			next = runes[i+1]
+		}
+
+		if quote == '\'' {
+			if c == '\'' {
+				quote = 0
+			} else {
+				word.WriteRune(c)
+			}
+			continue
+		}
+		if quote == '"' {
+			switch {
+			case c == '"':
+				quote = 0
+			case c == '\\' && next != 0:
+				word.WriteRune(next)
+				i++
+			case c == '`':
+				i = substitute(i, i+1, '`')
+			case c == '$' && next == '(':
+				i = substitute(i, i+2, ')')
+			default:
+				word.WriteRune(c)
+			}
+			continue
+		}
+
+		switch {
+		case c == '\'' || c == '"':
+			quote = c
+			inWord = true
+		case c == '\\' && next != 0:
+			word.WriteRune(next)
+			inWord = true
+			i++
+		case c == '`':
+			i = substitute(i, i+1, '`')
+		case c == '$' && next == '(':
+			i = substitute(i, i+2, ')')
+		case c == ' ' || c == '\t':
+			endWord()
+		case c == ';' || c == '\n':
+			endSegment()
+		case c == '|':
+			if next == '|' {
+				i++
+			} else {
+				if next == '&' {
+					i++
+				}
+				nextPiped = true
+			}
+			endSegment()
+		case c == '&':
+			if next == '&' {
+				i++
+			}
+			endSegment()
+		case (c == '>' || c == '<') && next == '(':
+			// <(...) のプロセス置換
+			i = substitute(i, i+2, ')')
+		case c == '>' || c == '<':
+			// 2>&1 のようなファイルディスクリプタの指定は語に含めない
+			if inWord && isDigits(word.String()) {
+				word.Reset()
+				inWord = false
+			}
+			endWord()
+			redirectWrite = c == '>'
+			if next == '>' {
+				i++
+			}
+			if i+1 < len(runes) && runes[i+1] == '&' {
+				// >&2 はファイルへの書き込みではない
+				i++
+				for i+1 < len(runes) && runes[i+1] >= '0' && runes[i+1] <= '9' {
+					i++
+				}
+				continue
+			}
+			redirect = true
+		default:
+			word.WriteRune(c)
+			inWord = true
+		}
+	}
+	parsed.unterminated = quote != 0
+	endSegment()
+	return parsed
+}
+
+// substitutionEnd returns the index of the ) or ` closing the substitution whose body starts at start (-1 if not closed)
+func substitutionEnd(runes []rune, start int, closing rune) int {
+	depth := 0
+	var quote rune
+	for i := start; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else if c == '\\' && quote == '"' {
+				i++
+			}
+		case c == '\\':
+			i++
+		case c == closing && (closing == '`' || depth == 0):
+			return i
+		case c == '\'' || c == '"':
+			quote = c
+		case closing == ')' && c == '(':
+			depth++
+		case closing == ')' && c == ')':
+			depth--
+		}
+	}
+	return -1
+}
+
+// normalizeCommandWords drops environment assignments and wrappers such as env or nohup, and strips the program path
+func normalizeCommandWords(words []string) []string {
+	_, words = splitCommandWrappers(words)
+	return words
+}
+
+// splitCommandWrappers returns the wrappers (env, sudo, xargs など) and the command they run, with the program path stripped
+func splitCommandWrappers(words []string) ([]string, []string) {
+	var wrappers []string
+	for len(words) > 0 {
+		w := words[0]
+		if isAssignmentWord(w) {
+			words = words[1:]
+			continue
+		}
+		name := filepath.Base(w)
+		if !wrapperPrograms[name] {
+			break
+		}
+		wrappers = append(wrappers, name)
+		words = skipWrapperArgs(name, words[1:])
+	}
+	if len(words) == 0 {
+		return wrappers, nil
+	}
+	if isSubstitutionWord(words[0]) {
+		// $(echo /bin/rm) のパスを取り除くと置換であることがわからなくなる
+		return wrappers, words
+	}
+	return wrappers, append([]string{filepath.Base(words[0])}, words[1:]...)
+}
+
+// skipWrapperArgs drops the options and numeric arguments of a wrapper (env -i、timeout 5、nice -n 10 など)
+func skipWrapperArgs(wrapper string, args []string) []string {
+	for len(args) > 0 {
+		a := args[0]
+		switch {
+		case a == "--":
+			return args[1:]
+		case strings.HasPrefix(a, "-") && a != "-":
+			args = args[1:]
+			if wrapperValueFlags[wrapper][a] && len(args) > 0 {
+				args = args[1:]
+			}
+		case wrapperNumericArg.MatchString(a):
+			args = args[1:]
+		case wrapper == "env" && isAssignmentWord(a):
+			args = args[1:]
+		default:
+			return args
+		}
+	}
+	return nil
+}
+
+// isAssignmentWord reports whether w is an environment assignment like KEY=value
+func isAssignmentWord(w string) bool {
+	eq := strings.Index(w, "=")
+	return eq > 0 && !strings.HasPrefix(w, "-") && !strings.ContainsAny(w[:eq], "/$`")
+}
+
+// isSubstitutionWord reports whether the word starts with a command substitution
+func isSubstitutionWord(w string) bool {
+	return strings.HasPrefix(w, "$(") || strings.HasPrefix(w, "`")
+}
+
+// containsProcessSubstitution reports whether the arguments contain <(...) or >(...)
+func containsProcessSubstitution(args []string) bool {
+	for _, a := range args {
+		if strings.HasPrefix(a, "<(") || strings.HasPrefix(a, ">(") {
+			return true
+		}
+	}
+	return false
+}
+
+// matchCommandRule returns the first rule matching the command
+// ルールのオプション（-で始まる語）は順序を問わず含まれていれば、それ以外の語は先頭から順に一致すればよい
+func matchCommandRule(words []string, rules []string) string {
+	args := nonOptionArgs(words)
+	for _, rule := range rules {
+		ruleWords := strings.Fields(rule)
+		if len(ruleWords) == 0 || ruleWords[0] != words[0] {
+			continue
+		}
+		matched := true
+		pos := 0
+		for _, rw := range ruleWords[1:] {
+			if strings.HasPrefix(rw, "-") {
+				if !containsWord(words[1:], rw) {
+					matched = false
+					break
+				}
+				continue
+			}
+			if pos >= len(args) || args[pos] != rw {
+				matched = false
+				break
+			}
+			pos++
+		}
+		if matched {
+			return rule
+		}
+	}
+	return ""
+}
+
+// shellScriptArg returns the script of "sh -c script"
+func shellScriptArg(words []string) (string, bool) {
+	if !shellPrograms[words[0]] || words[0] == "python" || words[0] == "python3" || words[0] == "node" || words[0] == "perl" || words[0] == "ruby" {
+		return "", false
+	}
+	for i, w := range words[1:] {
+		if w == "-c" && i+2 < len(words) {
+			return words[i+2], true
+		}
+	}
+	return "", false
+}
+
+// dangerousCommandReason returns why a command is dangerous, or "" if it is not
+func dangerousCommandReason(words []string) string {
+	program, args := words[0], words[1:]
+	sub := nonOptionArgs(words)
+
+	switch program {
+	case "rm":
+		if hasFlag(args, 'r', "--recursive") || hasFlag(args, 'R', "--recursive") {
+			if hasFlag(args, 'f', "--force") {
+				return "rm -rf でファイルを再帰的に強制削除します"
+			}
+		}
+	case "dropdb":
+		return "データベースを削除します"
+	case "dropuser":
+		return "データベースのユーザーを削除します"
+	case "dd":
+		return "ディスクやファイルを直接書き換えます"
+	case "shutdown", "reboot", "halt", "poweroff":
+		return "マシンを停止・再起動します"
+	case "sudo", "su", "doas":
+		return "管理者権限で実行します"
+	case "eval":
+		return "文字列をコマンドとして実行します"
+	case "chmod", "chown", "chgrp":
+		if hasFlag(args, 'R', "--recursive") {
+			return "権限や所有者を再帰的に変更します"
+		}
+	case "git":
+		if len(sub) == 0 {
+			break
+		}
+		switch sub[0] {
+		case "reset":
+			if containsWord(args, "--hard") {
+				return "git reset --hard でコミットしていない変更を破棄します"
+			}
+		case "clean":
+			if hasFlag(args, 'f', "--force") {
+				return "git clean で追跡していないファイルを削除します"
+			}
+		case "push":
+			if hasFlag(args, 'f', "--force") || containsWordPrefix(args, "--force-with-lease") {
+				return "git push --force でリモートの履歴を書き換えます"
+			}
+		}
+	case "docker", "podman", "docker-compose":
+		if containsWord(sub, "prune") {
+			return "未使用のコンテナ・イメージ・ボリュームをまとめて削除します"
+		}
+		if len(sub) >= 2 && sub[0] == "volume" && (sub[1] == "rm" || sub[1] == "remove") {
+			return "ボリュームとそのデータを削除します"
+		}
+		if (containsWord(sub, "rm") || containsWord(sub, "rmi")) && hasFlag(args, 'f', "--force") {
+			return "実行中でも強制的に削除します"
+		}
+		if containsWord(sub, "down") && hasFlag(args, 'v', "--volumes") {
+			return "down -v でボリュームとそのデータを削除します"
+		}
+	case "kubectl":
+		if len(sub) > 0 && sub[0] == "delete" {
+			return "Kubernetesのリソースを削除します"
+		}
+	case "psql", "mysql", "mariadb", "sqlite3", "mongosh", "mongo":
+		if destructiveSQLRegex.MatchString(strings.Join(args, " ")) {
+			return "データを削除するクエリを実行します"
+		}
+	case "redis-cli":
+		for _, a := range args {
+			if strings.EqualFold(a, "flushall") || strings.EqualFold(a, "flushdb") {
+				return "Redisのデータをすべて削除します"
+			}
+		}
+	case "kill", "pkill", "killall":
+		for i, a := range args {
+			if a == "-9" || a == "-KILL" || a == "-SIGKILL" || ((a == "-s" || a == "--signal") && i+1 < len(args) && strings.TrimPrefix(strings.ToUpper(args[i+1]), "SIG") == "KILL") {
+				return "SIGKILLで強制終了します（終了処理が行われません）"
+			}
+		}
+	case "find":
+		if containsWord(args, "-delete") {
+			return "見つかったファイルを削除します"
+		}
+		for i, a := range args {
+			if (a == "-exec" || a == "-execdir") && i+1 < len(args) && filepath.Base(args[i+1]) == "rm" {
+				return "見つかったファイルを削除します"
+			}
+		}
+	}
+
+	if strings.HasPrefix(program, "mkfs") {
+		return "ファイルシステムを作成し、既存のデータを消去します"
+	}
+	return ""
+}
+
+// isReadOnlyCommand reports whether a command only reads the state
+func isReadOnlyCommand(words []string) bool {
+	program := words[0]
+	if readOnlyPrograms[program] {
+		return !(program == "find" && (containsWord(words, "-exec") || containsWord(words, "-execdir") || containsWord(words, "-delete")))
+	}
+	subcommands, ok := readOnlySubcommands[program]
+	if !ok {
+		return false
+	}
+	sub := nonOptionArgs(words)
+	// docker compose ps や docker container ls はその次の語で判定する
+	if len(sub) >= 2 && (program == "docker" || program == "podman") && (sub[0] == "compose" || sub[0] == "container" || sub[0] == "image" || sub[0] == "volume" || sub[0] == "network") {
+		sub = sub[1:]
+	}
+	return len(sub) > 0 && subcommands[sub[0]]
+}
+
+// dryRunCommand returns a command that shows what the given command would affect without changing anything
+func dryRunCommand(words []string) string {
+	if len(words) == 0 {
+		return ""
+	}
+	program, args := words[0], words[1:]
+	sub := nonOptionArgs(words)
+
+	switch program {
+	case "rm":
+		if len(sub) > 0 {
+			return joinCommand(append([]string{"ls", "-ld", "--"}, sub...))
+		}
+	case "kill":
+		var pids []string
+		for _, a := range sub {
+			if isDigits(a) {
+				pids = append(pids, a)
+			}
+		}
+		if len(pids) > 0 {
+			return joinCommand([]string{"ps", "-o", "pid,user,etime,command", "-p", strings.Join(pids, ",")})
+		}
+	case "pkill":
+		var rest []string
+		for i := 0; i < len(args); i++ {
+			a := args[i]
+			if a == "-s" || a == "--signal" {
+				i++
+				continue
+			}
+			if isSignalFlag(a) {
+				continue
+			}
+			rest = append(rest, a)
+		}
+		if len(rest) > 0 {
+			return joinCommand(append([]string{"pgrep", "-l"}, rest...))
+		}
+	case "killall":
+		var names []string
+		for _, a := range args {
+			if !strings.HasPrefix(a, "-") {
+				names = append(names, a)
+			}
+		}
+		if len(names) > 0 {
+			return joinCommand(append([]string{"pgrep", "-lx"}, names...))
+		}
+	case "git":
+		if len(sub) > 0 && sub[0] == "clean" {
+			dry := []string{"git", "clean", "-n"}
+			for _, a := range args {
+				switch {
+				case a == "clean" || a == "-f" || a == "--force":
+				case strings.HasPrefix(a, "-") && !strings.HasPrefix(a, "--"):
+					if flags := strings.ReplaceAll(a, "f", ""); flags != "-" {
+						dry = append(dry, flags)
+					}
+				default:
+					dry = append(dry, a)
+				}
+			}
+			return joinCommand(dry)
+		}
+	case "rsync":
+		return joinCommand(append([]string{"rsync", "--dry-run"}, args...))
+	case "make":
+		return joinCommand(append([]string{"make", "-n"}, args...))
+	case "kubectl":
+		if len(sub) > 0 && (sub[0] == "apply" || sub[0] == "delete" || sub[0] == "create") {
+			return joinCommand(append(append([]string{}, words...), "--dry-run=client"))
+		}
+	case "find":
+		if containsWord(args, "-delete") {
+			dry := []string{"find"}
+			for _, a := range args {
+				if a != "-delete" {
+					dry = append(dry, a)
+				}
+			}
+			return joinCommand(dry)
+		}
+	case "docker", "podman":
+		// コンテナを操作するコマンドは対象のコンテナの状態を表示する
+		if len(sub) >= 2 && sub[0] == "container" {
+			sub = sub[1:]
+		}
+		if len(sub) >= 2 {
+			switch sub[0] {
+			case "stop", "start", "restart", "rm", "kill", "pause", "unpause":
+				return joinCommand(append([]string{program, "inspect", "--format", "{{.Name}} {{.State.Status}}"}, sub[1:]...))
+			}
+		}
+	}
+	return ""
+}
+
+// nonOptionArgs returns the arguments after the program that do not start with "-"
+func nonOptionArgs(words []string) []string {
+	var args []string
+	for _, w := range words[1:] {
+		if !strings.HasPrefix(w, "-") {
+			args = append(args, w)
+		}
+	}
+	return args
+}
+
+// hasFlag reports whether args contain the short flag (alone or combined like -rf) or the long flag
+func hasFlag(args []string, short rune, long string) bool {
+	for _, a := range args {
+		if a == "--" {
+			return false
+		}
+		if a == long {
+			return true
+		}
+		if len(a) > 1 && a[0] == '-' && a[1] != '-' && strings.ContainsRune(a[1:], short) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSignalFlag reports whether a is a signal option of kill/pkill (-9, -KILL, -SIGTERM)
+func isSignalFlag(a string) bool {
+	if !strings.HasPrefix(a, "-") || len(a) < 2 {
+		return false
+	}
+	name := strings.TrimPrefix(a[1:], "SIG")
+	return isDigits(name) || (name == strings.ToUpper(name) && len(name) >= 3)
+}
+
+func containsWord(words []string, word string) bool {
+	for _, w := range words {
+		if w == word {
+			return true
+		}
+	}
+	return false
+}
+
+func containsWordPrefix(words []string, prefix string) bool {
+	for _, w := range words {
+		if strings.HasPrefix(w, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// joinCommand quotes the words that need it and joins them into a shell command
+func joinCommand(words []string) string {
+	quoted := make([]string, len(words))
+	for i, w := range words {
+		if w != "" && !strings.ContainsAny(w, " \t\n'\"\\$`|&;<>(){}*?[]#~!") {
+			quoted[i] = w
+			continue
+		}
+		quoted[i] = "'" + strings.ReplaceAll(w, "'", `'\''`) + "'"
+	}
+	return strings.Join(quoted, " ")
+}
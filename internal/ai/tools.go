@@ -0,0 +1,296 @@
+package ai
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/llm"
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor/logs"
+)
+
+// ツールの引数の上限（モデルが大きな値を指定してもコンテキストを使い切らないように）
+const (
+	toolDefaultLogLines   = 100
+	toolMaxLogLines       = 300
+	toolDefaultMinutes    = 30
+	toolMaxMinutes        = 3 * 24 * 60
+	toolMetricHistoryRows = 20 // メトリクスの履歴をこの点数に平均してまとめる
+)
+
+// agentTool is a read-only tool the model can call
+type agentTool struct {
+	def llm.Tool
+	run func(s *Service, args map[string]any) (string, error)
+}
+
+// agentTools はモデルに渡すツール（すべて読み取り専用）
+var agentTools = []agentTool{
+	{
+		def: llm.NewTool("get_container_logs",
+			"Get the latest log lines of a container. Use it when a container is unhealthy, restarting or reported in recent_errors.",
+			map[string]llm.ToolProperty{
+				"container":   {Type: "string", Description: "container name or ID"},
+				"lines":       {Type: "integer", Description: fmt.Sprintf("number of lines (default %d, max %d)", toolDefaultLogLines, toolMaxLogLines)},
+				"errors_only": {Type: "boolean", Description: "return only ERROR/WARN lines"},
+			}, "container"),
+		run: toolContainerLogs,
+	},
+	{
+		def: llm.NewTool("get_process_details",
+			"Get details of a process: command line, CPU, memory, threads, uptime, working directory, child processes and listening ports.",
+			map[string]llm.ToolProperty{
+				"pid": {Type: "string", Description: "process ID"},
+			}, "pid"),
+		run: toolProcessDetails,
+	},
+	{
+		def: llm.NewTool("query_metric_history",
+			"Get the history of system CPU/memory/disk usage, or of processes whose name contains the given text, averaged over time.",
+			map[string]llm.ToolProperty{
+				"minutes": {Type: "integer", Description: fmt.Sprintf("how far back to look in minutes (default %d, max %d)", toolDefaultMinutes, toolMaxMinutes)},
+				"process": {Type: "string", Description: "process name to filter by (omit for system-wide metrics)"},
+			}),
+		run: toolMetricHistory,
+	},
+	{
+		def: llm.NewTool("list_ports",
+			"List listening TCP ports with the owning process, PID, bind address and container.",
+			nil),
+		run: toolListPorts,
+	},
+	{
+		def: llm.NewTool("describe_postgres_database",
+			"Describe a PostgreSQL database: size, connections by state, and the largest tables with row estimates, dead rows and last vacuum/analyze.",
+			map[string]llm.ToolProperty{
+				"database": {Type: "string", Description: "database name"},
+			}, "database"),
+		run: toolDescribePostgres,
+	},
+}
+
+// toolDefinitions returns the definitions passed to the model
+func toolDefinitions() []llm.Tool {
+	defs := make([]llm.Tool, 0, len(agentTools))
+	for _, tool := range agentTools {
+		defs = append(defs, tool.def)
+	}
+	return defs
+}
+
+// runTool runs the tool the model called
+func (s *Service) runTool(call llm.ToolCall) (string, error) {
+	for _, tool := range agentTools {
+		if tool.def.Function.Name == call.Function.Name {
+			return tool.run(s, call.Function.Arguments)
+		}
+	}
+	return "", fmt.Errorf("unknown tool: %s", call.Function.Name)
+}
+
+// toolContainerLogs returns the latest log lines of a container
+func toolContainerLogs(s *Service, args map[string]any) (string, error) {
+	name := argString(args, "container")
+	if name == "" {
+		return "", fmt.Errorf("container is required")
+	}
+
+	var container *monitor.DockerContainer
+	containers := monitor.GetDockerContainers()
+	for i, c := range containers {
+		if c.Name == name || (len(name) >= 4 && strings.HasPrefix(c.ID, name)) {
+			container = &containers[i]
+			break
+		}
+	}
+	if container == nil {
+		return "", fmt.Errorf("container not found: %s", name)
+	}
+
+	lines := clampArg(argInt(args, "lines", toolDefaultLogLines), 1, toolMaxLogLines)
+	logLines, err := logs.GetContainerLogLines(container.ID, container.Name, lines)
+	if err != nil {
+		return "", err
+	}
+
+	errorsOnly := argBool(args, "errors_only")
+	var b strings.Builder
+	fmt.Fprintf(&b, "container %s (%s), status %s\n", container.Name, container.Image, container.Status)
+	shown := 0
+	for _, line := range logLines {
+		if errorsOnly && line.Level != logs.LevelError && line.Level != logs.LevelWarn {
+			continue
+		}
+		b.WriteString(line.Text)
+		b.WriteString("\n")
+		shown++
+	}
+	if shown == 0 {
+		b.WriteString("(no matching log lines)\n")
+	}
+	return b.String(), nil
+}
+
+// toolProcessDetails returns details of a process
+func toolProcessDetails(s *Service, args map[string]any) (string, error) {
+	detail, err := monitor.GetProcessDetail(argString(args, "pid"))
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "pid %s (parent %s), user %s, state %s, uptime %s\n", detail.PID, detail.PPID, detail.User, detail.State, detail.Elapsed)
+	fmt.Fprintf(&b, "cpu %.1f%%, memory %.1f%% (rss %d MB)", detail.CPU, detail.MemPerc, detail.RSSKB/1024)
+	if detail.Threads > 0 {
+		fmt.Fprintf(&b, ", threads %d", detail.Threads)
+	}
+	fmt.Fprintf(&b, "\ncommand: %s\n", detail.Command)
+	if detail.Cwd != "" {
+		fmt.Fprintf(&b, "cwd: %s\n", detail.Cwd)
+	}
+	if len(detail.Children) > 0 {
+		fmt.Fprintf(&b, "children: %s\n", strings.Join(detail.Children, ", "))
+	}
+	for _, port := range detail.Ports {
+		fmt.Fprintf(&b, "listening: %s:%s\n", port.BindAddress, port.Port)
+	}
+	return b.String(), nil
+}
+
+// toolMetricHistory returns the averaged history of system or process metrics
+func toolMetricHistory(s *Service, args map[string]any) (string, error) {
+	if s.store == nil {
+		return "", fmt.Errorf("metric history is not available (database is not open)")
+	}
+
+	minutes := clampArg(argInt(args, "minutes", toolDefaultMinutes), 1, toolMaxMinutes)
+	since := time.Now().Add(-time.Duration(minutes) * time.Minute)
+	bucket := time.Duration(minutes) * time.Minute / toolMetricHistoryRows
+	if bucket < time.Minute {
+		bucket = time.Minute
+	}
+	bucket = bucket.Truncate(time.Minute)
+
+	var b strings.Builder
+	if process := argString(args, "process"); process != "" {
+		points, err := s.store.GetProcessHistory(process, since, bucket)
+		if err != nil {
+			return "", err
+		}
+		if len(points) == 0 {
+			return fmt.Sprintf("no samples of processes matching %q in the last %d minutes\n", process, minutes), nil
+		}
+		fmt.Fprintf(&b, "process usage (average per %s):\n", bucket)
+		for _, p := range points {
+			fmt.Fprintf(&b, "%s %s cpu %.1f%% mem %d MB\n", p.Time.Local().Format("01-02 15:04"), p.Name, p.CPUUsage, p.Memory)
+		}
+		return b.String(), nil
+	}
+
+	points, err := s.store.GetMetricHistory(since, bucket)
+	if err != nil {
+		return "", err
+	}
+	if len(points) == 0 {
+		return fmt.Sprintf("no samples in the last %d minutes\n", minutes), nil
+	}
+	fmt.Fprintf(&b, "system usage (average per %s):\n", bucket)
+	for _, p := range points {
+		fmt.Fprintf(&b, "%s cpu %.1f%% mem %d/%d MB disk %.1f%%\n", p.Time.Local().Format("01-02 15:04"), p.CPUUsage, p.MemoryUsed, p.MemoryTotal, p.DiskUsage)
+	}
+	return b.String(), nil
+}
+
+// toolListPorts returns the listening ports
+func toolListPorts(s *Service, args map[string]any) (string, error) {
+	ports := monitor.GetListeningPorts()
+	if len(ports) == 0 {
+		return "no listening ports\n", nil
+	}
+	var b strings.Builder
+	for _, port := range ports {
+		fmt.Fprintf(&b, "%s:%s %s (pid %s)", port.BindAddress, port.Port, port.Process, port.PID)
+		// コンテナの公開ポートはコンテナ名（Composeの場合はプロジェクト名）が入る
+		if port.ProjectName != "" && port.ProjectName != port.Process {
+			fmt.Fprintf(&b, " container %s", port.ProjectName)
+		}
+		b.WriteString("\n")
+	}
+	return b.String(), nil
+}
+
+// toolDescribePostgres describes a PostgreSQL database
+func toolDescribePostgres(s *Service, args map[string]any) (string, error) {
+	desc, err := monitor.DescribePostgresDatabase(argString(args, "database"))
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "database %s, size %s\n", desc.Name, desc.Size)
+	if len(desc.Connections) > 0 {
+		states := make([]string, 0, len(desc.Connections))
+		for state, count := range desc.Connections {
+			states = append(states, fmt.Sprintf("%s %d", state, count))
+		}
+		sort.Strings(states)
+		fmt.Fprintf(&b, "connections: %s\n", strings.Join(states, ", "))
+	}
+	if len(desc.Tables) == 0 {
+		b.WriteString("no user tables\n")
+	}
+	for _, t := range desc.Tables {
+		fmt.Fprintf(&b, "%s.%s size %s rows ~%d dead %d last vacuum %s last analyze %s\n",
+			t.Schema, t.Name, t.Size, t.RowEstimate, t.DeadRows, t.LastVacuum, t.LastAnalyze)
+	}
+	return b.String(), nil
+}
+
+// argString reads a string argument (モデルによっては数値で渡されるため文字列に変換する)
+func argString(args map[string]any, key string) string {
+	switch v := args[key].(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return ""
+}
+
+// argInt reads an integer argument, returning def if it is missing or invalid
+func argInt(args map[string]any, key string, def int) int {
+	switch v := args[key].(type) {
+	case float64:
+		return int(v)
+	case string:
+		if n, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+			return n
+		}
+	}
+	return def
+}
+
+// argBool reads a boolean argument
+func argBool(args map[string]any, key string) bool {
+	switch v := args[key].(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	}
+	return false
+}
+
+// clampArg limits n to [min, max]
+func clampArg(n, min, max int) int {
+	if n < min {
+		return min
+	}
+	if n > max {
+		return max
+	}
+	return n
+}
@@ -0,0 +1,38 @@
+package db
+
+import (
+	"time"
+	"unicode/utf8"
+)
+
+// auditOutputMaxBytes は監査ログに残すコマンド出力の最大バイト数
+const auditOutputMaxBytes = 64 * 1024
+
+// CommandAudit はAIが提案して実行したコマンドの記録です
+type CommandAudit struct {
+	Command  string
+	Risk     string
+	DryRun   bool // ドライランとして実行した
+	ExitCode int  // 起動できなかった場合は -1
+	Output   string
+	Model    string // コマンドを提案したモデル
+}
+
+// SaveCommandAudit は実行したコマンドとその出力を記録します
+func (s *Store) SaveCommandAudit(audit CommandAudit) error {
+	output := audit.Output
+	if len(output) > auditOutputMaxBytes {
+		// 文字の途中で切らないようにする
+		n := auditOutputMaxBytes
+		for n > 0 && !utf8.RuneStart(output[n]) {
+			n--
+		}
+		output = output[:n] + "\n... (truncated)"
+	}
+	_, err := s.db.Exec(`
+		INSERT INTO ai_command_audit (executed_at, command, risk, dry_run, exit_code, output, model)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		time.Now().UTC(), audit.Command, audit.Risk, audit.DryRun, audit.ExitCode, output, audit.Model,
+	)
+	return err
+}
@@ -0,0 +1,90 @@
+package db
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
+)
+
+// SaveComposeProjects はComposeプロジェクトの設定を保存（同名は上書き）します
+func (s *Store) SaveComposeProjects(projects []monitor.ComposeProject) error {
+	if len(projects) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO compose_projects (name, working_dir, config_files, env_files, profiles, last_seen)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(name) DO UPDATE SET
+			working_dir = excluded.working_dir,
+			config_files = excluded.config_files,
+			env_files = excluded.env_files,
+			profiles = excluded.profiles,
+			last_seen = excluded.last_seen
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, p := range projects {
+		lastSeen := p.LastSeen
+		if lastSeen.IsZero() {
+			lastSeen = time.Now()
+		}
+		_, err := stmt.Exec(p.Name, p.WorkingDir, encodeList(p.ConfigFiles), encodeList(p.EnvFiles), encodeList(p.Profiles), lastSeen.UTC())
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetComposeProjects は記憶しているComposeプロジェクトを名前順で取得します
+func (s *Store) GetComposeProjects() ([]monitor.ComposeProject, error) {
+	rows, err := s.db.Query(`
+		SELECT name, working_dir, config_files, env_files, profiles, last_seen
+		FROM compose_projects ORDER BY name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var projects []monitor.ComposeProject
+	for rows.Next() {
+		var p monitor.ComposeProject
+		var configFiles, envFiles, profiles string
+		if err := rows.Scan(&p.Name, &p.WorkingDir, &configFiles, &envFiles, &profiles, &p.LastSeen); err != nil {
+			return nil, err
+		}
+		p.ConfigFiles = decodeList(configFiles)
+		p.EnvFiles = decodeList(envFiles)
+		p.Profiles = decodeList(profiles)
+		projects = append(projects, p)
+	}
+	return projects, rows.Err()
+}
+
+// リスト型のカラムはJSON配列の文字列として保存する
+func encodeList(values []string) string {
+	if len(values) == 0 {
+		return "[]"
+	}
+	data, _ := json.Marshal(values)
+	return string(data)
+}
+
+func decodeList(value string) []string {
+	var values []string
+	json.Unmarshal([]byte(value), &values)
+	return values
+}
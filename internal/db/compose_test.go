@@ -0,0 +1,29 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
+)
+
+func TestCleanupRemovesExpiredComposeProjects(t *testing.T) {
+	store := newTestStore(t)
+	projects := []monitor.ComposeProject{
+		{Name: "current", WorkingDir: "/srv/current", LastSeen: time.Now().Add(-time.Hour)},
+		{Name: "old", WorkingDir: "/srv/old", LastSeen: time.Now().Add(-monitor.ComposeProjectExpiry - time.Hour)},
+	}
+	if err := store.SaveComposeProjects(projects); err != nil {
+		t.Fatal(err)
+	}
+
+	store.cleanupOldData(3 * 24 * time.Hour)
+
+	got, err := store.GetComposeProjects()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Name != "current" {
+		t.Errorf("projects = %+v, want only the current one", got)
+	}
+}
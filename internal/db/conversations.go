@@ -0,0 +1,43 @@
+package db
+
+import (
+	"time"
+)
+
+// ConversationMessage は保存するAIとの会話の1メッセージです
+type ConversationMessage struct {
+	Role    string
+	Content string
+}
+
+// SaveConversation はAIとの会話を保存し、会話のIDを返します
+func (s *Store) SaveConversation(title, model string, messages []ConversationMessage) (int64, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`INSERT INTO ai_conversations (title, model, created_at) VALUES (?, ?, ?)`,
+		title, model, time.Now().UTC())
+	if err != nil {
+		return 0, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	stmt, err := tx.Prepare(`INSERT INTO ai_messages (conversation_id, position, role, content) VALUES (?, ?, ?, ?)`)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	for i, msg := range messages {
+		if _, err := stmt.Exec(id, i, msg.Role, msg.Content); err != nil {
+			return 0, err
+		}
+	}
+	return id, tx.Commit()
+}
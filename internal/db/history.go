@@ -0,0 +1,119 @@
+package db
+
+import (
+	"time"
+)
+
+// MetricPoint は一定期間ごとに平均したシステムメトリクスです
+type MetricPoint struct {
+	Time        time.Time
+	CPUUsage    float64
+	MemoryUsed  int64
+	MemoryTotal int64
+	DiskUsage   float64
+}
+
+// ProcessMetricPoint は一定期間ごとに平均したプロセスのCPU・メモリ使用量です
+type ProcessMetricPoint struct {
+	Time     time.Time
+	Name     string
+	CPUUsage float64
+	Memory   int64 // MB
+}
+
+// GetMetricHistory は since 以降のシステムメトリクスを bucket ごとに平均して古い順で取得します
+func (s *Store) GetMetricHistory(since time.Time, bucket time.Duration) ([]MetricPoint, error) {
+	rows, err := s.db.Query(`
+		SELECT timestamp, cpu_usage, memory_used, memory_total, disk_usage
+		FROM system_metrics WHERE timestamp >= ?
+		ORDER BY timestamp ASC
+	`, since.UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var points []MetricPoint
+	var sum MetricPoint
+	count := 0
+	flush := func() {
+		if count > 0 {
+			n := float64(count)
+			points = append(points, MetricPoint{
+				Time:        sum.Time,
+				CPUUsage:    sum.CPUUsage / n,
+				MemoryUsed:  sum.MemoryUsed / int64(count),
+				MemoryTotal: sum.MemoryTotal / int64(count),
+				DiskUsage:   sum.DiskUsage / n,
+			})
+		}
+		sum = MetricPoint{}
+		count = 0
+	}
+
+	for rows.Next() {
+		var p MetricPoint
+		if err := rows.Scan(&p.Time, &p.CPUUsage, &p.MemoryUsed, &p.MemoryTotal, &p.DiskUsage); err != nil {
+			return nil, err
+		}
+		start := p.Time.Truncate(bucket)
+		if count > 0 && !start.Equal(sum.Time) {
+			flush()
+		}
+		sum.Time = start
+		sum.CPUUsage += p.CPUUsage
+		sum.MemoryUsed += p.MemoryUsed
+		sum.MemoryTotal += p.MemoryTotal
+		sum.DiskUsage += p.DiskUsage
+		count++
+	}
+	flush()
+	return points, rows.Err()
+}
+
+// GetProcessHistory は名前に name を含むプロセスの since 以降の使用量を bucket ごとに平均して取得します
+// 同じ時刻に同名のプロセスが複数あれば合計する
+func (s *Store) GetProcessHistory(name string, since time.Time, bucket time.Duration) ([]ProcessMetricPoint, error) {
+	rows, err := s.db.Query(`
+		SELECT m.timestamp, p.process_name, SUM(p.cpu_usage), SUM(p.memory_usage)
+		FROM process_snapshots p JOIN system_metrics m ON p.metric_id = m.id
+		WHERE m.timestamp >= ? AND p.process_name LIKE ?
+		GROUP BY m.id, p.process_name
+		ORDER BY p.process_name, m.timestamp ASC
+	`, since.UTC(), "%"+name+"%")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var points []ProcessMetricPoint
+	var sum ProcessMetricPoint
+	count := 0
+	flush := func() {
+		if count > 0 {
+			sum.CPUUsage /= float64(count)
+			sum.Memory /= int64(count)
+			points = append(points, sum)
+		}
+		sum = ProcessMetricPoint{}
+		count = 0
+	}
+
+	for rows.Next() {
+		var p ProcessMetricPoint
+		if err := rows.Scan(&p.Time, &p.Name, &p.CPUUsage, &p.Memory); err != nil {
+			return nil, err
+		}
+		start := p.Time.Truncate(bucket)
+		if count > 0 && (!start.Equal(sum.Time) || p.Name != sum.Name) {
+			flush()
+		}
+		sum.Time = start
+		sum.Name = p.Name
+		sum.CPUUsage += p.CPUUsage
+		sum.Memory += p.Memory
+		count++
+	}
+	flush()
+	return points, rows.Err()
+}
@@ -0,0 +1,185 @@
+package db
+
+import (
+	"time"
+	"unicode/utf8"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor/logs"
+)
+
+// LogLinesPerSource はソースごとに保存しておくログの最大行数
+const LogLinesPerSource = 1000
+
+// logTextMaxBytes は1行として保存する最大サイズ
+const logTextMaxBytes = 4096
+
+// ErrorSignature はログに出たエラーの種類です
+type ErrorSignature struct {
+	Signature string
+	Source    string
+	Message   string // 最初に出たときのメッセージ
+	Count     int
+	FirstSeen time.Time
+	LastSeen  time.Time
+}
+
+// LogLevelCount はソースごとのエラー・警告の行数です
+type LogLevelCount struct {
+	Source   string
+	Errors   int
+	Warnings int
+}
+
+// SaveLogLines はソースのログを追記し、古い行を削除して LogLinesPerSource 行に収めます
+func (s *Store) SaveLogLines(source string, lines []logs.Line) error {
+	if len(lines) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`INSERT INTO log_lines (source, timestamp, level, text) VALUES (?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	// 上限を超える分は書き込んでもすぐ消えるため、新しい行だけを保存
+	if len(lines) > LogLinesPerSource {
+		lines = lines[len(lines)-LogLinesPerSource:]
+	}
+	for _, line := range lines {
+		text := line.Text
+		if len(text) > logTextMaxBytes {
+			// マルチバイト文字の途中で切らないよう、文字の先頭まで戻す
+			end := logTextMaxBytes
+			for end > 0 && !utf8.RuneStart(text[end]) {
+				end--
+			}
+			text = text[:end]
+		}
+		if _, err := stmt.Exec(source, line.Time.UTC(), int(line.Level), text); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(`
+		DELETE FROM log_lines WHERE source = ? AND id <= (
+			SELECT id FROM log_lines WHERE source = ? ORDER BY id DESC LIMIT 1 OFFSET ?
+		)
+	`, source, source, LogLinesPerSource)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// RecordErrorSignature はエラーの発生を記録し、初めて出たエラーかどうかを返します
+func (s *Store) RecordErrorSignature(event logs.ErrorEvent) (bool, error) {
+	seenAt := event.Time
+	if seenAt.IsZero() {
+		seenAt = time.Now()
+	}
+
+	// 確認と記録を1つの文で行う（同時に記録しても「初めて」になるのは1回だけ）
+	var count int
+	err := s.db.QueryRow(`
+		INSERT INTO error_signatures (signature, source, message, count, first_seen, last_seen)
+		VALUES (?, ?, ?, 1, ?, ?)
+		ON CONFLICT(signature) DO UPDATE SET
+			count = count + 1,
+			last_seen = excluded.last_seen
+		RETURNING count
+	`, event.Signature, event.Source, event.Message, seenAt.UTC(), seenAt.UTC()).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+	return count == 1, nil
+}
+
+// GetRecentErrorSignatures は since 以降に出たエラーの種類を新しい順で取得します
+func (s *Store) GetRecentErrorSignatures(since time.Time, limit int) ([]ErrorSignature, error) {
+	rows, err := s.db.Query(`
+		SELECT signature, source, message, count, first_seen, last_seen
+		FROM error_signatures WHERE last_seen >= ?
+		ORDER BY last_seen DESC LIMIT ?
+	`, since.UTC(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var signatures []ErrorSignature
+	for rows.Next() {
+		var sig ErrorSignature
+		if err := rows.Scan(&sig.Signature, &sig.Source, &sig.Message, &sig.Count, &sig.FirstSeen, &sig.LastSeen); err != nil {
+			return nil, err
+		}
+		signatures = append(signatures, sig)
+	}
+	return signatures, rows.Err()
+}
+
+// GetLogLevelCounts は since 以降のソースごとのエラー・警告の行数を取得します
+func (s *Store) GetLogLevelCounts(since time.Time) ([]LogLevelCount, error) {
+	rows, err := s.db.Query(`
+		SELECT source,
+			SUM(CASE WHEN level = ? THEN 1 ELSE 0 END),
+			SUM(CASE WHEN level = ? THEN 1 ELSE 0 END)
+		FROM log_lines WHERE timestamp >= ? AND level IN (?, ?)
+		GROUP BY source ORDER BY source
+	`, int(logs.LevelError), int(logs.LevelWarn), since.UTC(), int(logs.LevelError), int(logs.LevelWarn))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var counts []LogLevelCount
+	for rows.Next() {
+		var c LogLevelCount
+		if err := rows.Scan(&c.Source, &c.Errors, &c.Warnings); err != nil {
+			return nil, err
+		}
+		counts = append(counts, c)
+	}
+	return counts, rows.Err()
+}
+
+// GetRecentErrorLines は since 以降のERROR行をソースごとに最大 perSource 行（古い順）取得します
+func (s *Store) GetRecentErrorLines(since time.Time, perSource int) (map[string][]logs.Line, error) {
+	rows, err := s.db.Query(`
+		SELECT source, timestamp, text FROM log_lines
+		WHERE timestamp >= ? AND level = ?
+		ORDER BY id DESC LIMIT 500
+	`, since.UTC(), int(logs.LevelError))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[string][]logs.Line)
+	for rows.Next() {
+		var line logs.Line
+		if err := rows.Scan(&line.Source, &line.Time, &line.Text); err != nil {
+			return nil, err
+		}
+		if len(result[line.Source]) >= perSource {
+			continue
+		}
+		line.Level = logs.LevelError
+		result[line.Source] = append(result[line.Source], line)
+	}
+
+	// 新しい順に取得したので古い順に並べ直す
+	for source, lines := range result {
+		for i, j := 0, len(lines)-1; i < j; i, j = i+1, j-1 {
+			lines[i], lines[j] = lines[j], lines[i]
+		}
+		result[source] = lines
+	}
+	return result, rows.Err()
+}
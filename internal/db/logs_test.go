@@ -0,0 +1,95 @@
+package db
+
+import (
+	"database/sql"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor/logs"
+)
+
+// newTestStore returns a store backed by a database in a temporary directory
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+	// 同時に記録するテストのため、どの接続でもロックを待つようにする
+	conn, err := sql.Open("sqlite", "file:"+t.TempDir()+"/metrics.db?_pragma=busy_timeout(5000)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := &Store{db: conn}
+	if err := store.migrate(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestSaveLogLinesTruncatesAtRuneBoundary(t *testing.T) {
+	store := newTestStore(t)
+	// 上限の位置がマルチバイト文字の途中になる長い行
+	text := "x" + strings.Repeat("エラー", logTextMaxBytes)
+	now := time.Now()
+	if err := store.SaveLogLines("api", []logs.Line{{Text: text, Level: logs.LevelError, Time: now}}); err != nil {
+		t.Fatal(err)
+	}
+
+	lines, err := store.GetRecentErrorLines(now.Add(-time.Minute), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lines["api"]) != 1 {
+		t.Fatalf("got %d lines, want 1", len(lines["api"]))
+	}
+	saved := lines["api"][0].Text
+	if !utf8.ValidString(saved) {
+		t.Error("saved line is not valid UTF-8")
+	}
+	if len(saved) > logTextMaxBytes || !strings.HasPrefix(text, saved) || len(saved) < logTextMaxBytes-utf8.UTFMax {
+		t.Errorf("saved %d bytes, want a prefix of at most %d bytes", len(saved), logTextMaxBytes)
+	}
+}
+
+func TestRecordErrorSignatureReportsNewOnce(t *testing.T) {
+	store := newTestStore(t)
+	event := logs.ErrorEvent{Source: "api", Signature: "sig", Message: "connection refused", Time: time.Now()}
+
+	// 同時に記録しても「初めて」は1回だけ
+	const workers = 8
+	var wg sync.WaitGroup
+	results := make(chan bool, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			isNew, err := store.RecordErrorSignature(event)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			results <- isNew
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	newCount := 0
+	for isNew := range results {
+		if isNew {
+			newCount++
+		}
+	}
+	if newCount != 1 {
+		t.Errorf("%d calls reported a new error, want 1", newCount)
+	}
+
+	signatures, err := store.GetRecentErrorSignatures(time.Now().Add(-time.Minute), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(signatures) != 1 || signatures[0].Count != workers {
+		t.Errorf("signatures = %+v, want one with count %d", signatures, workers)
+	}
+}
@@ -0,0 +1,71 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
+)
+
+// SaveManagedProcess は devmon run で起動したプロセスを登録（同じコマンドは上書き）します
+func (s *Store) SaveManagedProcess(p monitor.ManagedProcess) error {
+	startedAt := p.StartedAt
+	if startedAt.IsZero() {
+		startedAt = time.Now()
+	}
+
+	_, err := s.db.Exec(`
+		INSERT INTO managed_processes (key, name, dir, command, pid, process_start, own_group, log_file, started_at, exited_at, exit_code)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, NULL, 0)
+		ON CONFLICT(key) DO UPDATE SET
+			name = excluded.name,
+			dir = excluded.dir,
+			command = excluded.command,
+			pid = excluded.pid,
+			process_start = excluded.process_start,
+			own_group = excluded.own_group,
+			log_file = excluded.log_file,
+			started_at = excluded.started_at,
+			exited_at = NULL,
+			exit_code = 0
+	`, p.Key, p.Name, p.Dir, encodeList(p.Command), p.PID, p.ProcessStart, p.OwnGroup, p.LogFile, startedAt.UTC())
+	return err
+}
+
+// FinishManagedProcess はプロセスの終了を記録します
+// 再起動で別のPIDに置き換わっている場合は新しいプロセスの記録を上書きしない
+func (s *Store) FinishManagedProcess(key string, pid, exitCode int, exitedAt time.Time) error {
+	_, err := s.db.Exec(`
+		UPDATE managed_processes SET exited_at = ?, exit_code = ?
+		WHERE key = ? AND pid = ?
+	`, exitedAt.UTC(), exitCode, key, pid)
+	return err
+}
+
+// GetManagedProcesses は devmon run で起動したプロセスを起動日時の新しい順で取得します
+func (s *Store) GetManagedProcesses() ([]monitor.ManagedProcess, error) {
+	rows, err := s.db.Query(`
+		SELECT key, name, dir, command, pid, process_start, own_group, log_file, started_at, exited_at, exit_code
+		FROM managed_processes ORDER BY started_at DESC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var processes []monitor.ManagedProcess
+	for rows.Next() {
+		var p monitor.ManagedProcess
+		var command string
+		var exitedAt sql.NullTime
+		if err := rows.Scan(&p.Key, &p.Name, &p.Dir, &command, &p.PID, &p.ProcessStart, &p.OwnGroup, &p.LogFile, &p.StartedAt, &exitedAt, &p.ExitCode); err != nil {
+			return nil, err
+		}
+		p.Command = decodeList(command)
+		if exitedAt.Valid {
+			p.ExitedAt = exitedAt.Time
+		}
+		processes = append(processes, p)
+	}
+	return processes, rows.Err()
+}
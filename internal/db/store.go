@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
 	_ "modernc.org/sqlite" // Pure Go SQLite driver
 )
 
@@ -60,6 +61,12 @@ func (s *Store) Close() error {
 func (s *Store) migrate() error {
 	// 親テーブル：システム全体のメトリクス
 	// 子テーブル：その時点でのプロセススナップショット
+	// compose_projects：停止中でも起動できるように記憶するComposeプロジェクト
+	// managed_processes：devmon run で起動したプロセス（同じコマンドで再起動できるように記憶する）
+	// log_lines：監視中のコンテナ・プロセスの直近のログ（ソースごとに件数を制限）
+	// error_signatures：ログに出たエラーの種類（初めて出たエラーの検知に使う）
+	// ai_conversations / ai_messages：保存したAIとの会話
+	// ai_command_audit：AIが提案して実行したコマンドとその出力（監査用に削除しない）
 	query := `
 	CREATE TABLE IF NOT EXISTS system_metrics (
 		id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -81,8 +88,78 @@ func (s *Store) migrate() error {
 		FOREIGN KEY(metric_id) REFERENCES system_metrics(id) ON DELETE CASCADE
 	);
 
+	CREATE TABLE IF NOT EXISTS compose_projects (
+		name TEXT PRIMARY KEY,
+		working_dir TEXT,
+		config_files TEXT,
+		env_files TEXT,
+		profiles TEXT,
+		last_seen DATETIME
+	);
+
+	CREATE TABLE IF NOT EXISTS managed_processes (
+		key TEXT PRIMARY KEY,
+		name TEXT,
+		dir TEXT,
+		command TEXT,
+		pid INTEGER,
+		process_start TEXT,
+		own_group BOOLEAN,
+		log_file TEXT,
+		started_at DATETIME,
+		exited_at DATETIME,
+		exit_code INTEGER
+	);
+
+	CREATE TABLE IF NOT EXISTS log_lines (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		source TEXT,
+		timestamp DATETIME,
+		level INTEGER,
+		text TEXT
+	);
+
+	CREATE TABLE IF NOT EXISTS error_signatures (
+		signature TEXT PRIMARY KEY,
+		source TEXT,
+		message TEXT,
+		count INTEGER,
+		first_seen DATETIME,
+		last_seen DATETIME
+	);
+
+	CREATE TABLE IF NOT EXISTS ai_conversations (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		title TEXT,
+		model TEXT,
+		created_at DATETIME
+	);
+
+	CREATE TABLE IF NOT EXISTS ai_messages (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		conversation_id INTEGER,
+		position INTEGER,
+		role TEXT,
+		content TEXT,
+		FOREIGN KEY(conversation_id) REFERENCES ai_conversations(id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS ai_command_audit (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		executed_at DATETIME,
+		command TEXT,
+		risk TEXT,
+		dry_run BOOLEAN,
+		exit_code INTEGER,
+		output TEXT,
+		model TEXT
+	);
+
 	CREATE INDEX IF NOT EXISTS idx_metrics_timestamp ON system_metrics(timestamp);
 	CREATE INDEX IF NOT EXISTS idx_snapshots_metric_id ON process_snapshots(metric_id);
+	CREATE INDEX IF NOT EXISTS idx_log_lines_source ON log_lines(source, id);
+	CREATE INDEX IF NOT EXISTS idx_log_lines_timestamp ON log_lines(timestamp);
+	CREATE INDEX IF NOT EXISTS idx_ai_messages_conversation ON ai_messages(conversation_id, position);
 	`
 	_, err := s.db.Exec(query)
 	return err
@@ -93,6 +170,9 @@ func (s *Store) cleanupOldData(retention time.Duration) {
 	s.db.Exec("PRAGMA foreign_keys = ON;")
 	hours := fmt.Sprintf("-%d hours", int(retention.Hours()))
 	s.db.Exec("DELETE FROM system_metrics WHERE timestamp < datetime('now', ?)", hours)
+	// 監視されなくなったソースのログも残り続けないように削除
+	s.db.Exec("DELETE FROM log_lines WHERE timestamp < datetime('now', ?)", hours)
+	s.db.Exec("DELETE FROM compose_projects WHERE last_seen < ?", time.Now().Add(-monitor.ComposeProjectExpiry).UTC())
 }
 
 // SaveMetric は現在のメトリクスを保存します（シンプル版）
@@ -38,6 +38,15 @@ type DockerContainer struct {
 	Ports   string `json:"ports"`
 	MemUsed string `json:"mem_used"` // docker statsから取得
 	CPUUsed string `json:"cpu_used"` // docker statsから取得
+
+	// docker inspectから取得した状態
+	State        string `json:"state"`                   // running / restarting / exited など
+	Health       string `json:"health,omitempty"`        // healthy / unhealthy / starting
+	HealthOutput string `json:"health_output,omitempty"` // 最後のヘルスチェックの出力
+	ExitCode     int    `json:"exit_code"`
+	OOMKilled    bool   `json:"oom_killed"`
+	RestartCount int    `json:"restart_count"`
+	Summary      string `json:"summary,omitempty"` // 例: "restarted 14 times, last exit 137 (OOM)"
 }
 
 // DockerContext はDocker関連情報を構造化して保持します
@@ -69,9 +78,11 @@ type PortInfo struct {
 
 // DatabaseContext はDB情報を保持します
 type DatabaseContext struct {
-	Postgres DBStatus `json:"postgres"`
-	MySQL    DBStatus `json:"mysql"`
-	Redis    DBStatus `json:"redis"`
+	Postgres      DBStatus `json:"postgres"`
+	MySQL         DBStatus `json:"mysql"`
+	Redis         DBStatus `json:"redis"`
+	MongoDB       DBStatus `json:"mongodb"`
+	Elasticsearch DBStatus `json:"elasticsearch"`
 }
 
 type DBStatus struct {
@@ -82,10 +93,12 @@ type DBStatus struct {
 
 // ProjectInfo は個別のプロジェクト情報を保持します
 type ProjectInfo struct {
-	Name         string   `json:"name"`
-	Type         string   `json:"type"` // "Node.js", "Go", "Python" etc.
-	Path         string   `json:"path"`
-	Dependencies []string `json:"dependencies"` // 主要な依存ライブラリ
+	Name             string   `json:"name"`
+	Type             string   `json:"type"` // "Node.js", "Go", "Python" etc.
+	Path             string   `json:"path"`
+	Dependencies     []string `json:"dependencies"`                // 主要な依存ライブラリ
+	Environment      string   `json:"environment,omitempty"`       // 実行環境（Pythonの仮想環境など）
+	DependencyIssues []string `json:"dependency_issues,omitempty"` // 依存定義とインストール済みパッケージの不一致
 }
 
 // ProjectContext はプロジェクト全体の情報を保持します
@@ -97,11 +110,12 @@ type ProjectContext struct {
 
 // FullContext は全ての情報を統合した構造体です
 type FullContext struct {
-	System   *SystemContext   `json:"system"`
-	Docker   *DockerContext   `json:"docker"`
-	Process  *ProcessContext  `json:"process"`
-	Database *DatabaseContext `json:"database"`
-	Project  *ProjectContext  `json:"project"`
+	System       *SystemContext       `json:"system"`
+	Docker       *DockerContext       `json:"docker"`
+	Process      *ProcessContext      `json:"process"`
+	Database     *DatabaseContext     `json:"database"`
+	Project      *ProjectContext      `json:"project"`
+	RecentErrors *RecentErrorsContext `json:"recent_errors,omitempty"` // ログから検出したエラー（ストアがある場合のみ）
 }
 
 // ==========================================
@@ -171,8 +185,8 @@ func getDiskUsage() (float64, float64) {
 
 // CollectDockerContext はDocker情報をコマンドから直接収集します
 func CollectDockerContext() (*DockerContext, error) {
-	// docker ps でIDを取得
-	cmd := exec.Command("docker", "ps", "--format", "{{.ID}}|{{.Image}}|{{.Status}}|{{.Names}}|{{.Ports}}")
+	// docker ps -a でIDを取得（異常終了したコンテナも分析対象にする）
+	cmd := exec.Command(monitor.ContainerCLI(), "ps", "-a", "--format", "{{.ID}}|{{.Image}}|{{.Status}}|{{.Names}}|{{.Ports}}")
 	output, err := cmd.Output()
 
 	// Dockerが起動していない場合
@@ -185,7 +199,14 @@ func CollectDockerContext() (*DockerContext, error) {
 		return &DockerContext{IsRunning: true, Count: 0, Containers: []DockerContainer{}}, nil
 	}
 
+	var ids []string
+	for _, line := range lines {
+		ids = append(ids, strings.SplitN(line, "|", 2)[0])
+	}
+	states := monitor.GetContainerStates(ids)
+
 	var containers []DockerContainer
+	running := 0
 	for _, line := range lines {
 		parts := strings.Split(line, "|")
 		if len(parts) < 5 {
@@ -200,9 +221,33 @@ func CollectDockerContext() (*DockerContext, error) {
 			Ports:  parts[4],
 		}
 
+		isUp := strings.HasPrefix(c.Status, "Up")
+		if state, ok := states[c.ID]; ok {
+			// 正常終了した停止中のコンテナは分析に不要なので除外
+			if !isUp && state.State != "restarting" && !state.Crashed() {
+				continue
+			}
+			c.State = state.State
+			c.Health = state.Health
+			c.HealthOutput = state.HealthOutput
+			c.ExitCode = state.ExitCode
+			c.OOMKilled = state.OOMKilled
+			c.RestartCount = state.RestartCount
+			c.Summary = state.Summary()
+		} else if !isUp {
+			continue
+		}
+
+		// 停止中・再起動中のコンテナはdocker statsを取得しない
+		if !isUp {
+			containers = append(containers, c)
+			continue
+		}
+		running++
+
 		// リソース情報の取得 (docker stats --no-stream)
 		// 個別に叩くと重いが、正確性のために取得（必要に応じて非同期化すべき箇所）
-		statsCmd := exec.Command("docker", "stats", "--no-stream", "--format", "{{.MemUsage}}|{{.CPUPerc}}", c.ID)
+		statsCmd := exec.Command(monitor.ContainerCLI(), "stats", "--no-stream", "--format", "{{.MemUsage}}|{{.CPUPerc}}", c.ID)
 		if statsOut, err := statsCmd.Output(); err == nil {
 			statsParts := strings.Split(strings.TrimSpace(string(statsOut)), "|")
 			if len(statsParts) >= 2 {
@@ -216,7 +261,7 @@ func CollectDockerContext() (*DockerContext, error) {
 
 	return &DockerContext{
 		IsRunning:  true,
-		Count:      len(containers),
+		Count:      running,
 		Containers: containers,
 	}, nil
 }
@@ -303,12 +348,31 @@ func getListeningPortsStruct() []PortInfo {
 // CollectDatabaseContext はDB状態を収集します
 func CollectDatabaseContext() (*DatabaseContext, error) {
 	return &DatabaseContext{
-		Postgres: checkDBStatus("postgres", "5432"),
-		MySQL:    checkDBStatus("mysqld", "3306"),
-		Redis:    checkDBStatus("redis-server", "6379"),
+		Postgres:      checkDBStatus("postgres", "5432"),
+		MySQL:         checkDBStatus("mysqld", "3306"),
+		Redis:         checkDBStatus("redis-server", "6379"),
+		MongoDB:       checkMongoDBStatus(),
+		Elasticsearch: checkElasticsearchStatus(),
 	}, nil
 }
 
+// checkMongoDBStatus はMongoDBの状態を確認します（コンテナで動作している場合も含む）
+func checkMongoDBStatus() DBStatus {
+	if status := checkDBStatus("mongod", "27017"); status.IsRunning {
+		return status
+	}
+
+	if !monitor.IsMongoDBRunning() {
+		return DBStatus{IsRunning: false}
+	}
+
+	return DBStatus{
+		IsRunning: true,
+		Port:      "27017",
+		Message:   "Running in container",
+	}
+}
+
 func checkDBStatus(procName, defaultPort string) DBStatus {
 	// プロセスチェック
 	cmd := exec.Command("pgrep", procName)
@@ -325,6 +389,34 @@ func checkDBStatus(procName, defaultPort string) DBStatus {
 	}
 }
 
+// checkElasticsearchStatus はElasticsearch/OpenSearchの状態をクラスタヘルスを含めて確認します
+func checkElasticsearchStatus() DBStatus {
+	if !monitor.IsElasticsearchRunning() {
+		return DBStatus{IsRunning: false}
+	}
+
+	cluster := monitor.GetElasticsearchCluster()
+	if !cluster.IsReachable {
+		return DBStatus{
+			IsRunning: true,
+			Port:      "9200",
+			Message:   "Process is running but API is unreachable: " + cluster.Error,
+		}
+	}
+
+	message := fmt.Sprintf("%s %s, cluster health: %s, unassigned shards: %d",
+		cluster.Distribution, cluster.Version, cluster.Health, cluster.UnassignedShards)
+	if tasks := monitor.GetElasticsearchPendingTasks(); len(tasks) > 0 {
+		message += fmt.Sprintf(", pending tasks: %d", len(tasks))
+	}
+
+	return DBStatus{
+		IsRunning: true,
+		Port:      "9200",
+		Message:   message,
+	}
+}
+
 // CollectProjectContext はカレントディレクトリ周辺のプロジェクト情報を収集します
 func CollectProjectContext() (*ProjectContext, error) {
 	cwd, _ := os.Getwd()
@@ -395,10 +487,32 @@ func analyzeProject(dir string) (ProjectInfo, bool) {
 		}
 	}
 
-	// Python
-	if _, err := os.Stat(filepath.Join(dir, "requirements.txt")); err == nil {
+	// Python (requirements.txt / pyproject.toml)
+	env := monitor.InspectPythonProject(dir)
+	if len(env.DependencyFiles) > 0 {
 		pType = "Python"
-		return ProjectInfo{Name: filepath.Base(dir), Type: pType, Path: dir, Dependencies: []string{"requirements.txt detected"}}, true
+		deps = env.Dependencies
+		if len(deps) > 10 {
+			deps = deps[:10]
+		}
+
+		environment := fmt.Sprintf("%s, Python %s", env.EnvType, env.Version)
+		if env.EnvPath != "" {
+			environment += fmt.Sprintf(" (%s)", env.EnvPath)
+		}
+
+		var issues []string
+		for _, name := range env.Missing {
+			issues = append(issues, "not installed: "+name)
+		}
+		for _, mismatch := range env.Mismatched {
+			issues = append(issues, "version mismatch: "+mismatch)
+		}
+		if !env.Checked {
+			issues = append(issues, "no virtualenv found in project; installed packages not checked")
+		}
+
+		return ProjectInfo{Name: filepath.Base(dir), Type: pType, Path: dir, Dependencies: deps, Environment: environment, DependencyIssues: issues}, true
 	}
 
 	return ProjectInfo{}, false
@@ -469,10 +583,19 @@ func FormatAsMarkdown(ctx interface{}) (string, error) {
 		for _, p := range c.Project.Projects {
 			sb.WriteString(fmt.Sprintf("### %s (%s)\n", p.Name, p.Type))
 			sb.WriteString(fmt.Sprintf("- Path: `%s`\n", p.Path))
+			if p.Environment != "" {
+				sb.WriteString(fmt.Sprintf("- Environment: %s\n", p.Environment))
+			}
 			sb.WriteString("- Dependencies:\n")
 			for _, d := range p.Dependencies {
 				sb.WriteString(fmt.Sprintf("  - %s\n", d))
 			}
+			if len(p.DependencyIssues) > 0 {
+				sb.WriteString("- Dependency Issues:\n")
+				for _, issue := range p.DependencyIssues {
+					sb.WriteString(fmt.Sprintf("  - %s\n", issue))
+				}
+			}
 		}
 	}
 	sb.WriteString("\n")
@@ -484,11 +607,25 @@ func FormatAsMarkdown(ctx interface{}) (string, error) {
 	} else {
 		sb.WriteString(fmt.Sprintf("Running Containers: %d\n", c.Docker.Count))
 		if len(c.Docker.Containers) > 0 {
-			sb.WriteString("| ID | Image | Status | Ports | CPU | Mem |\n")
-			sb.WriteString("|---|---|---|---|---|---|\n")
+			sb.WriteString("| ID | Name | Image | Status | Health | Ports | CPU | Mem |\n")
+			sb.WriteString("|---|---|---|---|---|---|---|---|\n")
 			for _, cnt := range c.Docker.Containers {
-				sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %s |\n",
-					cnt.ID[:4], cnt.Image, cnt.Status, cnt.Ports, cnt.CPUUsed, cnt.MemUsed))
+				sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %s | %s | %s |\n",
+					cnt.ID[:4], cnt.Names, cnt.Image, cnt.Status, cnt.Health, cnt.Ports, cnt.CPUUsed, cnt.MemUsed))
+			}
+
+			// 再起動ループ・異常終了・unhealthy のコンテナを明示する
+			var problems []string
+			for _, cnt := range c.Docker.Containers {
+				if cnt.Summary != "" {
+					problems = append(problems, fmt.Sprintf("- **%s**: %s\n", cnt.Names, cnt.Summary))
+				}
+			}
+			if len(problems) > 0 {
+				sb.WriteString("\n**Container Problems:**\n")
+				for _, p := range problems {
+					sb.WriteString(p)
+				}
 			}
 		}
 	}
@@ -506,6 +643,26 @@ func FormatAsMarkdown(ctx interface{}) (string, error) {
 	formatDB("PostgreSQL", c.Database.Postgres)
 	formatDB("MySQL", c.Database.MySQL)
 	formatDB("Redis", c.Database.Redis)
+	formatDB("MongoDB", c.Database.MongoDB)
+	formatDB("Elasticsearch", c.Database.Elasticsearch)
+
+	// 5. Recent Errors
+	if c.RecentErrors != nil && len(c.RecentErrors.Sources) > 0 {
+		sb.WriteString(fmt.Sprintf("\n## 5. Recent Errors (last %d minutes)\n", c.RecentErrors.WindowMinutes))
+		for _, src := range c.RecentErrors.Sources {
+			sb.WriteString(fmt.Sprintf("### %s (errors: %d, warnings: %d)\n", src.Source, src.ErrorLines, src.WarnLines))
+			for _, e := range src.Errors {
+				mark := ""
+				if e.IsNew {
+					mark = " **[NEW]**"
+				}
+				sb.WriteString(fmt.Sprintf("- %s (x%d)%s\n", e.Message, e.Count, mark))
+			}
+			if len(src.RecentLines) > 0 {
+				sb.WriteString("```\n" + strings.Join(src.RecentLines, "\n") + "\n```\n")
+			}
+		}
+	}
 
 	return sb.String(), nil
 }
@@ -0,0 +1,111 @@
+package llm
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/db"
+)
+
+// recentErrorLinesPerSource はAIに渡すソースごとのエラー行数
+const recentErrorLinesPerSource = 5
+
+// recentErrorLineMaxChars はAIに渡すエラー行の最大文字数
+const recentErrorLineMaxChars = 300
+
+// RecentErrorsContext はログから検出した直近のエラーを保持します
+type RecentErrorsContext struct {
+	WindowMinutes int            `json:"window_minutes"`
+	Sources       []SourceErrors `json:"sources"`
+}
+
+// SourceErrors はコンテナ・プロセスごとのエラーです
+type SourceErrors struct {
+	Source      string         `json:"source"`
+	ErrorLines  int            `json:"error_lines"`
+	WarnLines   int            `json:"warning_lines"`
+	Errors      []ErrorSummary `json:"errors,omitempty"`             // エラーの種類ごとの集計
+	RecentLines []string       `json:"recent_error_lines,omitempty"` // 直近のERROR行
+}
+
+// ErrorSummary はエラーの種類（正規化したメッセージ）ごとの集計です
+type ErrorSummary struct {
+	Message   string `json:"message"`
+	Count     int    `json:"total_count"`
+	FirstSeen string `json:"first_seen"`
+	IsNew     bool   `json:"new"` // 集計期間内に初めて出たエラー
+}
+
+// CollectRecentErrorsContext は保存しているログから直近 window のエラーを集計します
+func CollectRecentErrorsContext(store *db.Store, window time.Duration) (*RecentErrorsContext, error) {
+	since := time.Now().Add(-window)
+
+	counts, err := store.GetLogLevelCounts(since)
+	if err != nil {
+		return nil, err
+	}
+	signatures, err := store.GetRecentErrorSignatures(since, 30)
+	if err != nil {
+		return nil, err
+	}
+	lines, err := store.GetRecentErrorLines(since, recentErrorLinesPerSource)
+	if err != nil {
+		return nil, err
+	}
+
+	bySource := make(map[string]*SourceErrors)
+	get := func(source string) *SourceErrors {
+		if s, ok := bySource[source]; ok {
+			return s
+		}
+		s := &SourceErrors{Source: source}
+		bySource[source] = s
+		return s
+	}
+
+	for _, c := range counts {
+		s := get(c.Source)
+		s.ErrorLines = c.Errors
+		s.WarnLines = c.Warnings
+	}
+	for _, sig := range signatures {
+		s := get(sig.Source)
+		s.Errors = append(s.Errors, ErrorSummary{
+			Message:   truncateText(sig.Message, recentErrorLineMaxChars),
+			Count:     sig.Count,
+			FirstSeen: sig.FirstSeen.Local().Format("2006-01-02 15:04:05"),
+			IsNew:     sig.FirstSeen.After(since),
+		})
+	}
+	for source, sourceLines := range lines {
+		s := get(source)
+		for _, line := range sourceLines {
+			s.RecentLines = append(s.RecentLines, truncateText(line.Text, recentErrorLineMaxChars))
+		}
+	}
+
+	ctx := &RecentErrorsContext{WindowMinutes: int(window.Minutes())}
+	for _, s := range bySource {
+		if s.ErrorLines == 0 && len(s.Errors) == 0 {
+			continue
+		}
+		ctx.Sources = append(ctx.Sources, *s)
+	}
+	// エラーの多いソースから並べる
+	sort.Slice(ctx.Sources, func(i, j int) bool {
+		if ctx.Sources[i].ErrorLines != ctx.Sources[j].ErrorLines {
+			return ctx.Sources[i].ErrorLines > ctx.Sources[j].ErrorLines
+		}
+		return ctx.Sources[i].Source < ctx.Sources[j].Source
+	})
+	return ctx, nil
+}
+
+// truncateText shortens text to max characters
+func truncateText(text string, max int) string {
+	runes := []rune(text)
+	if len(runes) <= max {
+		return text
+	}
+	return string(runes[:max]) + "..."
+}
@@ -0,0 +1,219 @@
+// Package mockollama provides an Ollama server that returns scripted replies,
+// for exercising the AI panel and the tool-calling agent without a model.
+// The same server also answers the OpenAI-compatible API under /v1.
+package mockollama
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/llm"
+)
+
+// Server is a local Ollama API (/, /api/tags, /api/chat) and OpenAI-compatible API (/v1/models, /v1/chat/completions)
+// that answers chat requests with scripted replies in order
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	models   []string
+	replies  []llm.Message
+	requests []llm.ChatRequest
+}
+
+// NewServer starts a server that lists models and answers with replies in order
+// 応答がなくなった後のリクエストにはエラーを返す
+func NewServer(models []string, replies ...llm.Message) *Server {
+	s := &Server{models: models, replies: replies}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("Ollama is running"))
+	})
+	mux.HandleFunc("/api/tags", s.handleTags)
+	mux.HandleFunc("/api/chat", s.handleChat)
+	mux.HandleFunc("/v1/models", s.handleOpenAIModels)
+	mux.HandleFunc("/v1/chat/completions", s.handleOpenAIChat)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// Reply returns an assistant reply with content
+func Reply(content string) llm.Message {
+	return llm.Message{Role: "assistant", Content: content}
+}
+
+// ToolCallReply returns an assistant reply that calls a tool
+func ToolCallReply(name string, args map[string]any) llm.Message {
+	return llm.Message{
+		Role:      "assistant",
+		ToolCalls: []llm.ToolCall{{Function: llm.ToolCallFunction{Name: name, Arguments: args}}},
+	}
+}
+
+// Requests returns the chat requests received so far
+func (s *Server) Requests() []llm.ChatRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]llm.ChatRequest{}, s.requests...)
+}
+
+func (s *Server) handleTags(w http.ResponseWriter, r *http.Request) {
+	type model struct {
+		Name string `json:"name"`
+	}
+	var resp struct {
+		Models []model `json:"models"`
+	}
+	for _, name := range s.models {
+		resp.Models = append(resp.Models, model{Name: name})
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Server) handleChat(w http.ResponseWriter, r *http.Request) {
+	var req llm.ChatRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, `{"error":"invalid request"}`, http.StatusBadRequest)
+		return
+	}
+
+	reply, ok := s.nextReply(req)
+	if !ok {
+		// 500系はクライアントが再試行するため400を返す
+		http.Error(w, `{"error":"mockollama: no more scripted replies"}`, http.StatusBadRequest)
+		return
+	}
+
+	enc := json.NewEncoder(w)
+	if !req.Stream {
+		enc.Encode(map[string]any{"message": reply, "done": true})
+		return
+	}
+
+	// ストリーミングは単語ごとに分けて返す
+	words := strings.SplitAfter(reply.Content, " ")
+	for _, word := range words {
+		enc.Encode(map[string]any{"message": llm.Message{Role: "assistant", Content: word}, "done": false})
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+	}
+	enc.Encode(map[string]any{"message": llm.Message{Role: "assistant"}, "done": true})
+}
+
+// nextReply records the request and returns the next scripted reply
+func (s *Server) nextReply(req llm.ChatRequest) (llm.Message, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, req)
+	if len(s.replies) == 0 {
+		return llm.Message{}, false
+	}
+	reply := s.replies[0]
+	s.replies = s.replies[1:]
+	return reply, true
+}
+
+// openAIMessage is a chat message of the OpenAI-compatible API (tool call arguments are a JSON string)
+type openAIMessage struct {
+	Role       string           `json:"role,omitempty"`
+	Content    string           `json:"content"`
+	ToolCalls  []openAIToolCall `json:"tool_calls,omitempty"`
+	ToolCallID string           `json:"tool_call_id,omitempty"`
+}
+
+// openAIToolCall is a tool call of the OpenAI-compatible API
+type openAIToolCall struct {
+	ID       string             `json:"id"`
+	Type     string             `json:"type"`
+	Function openAIToolFunction `json:"function"`
+}
+
+type openAIToolFunction struct {
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+}
+
+func (s *Server) handleOpenAIModels(w http.ResponseWriter, r *http.Request) {
+	type model struct {
+		ID     string `json:"id"`
+		Object string `json:"object"`
+	}
+	resp := struct {
+		Object string  `json:"object"`
+		Data   []model `json:"data"`
+	}{Object: "list"}
+	for _, name := range s.models {
+		resp.Data = append(resp.Data, model{ID: name, Object: "model"})
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Server) handleOpenAIChat(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Model    string          `json:"model"`
+		Messages []openAIMessage `json:"messages"`
+		Stream   bool            `json:"stream"`
+		Tools    []llm.Tool      `json:"tools"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, `{"error":{"message":"invalid request"}}`, http.StatusBadRequest)
+		return
+	}
+
+	// Requests() で両方のAPIを同じ形式で確認できるように変換して記録する
+	req := llm.ChatRequest{Model: body.Model, Stream: body.Stream, Tools: body.Tools}
+	for _, msg := range body.Messages {
+		converted := llm.Message{Role: msg.Role, Content: msg.Content, ToolCallID: msg.ToolCallID}
+		for _, tc := range msg.ToolCalls {
+			call := llm.ToolCall{ID: tc.ID, Function: llm.ToolCallFunction{Name: tc.Function.Name}}
+			json.Unmarshal([]byte(tc.Function.Arguments), &call.Function.Arguments)
+			converted.ToolCalls = append(converted.ToolCalls, call)
+		}
+		req.Messages = append(req.Messages, converted)
+	}
+
+	reply, ok := s.nextReply(req)
+	if !ok {
+		http.Error(w, `{"error":{"message":"mockollama: no more scripted replies"}}`, http.StatusBadRequest)
+		return
+	}
+
+	out := openAIMessage{Role: "assistant", Content: reply.Content}
+	for i, call := range reply.ToolCalls {
+		args, _ := json.Marshal(call.Function.Arguments)
+		out.ToolCalls = append(out.ToolCalls, openAIToolCall{
+			ID:       fmt.Sprintf("call_%d", i),
+			Type:     "function",
+			Function: openAIToolFunction{Name: call.Function.Name, Arguments: string(args)},
+		})
+	}
+
+	if !body.Stream {
+		finish := "stop"
+		if len(out.ToolCalls) > 0 {
+			finish = "tool_calls"
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{{"index": 0, "message": out, "finish_reason": finish}},
+		})
+		return
+	}
+
+	// ストリーミングは Server-Sent Events で単語ごとに分けて返す
+	w.Header().Set("Content-Type", "text/event-stream")
+	for _, word := range strings.SplitAfter(reply.Content, " ") {
+		chunk, _ := json.Marshal(map[string]any{
+			"choices": []map[string]any{{"index": 0, "delta": map[string]string{"content": word}}},
+		})
+		fmt.Fprintf(w, "data: %s\n\n", chunk)
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+	}
+	fmt.Fprint(w, "data: [DONE]\n\n")
+}
@@ -12,15 +12,19 @@ import (
 
 // OllamaClient はOllama APIとの通信を担当するクライアントです
 type OllamaClient struct {
-	Endpoint   string
-	HTTPClient *http.Client
-	MaxRetries int
+	Endpoint    string
+	HTTPClient  *http.Client
+	MaxRetries  int
+	DisplayName string // モデル選択に表示する名前（未指定は "Ollama"）
 }
 
 // Message はチャットメッセージを表します
 type Message struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
+	Role       string     `json:"role"`
+	Content    string     `json:"content"`
+	ToolCalls  []ToolCall `json:"tool_calls,omitempty"`   // モデルが呼び出したツール（role: assistant）
+	ToolName   string     `json:"tool_name,omitempty"`    // 結果を返すツール名（role: tool）
+	ToolCallID string     `json:"tool_call_id,omitempty"` // 結果を返す呼び出しのID（role: tool、OpenAI互換のみ）
 }
 
 // Options はモデルパラメータを表します
@@ -34,6 +38,7 @@ type ChatRequest struct {
 	Model    string    `json:"model"`
 	Messages []Message `json:"messages"`
 	Stream   bool      `json:"stream"`
+	Tools    []Tool    `json:"tools,omitempty"`
 	Options  *Options  `json:"options,omitempty"`
 }
 
@@ -68,6 +73,14 @@ func NewOllamaClient(endpoint string) *OllamaClient {
 	}
 }
 
+// Name はモデル選択に表示する名前を返します
+func (c *OllamaClient) Name() string {
+	if c.DisplayName == "" {
+		return "Ollama"
+	}
+	return c.DisplayName
+}
+
 // CheckHealth はOllamaサーバーへの接続を確認します
 func (c *OllamaClient) CheckHealth(ctx context.Context) error {
 	req, err := http.NewRequestWithContext(ctx, "GET", c.Endpoint, nil)
@@ -249,9 +262,14 @@ func (c *OllamaClient) GenerateStream(ctx context.Context, messages []Message, m
 
 // doRequestWithRetry はリトライロジック付きでリクエストを実行します
 func (c *OllamaClient) doRequestWithRetry(req *http.Request) (*http.Response, error) {
+	return doRequestWithRetry(c.HTTPClient, c.MaxRetries, req)
+}
+
+// doRequestWithRetry は接続エラーと500系エラーの場合に再試行します（Ollama / OpenAI互換で共通）
+func doRequestWithRetry(client *http.Client, maxRetries int, req *http.Request) (*http.Response, error) {
 	var lastErr error
 
-	for i := 0; i <= c.MaxRetries; i++ {
+	for i := 0; i <= maxRetries; i++ {
 		if req.Context().Err() != nil {
 			return nil, req.Context().Err()
 		}
@@ -263,7 +281,7 @@ func (c *OllamaClient) doRequestWithRetry(req *http.Request) (*http.Response, er
 			}
 		}
 
-		resp, err := c.HTTPClient.Do(req)
+		resp, err := client.Do(req)
 		if err == nil {
 			// 500系エラーのみリトライ対象とする
 			if resp.StatusCode < 500 {
@@ -275,7 +293,7 @@ func (c *OllamaClient) doRequestWithRetry(req *http.Request) (*http.Response, er
 			lastErr = err
 		}
 
-		if i < c.MaxRetries {
+		if i < maxRetries {
 			select {
 			case <-req.Context().Done():
 				return nil, req.Context().Err()
@@ -0,0 +1,328 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// openAIStreamMaxLine はストリーミングの1行（data: ...）の最大バイト数
+const openAIStreamMaxLine = 1024 * 1024
+
+// OpenAIClient はOpenAI互換の /v1/chat/completions と通信するクライアントです
+// LM Studio、vLLM、llama.cpp server などのローカルサーバーでも利用できます
+type OpenAIClient struct {
+	Endpoint    string // /v1 までのURL（例: http://localhost:1234/v1）
+	APIKey      string
+	HTTPClient  *http.Client
+	MaxRetries  int
+	DisplayName string
+}
+
+// openAIMessage はOpenAI形式のチャットメッセージです
+type openAIMessage struct {
+	Role       string           `json:"role"`
+	Content    string           `json:"content"`
+	ToolCalls  []openAIToolCall `json:"tool_calls,omitempty"`
+	ToolCallID string           `json:"tool_call_id,omitempty"`
+}
+
+// openAIToolCall はOpenAI形式のツールの呼び出しです（引数はJSON文字列）
+type openAIToolCall struct {
+	ID       string `json:"id,omitempty"`
+	Type     string `json:"type"`
+	Function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
+}
+
+// openAIChatRequest は /chat/completions へのリクエストです
+type openAIChatRequest struct {
+	Model    string          `json:"model"`
+	Messages []openAIMessage `json:"messages"`
+	Stream   bool            `json:"stream"`
+	Tools    []Tool          `json:"tools,omitempty"` // ツールの定義はOllamaと同じ形式
+}
+
+// openAIChatResponse は /chat/completions のレスポンス（ストリーミングの場合は各チャンク）です
+type openAIChatResponse struct {
+	Choices []struct {
+		Message      openAIMessage `json:"message"`
+		Delta        openAIMessage `json:"delta"`
+		FinishReason string        `json:"finish_reason"`
+	} `json:"choices"`
+	Error *openAIError `json:"error,omitempty"`
+}
+
+// openAIError はAPIが返すエラーです
+type openAIError struct {
+	Message string `json:"message"`
+}
+
+// NewOpenAIClient は新しいクライアントを初期化します
+// パスのないURL（http://localhost:1234）には /v1 を補います
+func NewOpenAIClient(name, endpoint, apiKey string) *OpenAIClient {
+	endpoint = strings.TrimRight(endpoint, "/")
+	if endpoint == "" {
+		endpoint = "http://localhost:8080/v1" // llama.cpp server のデフォルト
+	} else if u, err := url.Parse(endpoint); err == nil && u.Path == "" {
+		endpoint += "/v1"
+	}
+
+	return &OpenAIClient{
+		Endpoint:    endpoint,
+		APIKey:      apiKey,
+		HTTPClient:  &http.Client{Timeout: 0}, // 生成は長時間かかるためContextで制御する
+		MaxRetries:  3,
+		DisplayName: name,
+	}
+}
+
+// Name はモデル選択に表示する名前を返します
+func (c *OpenAIClient) Name() string {
+	if c.DisplayName == "" {
+		return "OpenAI互換"
+	}
+	return c.DisplayName
+}
+
+// CheckHealth はサーバーへの接続を確認します（/models を取得できるか）
+func (c *OpenAIClient) CheckHealth(ctx context.Context) error {
+	resp, err := c.do(ctx, "GET", "/models", nil)
+	if err != nil {
+		return fmt.Errorf("%s接続エラー: %w", c.Name(), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%sステータス異常: %s", c.Name(), resp.Status)
+	}
+	return nil
+}
+
+// ListModels は利用可能なモデル一覧を取得します
+func (c *OpenAIClient) ListModels(ctx context.Context) ([]string, error) {
+	resp, err := c.do(ctx, "GET", "/models", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("APIエラー (%s): %s", resp.Status, string(body))
+	}
+
+	var result struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	var models []string
+	for _, m := range result.Data {
+		models = append(models, m.ID)
+	}
+	return models, nil
+}
+
+// Generate はテキスト生成を行います（非ストリーミング）
+func (c *OpenAIClient) Generate(ctx context.Context, messages []Message, model string) (string, error) {
+	reply, err := c.chat(ctx, openAIChatRequest{Model: model, Messages: toOpenAIMessages(messages)})
+	if err != nil {
+		return "", err
+	}
+	return reply.Content, nil
+}
+
+// ChatWithTools はツールを渡して1回問い合わせ、モデルの応答（回答またはツールの呼び出し）を返します
+func (c *OpenAIClient) ChatWithTools(ctx context.Context, messages []Message, model string, tools []Tool) (Message, error) {
+	return c.chat(ctx, openAIChatRequest{Model: model, Messages: toOpenAIMessages(messages), Tools: tools})
+}
+
+// GenerateStream はテキスト生成をストリーミングで行います（Server-Sent Events）
+func (c *OpenAIClient) GenerateStream(ctx context.Context, messages []Message, model string) (<-chan GenerateResponseStream, error) {
+	resp, err := c.post(ctx, openAIChatRequest{Model: model, Messages: toOpenAIMessages(messages), Stream: true})
+	if err != nil {
+		return nil, err
+	}
+
+	stream := make(chan GenerateResponseStream)
+
+	go func() {
+		defer close(stream)
+		defer resp.Body.Close()
+
+		// 受信側がキャンセルした後に送信で止まり続けないようにする
+		send := func(chunk GenerateResponseStream) bool {
+			select {
+			case stream <- chunk:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), openAIStreamMaxLine)
+		for scanner.Scan() {
+			if ctx.Err() != nil {
+				send(GenerateResponseStream{Err: ctx.Err()})
+				return
+			}
+
+			data, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "data:")
+			if !ok {
+				continue // 空行やコメント行
+			}
+			data = strings.TrimSpace(data)
+			if data == "[DONE]" {
+				send(GenerateResponseStream{Done: true})
+				return
+			}
+
+			var chunk openAIChatResponse
+			if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+				send(GenerateResponseStream{Err: fmt.Errorf("stream decode error: %w", err)})
+				return
+			}
+			if chunk.Error != nil {
+				send(GenerateResponseStream{Err: fmt.Errorf("%s API error: %s", c.Name(), chunk.Error.Message)})
+				return
+			}
+			for _, choice := range chunk.Choices {
+				if choice.Delta.Content != "" && !send(GenerateResponseStream{Response: choice.Delta.Content}) {
+					return
+				}
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			send(GenerateResponseStream{Err: fmt.Errorf("stream read error: %w", err)})
+			return
+		}
+		// [DONE] を送らないサーバーもあるため、終端まで読んだら完了とする
+		send(GenerateResponseStream{Done: true})
+	}()
+
+	return stream, nil
+}
+
+// chat sends a non-streaming request and returns the first choice
+func (c *OpenAIClient) chat(ctx context.Context, reqBody openAIChatRequest) (Message, error) {
+	resp, err := c.post(ctx, reqBody)
+	if err != nil {
+		return Message{}, err
+	}
+	defer resp.Body.Close()
+
+	var result openAIChatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return Message{}, err
+	}
+	if result.Error != nil {
+		return Message{}, fmt.Errorf("%s API error: %s", c.Name(), result.Error.Message)
+	}
+	if len(result.Choices) == 0 {
+		return Message{}, fmt.Errorf("%s API error: no choices in response", c.Name())
+	}
+	return fromOpenAIMessage(result.Choices[0].Message), nil
+}
+
+// post sends a chat request and checks the status
+func (c *OpenAIClient) post(ctx context.Context, reqBody openAIChatRequest) (*http.Response, error) {
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(ctx, "POST", "/chat/completions", jsonData)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("APIエラー (%s): %s", resp.Status, string(body))
+	}
+	return resp, nil
+}
+
+// do sends a request to the endpoint with the API key
+func (c *OpenAIClient) do(ctx context.Context, method, path string, body []byte) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.Endpoint+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("リクエスト作成エラー: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.APIKey)
+	}
+	return doRequestWithRetry(c.HTTPClient, c.MaxRetries, req)
+}
+
+// toOpenAIMessages converts the conversation to the OpenAI format
+// IDのないツールの呼び出し（Ollamaでの会話の続きなど）にはIDを振り、結果と順に対応させる
+func toOpenAIMessages(messages []Message) []openAIMessage {
+	converted := make([]openAIMessage, 0, len(messages))
+	var pendingIDs []string
+	for i, msg := range messages {
+		m := openAIMessage{Role: msg.Role, Content: msg.Content, ToolCallID: msg.ToolCallID}
+		if len(msg.ToolCalls) > 0 {
+			pendingIDs = nil
+		}
+		for j, call := range msg.ToolCalls {
+			tc := openAIToolCall{ID: call.ID, Type: "function"}
+			if tc.ID == "" {
+				tc.ID = fmt.Sprintf("call_%d_%d", i, j)
+			}
+			tc.Function.Name = call.Function.Name
+			args, err := json.Marshal(call.Function.Arguments)
+			if err != nil || call.Function.Arguments == nil {
+				args = []byte("{}")
+			}
+			tc.Function.Arguments = string(args)
+			m.ToolCalls = append(m.ToolCalls, tc)
+			pendingIDs = append(pendingIDs, tc.ID)
+		}
+		if msg.Role == "tool" && m.ToolCallID == "" && len(pendingIDs) > 0 {
+			m.ToolCallID = pendingIDs[0]
+			pendingIDs = pendingIDs[1:]
+		}
+		converted = append(converted, m)
+	}
+	return converted
+}
+
+// fromOpenAIMessage converts a reply to the common format
+// 引数が正しいJSONでない場合は引数なしとして扱う（ツールが不足している引数をエラーとしてモデルに返す）
+func fromOpenAIMessage(msg openAIMessage) Message {
+	reply := Message{Role: msg.Role, Content: msg.Content}
+	if reply.Role == "" {
+		reply.Role = "assistant"
+	}
+	for i, tc := range msg.ToolCalls {
+		call := ToolCall{ID: tc.ID, Function: ToolCallFunction{Name: tc.Function.Name}}
+		if call.ID == "" {
+			call.ID = fmt.Sprintf("call_%d", i)
+		}
+		json.Unmarshal([]byte(tc.Function.Arguments), &call.Function.Arguments)
+		reply.ToolCalls = append(reply.ToolCalls, call)
+	}
+	return reply
+}
@@ -0,0 +1,76 @@
+package llm_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/llm"
+	"github.com/Masahide-S/bho_hacka_go/internal/llm/mockollama"
+)
+
+func TestOpenAIClientWithMock(t *testing.T) {
+	srv := mockollama.NewServer([]string{"qwen2.5", "llama3.2"},
+		mockollama.ToolCallReply("get_container_logs", map[string]any{"container": "api"}),
+		mockollama.Reply("the api container is out of memory"),
+		mockollama.Reply("streamed answer here"),
+	)
+	defer srv.Close()
+
+	client, err := llm.NewProvider(llm.ProviderOpenAI, "mock", srv.URL, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	models, err := client.ListModels(ctx)
+	if err != nil || !slices.Equal(models, []string{"qwen2.5", "llama3.2"}) {
+		t.Fatalf("ListModels() = %v, %v", models, err)
+	}
+
+	// ツールの呼び出しと、結果を返した後の回答
+	tools := []llm.Tool{llm.NewTool("get_container_logs", "logs", nil)}
+	history := []llm.Message{{Role: "user", Content: "why is api down?"}}
+	reply, err := client.ChatWithTools(ctx, history, "qwen2.5", tools)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reply.ToolCalls) != 1 || reply.ToolCalls[0].ID == "" || reply.ToolCalls[0].Function.Arguments["container"] != "api" {
+		t.Fatalf("reply = %+v, want a get_container_logs call", reply)
+	}
+	history = append(history, reply, llm.Message{Role: "tool", ToolCallID: reply.ToolCalls[0].ID, Content: "OOMKilled"})
+	answer, err := client.ChatWithTools(ctx, history, "qwen2.5", tools)
+	if err != nil || answer.Content != "the api container is out of memory" {
+		t.Fatalf("answer = %+v, %v", answer, err)
+	}
+
+	requests := srv.Requests()
+	if len(requests[0].Tools) != 1 {
+		t.Errorf("first request has %d tools, want 1", len(requests[0].Tools))
+	}
+	sent := requests[1].Messages
+	if tool := sent[len(sent)-1]; tool.Role != "tool" || tool.ToolCallID != reply.ToolCalls[0].ID {
+		t.Errorf("tool result = %+v, want tool_call_id %q", tool, reply.ToolCalls[0].ID)
+	}
+
+	// ストリーミング（[DONE] で終わる）
+	stream, err := client.GenerateStream(ctx, history, "qwen2.5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var text string
+	done := false
+	for chunk := range stream {
+		if chunk.Err != nil {
+			t.Fatal(chunk.Err)
+		}
+		text += chunk.Response
+		done = done || chunk.Done
+	}
+	if text != "streamed answer here" || !done {
+		t.Errorf("stream = %q (done %v)", text, done)
+	}
+	if !srv.Requests()[2].Stream {
+		t.Error("streaming request was not sent with stream=true")
+	}
+}
@@ -0,0 +1,212 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestToOpenAIMessages(t *testing.T) {
+	messages := []Message{
+		{Role: "system", Content: "prompt"},
+		{Role: "user", Content: "why?"},
+		// Ollamaでの会話の続きはツールの呼び出しにIDがない
+		{Role: "assistant", ToolCalls: []ToolCall{
+			{Function: ToolCallFunction{Name: "list_ports"}},
+			{Function: ToolCallFunction{Name: "get_container_logs", Arguments: map[string]any{"container": "api"}}},
+		}},
+		{Role: "tool", ToolName: "list_ports", Content: "5432 postgres"},
+		{Role: "tool", ToolName: "get_container_logs", Content: "ERROR"},
+		{Role: "assistant", ToolCalls: []ToolCall{{ID: "call_x", Function: ToolCallFunction{Name: "list_ports"}}}},
+		{Role: "tool", ToolCallID: "call_x", Content: "8080 api"},
+	}
+
+	converted := toOpenAIMessages(messages)
+	if len(converted) != len(messages) {
+		t.Fatalf("got %d messages, want %d", len(converted), len(messages))
+	}
+
+	calls := converted[2].ToolCalls
+	if len(calls) != 2 {
+		t.Fatalf("got %d tool calls, want 2", len(calls))
+	}
+	if calls[0].ID == "" || calls[0].ID == calls[1].ID || calls[0].Type != "function" {
+		t.Errorf("tool calls have IDs %q and %q, type %q", calls[0].ID, calls[1].ID, calls[0].Type)
+	}
+	if calls[0].Function.Arguments != "{}" {
+		t.Errorf("arguments without values = %q, want {}", calls[0].Function.Arguments)
+	}
+	if calls[1].Function.Arguments != `{"container":"api"}` {
+		t.Errorf("arguments = %q", calls[1].Function.Arguments)
+	}
+
+	// 結果は呼び出しの順に対応させる
+	if converted[3].ToolCallID != calls[0].ID || converted[4].ToolCallID != calls[1].ID {
+		t.Errorf("tool results have IDs %q and %q, want %q and %q", converted[3].ToolCallID, converted[4].ToolCallID, calls[0].ID, calls[1].ID)
+	}
+	if converted[5].ToolCalls[0].ID != "call_x" || converted[6].ToolCallID != "call_x" {
+		t.Errorf("existing IDs were not kept: %q, %q", converted[5].ToolCalls[0].ID, converted[6].ToolCallID)
+	}
+}
+
+func TestFromOpenAIMessage(t *testing.T) {
+	msg := openAIMessage{ToolCalls: []openAIToolCall{{ID: "", Type: "function"}, {ID: "call_b", Type: "function"}}}
+	msg.ToolCalls[0].Function.Name = "get_process_details"
+	msg.ToolCalls[0].Function.Arguments = `{"pid":"42"}`
+	msg.ToolCalls[1].Function.Name = "list_ports"
+	msg.ToolCalls[1].Function.Arguments = `not json`
+
+	reply := fromOpenAIMessage(msg)
+	if reply.Role != "assistant" {
+		t.Errorf("role = %q, want assistant", reply.Role)
+	}
+	if len(reply.ToolCalls) != 2 {
+		t.Fatalf("got %d tool calls, want 2", len(reply.ToolCalls))
+	}
+	if reply.ToolCalls[0].ID == "" || reply.ToolCalls[0].Function.Arguments["pid"] != "42" {
+		t.Errorf("first call = %+v", reply.ToolCalls[0])
+	}
+	if reply.ToolCalls[1].ID != "call_b" || len(reply.ToolCalls[1].Function.Arguments) != 0 {
+		t.Errorf("second call = %+v, want no arguments", reply.ToolCalls[1])
+	}
+}
+
+// sseServer returns a server that answers chat completions with the given SSE lines
+func sseServer(t *testing.T, lines ...string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, line := range lines {
+			fmt.Fprintf(w, "%s\n\n", line)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// collectStream reads the stream until it is closed
+func collectStream(t *testing.T, stream <-chan GenerateResponseStream) (string, bool, error) {
+	t.Helper()
+	var text strings.Builder
+	done := false
+	for chunk := range stream {
+		if chunk.Err != nil {
+			return text.String(), done, chunk.Err
+		}
+		text.WriteString(chunk.Response)
+		done = done || chunk.Done
+	}
+	return text.String(), done, nil
+}
+
+func TestOpenAIGenerateStream(t *testing.T) {
+	srv := sseServer(t,
+		`: keep-alive`,
+		`data: {"choices":[{"delta":{"role":"assistant"}}]}`,
+		`data: {"choices":[{"delta":{"content":"Hello"}}]}`,
+		`data: {"choices":[{"delta":{"content":", world"}}]}`,
+		`data: [DONE]`,
+		`data: {"choices":[{"delta":{"content":"ignored"}}]}`,
+	)
+
+	client := NewOpenAIClient("test", srv.URL, "")
+	stream, err := client.GenerateStream(context.Background(), []Message{{Role: "user", Content: "hi"}}, "m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	text, done, err := collectStream(t, stream)
+	if err != nil || !done || text != "Hello, world" {
+		t.Errorf("got %q (done %v, err %v), want %q", text, done, err, "Hello, world")
+	}
+}
+
+func TestOpenAIGenerateStreamErrorChunk(t *testing.T) {
+	srv := sseServer(t,
+		`data: {"choices":[{"delta":{"content":"partial"}}]}`,
+		`data: {"error":{"message":"model overloaded"}}`,
+	)
+
+	client := NewOpenAIClient("test", srv.URL, "")
+	stream, err := client.GenerateStream(context.Background(), []Message{{Role: "user", Content: "hi"}}, "m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	text, _, err := collectStream(t, stream)
+	if err == nil || !strings.Contains(err.Error(), "model overloaded") {
+		t.Errorf("err = %v, want the API error", err)
+	}
+	if text != "partial" {
+		t.Errorf("text before the error = %q", text)
+	}
+}
+
+func TestOpenAIGenerateStreamCancel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; ; i++ {
+			if _, err := fmt.Fprintf(w, "data: {\"choices\":[{\"delta\":{\"content\":\"%d \"}}]}\n\n", i); err != nil {
+				return
+			}
+			w.(http.Flusher).Flush()
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(time.Millisecond):
+			}
+		}
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	client := NewOpenAIClient("test", srv.URL, "")
+	stream, err := client.GenerateStream(ctx, []Message{{Role: "user", Content: "hi"}}, "m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-stream
+	// 読むのをやめてキャンセルしても、ストリームを読むゴルーチンは終了する
+	cancel()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for streamGoroutineRunning() {
+		if time.Now().After(deadline) {
+			t.Fatal("stream goroutine is still running after cancel")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// streamGoroutineRunning reports whether a GenerateStream goroutine is alive
+func streamGoroutineRunning() bool {
+	buf := make([]byte, 1<<20)
+	buf = buf[:runtime.Stack(buf, true)]
+	return strings.Contains(string(buf), "(*OpenAIClient).GenerateStream.func")
+}
+
+func TestOpenAIBearerHeader(t *testing.T) {
+	var auth []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = append(auth, r.Header.Get("Authorization"))
+		if r.URL.Path != "/v1/models" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"data":[]}`)
+	}))
+	defer srv.Close()
+
+	// パスのないURLには /v1 を補う
+	if err := NewOpenAIClient("test", srv.URL, "secret-key").CheckHealth(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewOpenAIClient("test", srv.URL+"/v1", "").CheckHealth(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(auth) != 2 || auth[0] != "Bearer secret-key" || auth[1] != "" {
+		t.Errorf("Authorization headers = %q, want [\"Bearer secret-key\" \"\"]", auth)
+	}
+}
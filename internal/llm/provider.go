@@ -0,0 +1,46 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+)
+
+// プロバイダーの種類（設定の ai.providers[].type）
+const (
+	ProviderOllama = "ollama"
+	ProviderOpenAI = "openai" // OpenAI互換の /v1/chat/completions（LM Studio、vLLM、llama.cpp server など）
+)
+
+// Provider はAI分析に使うLLMサーバーです
+type Provider interface {
+	// Name はモデル選択に表示する名前を返します
+	Name() string
+	// CheckHealth はサーバーへの接続を確認します
+	CheckHealth(ctx context.Context) error
+	// ListModels は利用可能なモデル一覧を取得します
+	ListModels(ctx context.Context) ([]string, error)
+	// Generate はテキスト生成を行います（非ストリーミング）
+	Generate(ctx context.Context, messages []Message, model string) (string, error)
+	// GenerateStream はテキスト生成をストリーミングで行います
+	GenerateStream(ctx context.Context, messages []Message, model string) (<-chan GenerateResponseStream, error)
+	// ChatWithTools はツールを渡して1回問い合わせ、回答またはツールの呼び出しを返します
+	ChatWithTools(ctx context.Context, messages []Message, model string, tools []Tool) (Message, error)
+}
+
+var (
+	_ Provider = (*OllamaClient)(nil)
+	_ Provider = (*OpenAIClient)(nil)
+)
+
+// NewProvider は種類に応じたプロバイダーを作成します
+func NewProvider(kind, name, endpoint, apiKey string) (Provider, error) {
+	switch kind {
+	case "", ProviderOllama:
+		client := NewOllamaClient(endpoint)
+		client.DisplayName = name
+		return client, nil
+	case ProviderOpenAI:
+		return NewOpenAIClient(name, endpoint, apiKey), nil
+	}
+	return nil, fmt.Errorf("unknown AI provider type: %s (ollama / openai)", kind)
+}
@@ -0,0 +1,219 @@
+package llm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
+)
+
+// RedactionReport はマスクした値の件数（ルール名ごと）です
+type RedactionReport map[string]int
+
+// redactRule はシークレットらしい値を見つけて置き換えるルールです
+type redactRule struct {
+	name    string
+	pattern *regexp.Regexp
+	replace string // ${1} などでマスクしない前後の部分を残す
+}
+
+// builtinRedactRules は常に適用するルール（よく知られたシークレットの形式）
+var builtinRedactRules = []redactRule{
+	{"private_key", regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`), "[REDACTED:private_key]"},
+	{"aws_access_key", regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`), "[REDACTED:aws_access_key]"},
+	{"aws_secret_key", regexp.MustCompile(`(?i)(aws_?secret_?(?:access_?)?key["']?\s*[=:]\s*["']?)[A-Za-z0-9/+=]{40}`), "${1}[REDACTED:aws_secret_key]"},
+	{"jwt", regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{4,}\.[A-Za-z0-9_-]{4,}\.[A-Za-z0-9_-]+`), "[REDACTED:jwt]"},
+	{"github_token", regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{30,}|github_pat_[A-Za-z0-9_]{30,})\b`), "[REDACTED:github_token]"},
+	{"slack_token", regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`), "[REDACTED:slack_token]"},
+	{"api_key", regexp.MustCompile(`\bsk-[A-Za-z0-9_-]{20,}`), "[REDACTED:api_key]"},
+	{"bearer_token", regexp.MustCompile(`(?i)(\bbearer\s+)[A-Za-z0-9._~+/-]{8,}=*`), "${1}[REDACTED:bearer_token]"},
+	{"url_credentials", regexp.MustCompile(`(://[^:/@\s"']*:)([^@\s"']+)(@)`), "${1}[REDACTED]${3}"},
+	// PASSWORD=... や "api_key": "..." のような代入（環境変数、設定、コマンドライン）
+	// キー名はコンテナの環境変数のマスクと共通（max_tokens などは対象外）
+	// 値が [ で始まる場合は前のルールでマスク済み
+	{"secret_env", regexp.MustCompile(`(?i)(\b` + monitor.SecretKeyPattern + `["']?\s*[=:]\s*["']?)[^\s"',;&\[][^\s"',;&]*`), "${1}[REDACTED]"},
+	{"secret_flag", regexp.MustCompile(`(?i)(--?(?:password|passwd|token|api-?key|secret)[= ]["']?)[^\s"'\[][^\s"']*`), "${1}[REDACTED]"},
+}
+
+// Redactor masks secrets in the context before it is sent to the LLM
+type Redactor struct {
+	rules []redactRule
+	home  string // 空でなければホームディレクトリ以下のパスをハッシュ化する
+}
+
+// NewRedactor creates a redactor with the built-in rules and the user-defined patterns
+// 正しくない正規表現はエラーとして返し、残りのルールで動作する
+func NewRedactor(patterns []string, hashHomePaths bool) (*Redactor, error) {
+	r := &Redactor{rules: append([]redactRule{}, builtinRedactRules...)}
+
+	var errs []error
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid redaction pattern %q: %w", p, err))
+			continue
+		}
+		r.rules = append(r.rules, redactRule{name: "custom", pattern: re, replace: "[REDACTED]"})
+	}
+
+	if hashHomePaths {
+		if home, err := os.UserHomeDir(); err == nil && home != "/" {
+			r.home = strings.TrimRight(home, "/")
+		}
+	}
+	return r, errors.Join(errs...)
+}
+
+// Redact masks secrets in a text (ツールの結果など)
+func (r *Redactor) Redact(text string) string {
+	return r.redact(text, nil)
+}
+
+// RedactContext returns a copy of the context with secrets masked, and how many values were masked
+// 収集した値はキャッシュと共有している場合があるため、JSONを経由してコピーしてから書き換える
+func (r *Redactor) RedactContext(ctx *FullContext) (*FullContext, RedactionReport, error) {
+	data, err := json.Marshal(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	var redacted FullContext
+	if err := json.Unmarshal(data, &redacted); err != nil {
+		return nil, nil, err
+	}
+
+	report := RedactionReport{}
+	r.redactValue(reflect.ValueOf(&redacted).Elem(), report)
+	return &redacted, report, nil
+}
+
+// redactValue masks every string in the value
+func (r *Redactor) redactValue(v reflect.Value, report RedactionReport) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+		if v.Kind() == reflect.Interface {
+			// interface の中身は書き換えられないため、コピーを書き換えて入れ直す
+			elem := reflect.New(v.Elem().Type()).Elem()
+			elem.Set(v.Elem())
+			r.redactValue(elem, report)
+			v.Set(elem)
+			return
+		}
+		r.redactValue(v.Elem(), report)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Field(i).CanSet() {
+				r.redactValue(v.Field(i), report)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			r.redactValue(v.Index(i), report)
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(v.MapIndex(key))
+			r.redactValue(elem, report)
+			v.SetMapIndex(key, elem)
+		}
+	case reflect.String:
+		v.SetString(r.redact(v.String(), report))
+	}
+}
+
+// redact applies the rules to a text, counting the masked values in report (nil なら数えない)
+func (r *Redactor) redact(text string, report RedactionReport) string {
+	for _, rule := range r.rules {
+		matches := len(rule.pattern.FindAllStringIndex(text, -1))
+		if matches == 0 {
+			continue
+		}
+		text = rule.pattern.ReplaceAllString(text, rule.replace)
+		if report != nil {
+			report[rule.name] += matches
+		}
+	}
+	if r.home != "" {
+		text = r.hashHomePaths(text, report)
+	}
+	return text
+}
+
+// homePathComponent はパスの1要素として扱う文字
+var homePathComponent = regexp.MustCompile(`^/[^/\s"'` + "`" + `:;,()\[\]{}<>|=]+`)
+
+// hashHomePaths replaces paths under the home directory with "~/<hash>/<hash>"
+// 同じディレクトリは同じハッシュになるため、AIはパスの名前を知らずに同じ場所かどうかを判断できる
+func (r *Redactor) hashHomePaths(text string, report RedactionReport) string {
+	var b strings.Builder
+	for {
+		i := strings.Index(text, r.home)
+		if i < 0 {
+			b.WriteString(text)
+			return b.String()
+		}
+		b.WriteString(text[:i])
+		rest := text[i+len(r.home):]
+
+		// /home/al が /home/alice の一部に一致した場合などは置き換えない
+		if rest != "" && !strings.HasPrefix(rest, "/") && isPathChar(rest[0]) {
+			b.WriteString(r.home)
+			text = rest
+			continue
+		}
+
+		b.WriteString("~")
+		for {
+			component := homePathComponent.FindString(rest)
+			if component == "" {
+				break
+			}
+			sum := sha256.Sum256([]byte(component[1:]))
+			b.WriteString("/" + hex.EncodeToString(sum[:])[:8])
+			rest = rest[len(component):]
+		}
+		if report != nil {
+			report["home_path"]++
+		}
+		text = rest
+	}
+}
+
+// isPathChar reports whether c can continue a file name
+func isPathChar(c byte) bool {
+	return c == '.' || c == '_' || c == '-' || (c >= '0' && c <= '9') || (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z')
+}
+
+// Total returns the number of masked values
+func (r RedactionReport) Total() int {
+	total := 0
+	for _, n := range r {
+		total += n
+	}
+	return total
+}
+
+// String returns the counts by rule, e.g. "jwt 2, url_credentials 1"
+func (r RedactionReport) String() string {
+	names := make([]string, 0, len(r))
+	for name := range r {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s %d", name, r[name])
+	}
+	return strings.Join(parts, ", ")
+}
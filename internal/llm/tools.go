@@ -0,0 +1,112 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Tool はモデルに渡す呼び出し可能な関数の定義です（Ollamaのtools API）
+type Tool struct {
+	Type     string       `json:"type"` // 常に "function"
+	Function ToolFunction `json:"function"`
+}
+
+// ToolFunction はツールの名前・説明・引数のJSONスキーマです
+type ToolFunction struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Parameters  ToolParameters `json:"parameters"`
+}
+
+// ToolParameters はツールの引数のJSONスキーマ（object）です
+type ToolParameters struct {
+	Type       string                  `json:"type"` // 常に "object"
+	Properties map[string]ToolProperty `json:"properties"`
+	Required   []string                `json:"required,omitempty"`
+}
+
+// ToolProperty はツールの引数1つの定義です
+type ToolProperty struct {
+	Type        string `json:"type"` // string / integer など
+	Description string `json:"description"`
+}
+
+// ToolCall はモデルが要求したツールの呼び出しです
+type ToolCall struct {
+	ID       string           `json:"id,omitempty"` // OpenAI互換のみ（結果の tool_call_id に使う）
+	Function ToolCallFunction `json:"function"`
+}
+
+// ToolCallFunction は呼び出すツールの名前と引数です
+type ToolCallFunction struct {
+	Name      string         `json:"name"`
+	Arguments map[string]any `json:"arguments"`
+}
+
+// NewTool はツールの定義を作成します
+func NewTool(name, description string, properties map[string]ToolProperty, required ...string) Tool {
+	if properties == nil {
+		properties = map[string]ToolProperty{}
+	}
+	return Tool{
+		Type: "function",
+		Function: ToolFunction{
+			Name:        name,
+			Description: description,
+			Parameters: ToolParameters{
+				Type:       "object",
+				Properties: properties,
+				Required:   required,
+			},
+		},
+	}
+}
+
+// ChatWithTools はツールを渡して1回問い合わせ、モデルの応答（回答またはツールの呼び出し）を返します
+// ツールの呼び出しはストリーミングでは途中で分割されるため、非ストリーミングで行う
+func (c *OllamaClient) ChatWithTools(ctx context.Context, messages []Message, model string, tools []Tool) (Message, error) {
+	reqBody := ChatRequest{
+		Model:    model,
+		Messages: messages,
+		Stream:   false,
+		Tools:    tools,
+		Options: &Options{
+			NumCtx: 8192, // ツールの結果を含めるため大きめに確保
+		},
+	}
+
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return Message{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.Endpoint+"/api/chat", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return Message{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.doRequestWithRetry(req)
+	if err != nil {
+		return Message{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return Message{}, fmt.Errorf("APIエラー (%s): %s", resp.Status, string(body))
+	}
+
+	var result chatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return Message{}, err
+	}
+	if result.Error != "" {
+		return Message{}, fmt.Errorf("Ollama API error: %s", result.Error)
+	}
+	return result.Message, nil
+}
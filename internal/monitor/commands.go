@@ -1,9 +1,16 @@
 package monitor
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/url"
 	"os/exec"
+	"slices"
+	"strconv"
 	"strings"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/config"
 )
 
 // CommandResult holds the result of command execution
@@ -16,6 +23,8 @@ type CommandResult struct {
 func ExecuteDockerCommand(target, action, targetType string) CommandResult {
 	if targetType == "project" {
 		return executeComposeProjectCommand(target, action)
+	} else if targetType == "service" {
+		return executeComposeServiceCommand(target, action)
 	} else {
 		return executeDockerContainerCommand(target, action)
 	}
@@ -32,17 +41,17 @@ func executeDockerContainerCommand(containerID, action string) CommandResult {
 
 	switch action {
 	case "start":
-		cmd = exec.Command("docker", "start", containerID)
+		cmd = exec.Command(ContainerCLI(), "start", containerID)
 	case "stop":
-		cmd = exec.Command("docker", "stop", containerID)
+		cmd = exec.Command(ContainerCLI(), "stop", containerID)
 	case "restart":
-		cmd = exec.Command("docker", "restart", containerID)
+		cmd = exec.Command(ContainerCLI(), "restart", containerID)
 	case "rebuild":
 		// 個別コンテナのリビルドはdocker-compose経由で実行
 		return executeContainerRebuild(containerID)
 	case "remove":
 		// コンテナを削除（-vオプションで関連ボリュームも削除）
-		cmd = exec.Command("docker", "rm", "-f", containerID)
+		cmd = exec.Command(ContainerCLI(), "rm", "-f", containerID)
 	default:
 		return CommandResult{Success: false, Message: "不明なアクション"}
 	}
@@ -69,58 +78,46 @@ func executeComposeProjectCommand(projectName, action string) CommandResult {
 		return CommandResult{Success: false, Message: "不正なプロジェクト名です"}
 	}
 
-	// プロジェクトのコンテナを取得してワーキングディレクトリを見つける
-	workDir := findComposeWorkDir(projectName)
-	if workDir == "" {
+	// コンテナのラベルまたは記憶しているプロジェクトからCompose設定を取得
+	project, ok := ResolveComposeProject(projectName)
+	if !ok {
 		return CommandResult{
 			Success: false,
 			Message: fmt.Sprintf("プロジェクト %s の作業ディレクトリが見つかりません", projectName),
 		}
 	}
+	if len(project.ConfigFiles) == 0 {
+		return CommandResult{
+			Success: false,
+			Message: fmt.Sprintf("プロジェクト %s のComposeファイルが見つかりません (%s)", projectName, project.WorkingDir),
+		}
+	}
 
 	// docker-composeコマンドを判定
 	composeCmd := getComposeCommand()
-	if composeCmd == "" {
+	if composeCmd == nil {
 		return CommandResult{
 			Success: false,
-			Message: "docker-compose または docker compose コマンドが見つかりません",
+			Message: "composeコマンド（docker compose / podman-compose / nerdctl compose）が見つかりません",
 		}
 	}
 
 	var cmd *exec.Cmd
 
-	// docker-compose v1 or v2
-	if strings.HasPrefix(composeCmd, "docker-compose") {
-		switch action {
-		case "start_project":
-			cmd = exec.Command("docker-compose", "-f", workDir+"/docker-compose.yml", "up", "-d")
-		case "stop_project":
-			cmd = exec.Command("docker-compose", "-f", workDir+"/docker-compose.yml", "stop")
-		case "delete_project":
-			cmd = exec.Command("docker-compose", "-f", workDir+"/docker-compose.yml", "down")
-		case "restart_project":
-			cmd = exec.Command("docker-compose", "-f", workDir+"/docker-compose.yml", "up", "-d")
-		case "rebuild_project":
-			cmd = exec.Command("docker-compose", "-f", workDir+"/docker-compose.yml", "up", "-d", "--build")
-		default:
-			return CommandResult{Success: false, Message: "不明なアクション"}
-		}
-	} else {
-		// docker compose v2
-		switch action {
-		case "start_project":
-			cmd = exec.Command("docker", "compose", "-f", workDir+"/docker-compose.yml", "up", "-d")
-		case "stop_project":
-			cmd = exec.Command("docker", "compose", "-f", workDir+"/docker-compose.yml", "stop")
-		case "delete_project":
-			cmd = exec.Command("docker", "compose", "-f", workDir+"/docker-compose.yml", "down")
-		case "restart_project":
-			cmd = exec.Command("docker", "compose", "-f", workDir+"/docker-compose.yml", "up", "-d")
-		case "rebuild_project":
-			cmd = exec.Command("docker", "compose", "-f", workDir+"/docker-compose.yml", "up", "-d", "--build")
-		default:
-			return CommandResult{Success: false, Message: "不明なアクション"}
-		}
+	// -f / --env-file / --profile を付けて v1 / v2 どちらでも同じ構成を再現する
+	switch action {
+	case "start_project":
+		cmd = newComposeCommand(composeCmd, project, "up", "-d")
+	case "stop_project":
+		cmd = newComposeCommand(composeCmd, project, "stop")
+	case "delete_project":
+		cmd = newComposeCommand(composeCmd, project, "down")
+	case "restart_project":
+		cmd = newComposeCommand(composeCmd, project, "up", "-d")
+	case "rebuild_project":
+		cmd = newComposeCommand(composeCmd, project, "up", "-d", "--build")
+	default:
+		return CommandResult{Success: false, Message: "不明なアクション"}
 	}
 
 	output, err := cmd.CombinedOutput()
@@ -167,22 +164,17 @@ func executeContainerRebuild(containerID string) CommandResult {
 		return CommandResult{Success: false, Message: "Composeコンテナではありません"}
 	}
 
-	workDir := findComposeWorkDir(targetContainer.ComposeProject)
-	if workDir == "" {
+	project, ok := ResolveComposeProject(targetContainer.ComposeProject)
+	if !ok || len(project.ConfigFiles) == 0 {
 		return CommandResult{Success: false, Message: "作業ディレクトリが見つかりません"}
 	}
 
 	composeCmd := getComposeCommand()
-	if composeCmd == "" {
-		return CommandResult{Success: false, Message: "docker-composeコマンドが見つかりません"}
+	if composeCmd == nil {
+		return CommandResult{Success: false, Message: "composeコマンドが見つかりません"}
 	}
 
-	var cmd *exec.Cmd
-	if strings.HasPrefix(composeCmd, "docker-compose") {
-		cmd = exec.Command("docker-compose", "-f", workDir+"/docker-compose.yml", "up", "-d", "--build", targetContainer.ComposeService)
-	} else {
-		cmd = exec.Command("docker", "compose", "-f", workDir+"/docker-compose.yml", "up", "-d", "--build", targetContainer.ComposeService)
-	}
+	cmd := newComposeCommand(composeCmd, project, "up", "-d", "--build", targetContainer.ComposeService)
 
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -198,40 +190,68 @@ func executeContainerRebuild(containerID string) CommandResult {
 	}
 }
 
-// findComposeWorkDir finds the working directory for a compose project
-func findComposeWorkDir(projectName string) string {
-	containers := GetDockerContainers()
-	for _, c := range containers {
-		if c.ComposeProject == projectName {
-			// docker inspectでworking dirを取得
-			cmd := exec.Command("docker", "inspect", c.ID, "--format", "{{index .Config.Labels \"com.docker.compose.project.working_dir\"}}")
-			output, err := cmd.Output()
-			if err == nil {
-				workDir := strings.TrimSpace(string(output))
-				if workDir != "" && workDir != "<no value>" {
-					return workDir
-				}
-			}
-		}
-	}
-	return ""
-}
+// executeComposeServiceCommand executes command on a single compose service
+// target は "プロジェクト:サービス"、スケール時は "プロジェクト:サービス:レプリカ数"
+func executeComposeServiceCommand(target, action string) CommandResult {
+	parts := strings.Split(target, ":")
+	if len(parts) < 2 {
+		return CommandResult{Success: false, Message: "不正なサービス指定です"}
+	}
+	projectName, service := parts[0], parts[1]
+
+	// セキュリティバリデーション
+	if !IsValidIdentifier(projectName) || !IsValidComposeServiceName(service) {
+		return CommandResult{Success: false, Message: "不正なサービス名です"}
+	}
+
+	var args []string
+	actionJP := ""
+	switch action {
+	case "up_service":
+		args = []string{"up", "-d", service}
+		actionJP = "起動"
+	case "restart_service":
+		args = []string{"restart", service}
+		actionJP = "再起動"
+	case "build_service":
+		args = []string{"build", service}
+		actionJP = "ビルド"
+	case "scale_service":
+		if len(parts) != 3 {
+			return CommandResult{Success: false, Message: "レプリカ数が指定されていません"}
+		}
+		replicas, err := strconv.Atoi(parts[2])
+		if err != nil || replicas < 0 || replicas > 20 {
+			return CommandResult{Success: false, Message: "レプリカ数は0〜20で指定してください"}
+		}
+		args = []string{"up", "-d", "--no-recreate", "--scale", fmt.Sprintf("%s=%d", service, replicas), service}
+		actionJP = fmt.Sprintf("%d台にスケール", replicas)
+	default:
+		return CommandResult{Success: false, Message: "不明なアクション"}
+	}
 
-// getComposeCommand returns the available docker-compose command
-func getComposeCommand() string {
-	// docker-compose (v1) をチェック
-	cmd := exec.Command("which", "docker-compose")
-	if err := cmd.Run(); err == nil {
-		return "docker-compose"
+	argv, err := BuildComposeCommand(projectName, args...)
+	if err != nil {
+		return CommandResult{Success: false, Message: err.Error()}
 	}
 
-	// docker compose (v2) をチェック
-	cmd = exec.Command("docker", "compose", "version")
-	if err := cmd.Run(); err == nil {
-		return "docker compose"
+	output, err := exec.Command(argv[0], argv[1:]...).CombinedOutput()
+	if err != nil {
+		return CommandResult{
+			Success: false,
+			Message: fmt.Sprintf("Compose操作失敗: %s", string(output)),
+		}
 	}
 
-	return ""
+	return CommandResult{
+		Success: true,
+		Message: fmt.Sprintf("サービス %s を%sしました", service, actionJP),
+	}
+}
+
+// getComposeCommand returns the compose command of the current container runtime (nil if unavailable)
+func getComposeCommand() []string {
+	return CurrentContainerRuntime().ComposeCommand()
 }
 
 // getActionJapanese converts action to Japanese
@@ -353,10 +373,10 @@ func ExecuteMySQLCommand(databaseName, action string) CommandResult {
 	switch action {
 	case "drop":
 		// データベースを削除
-		cmd = exec.Command("mysql", "-e", fmt.Sprintf("DROP DATABASE IF EXISTS %s;", databaseName))
+		cmd = mysqlCommand(context.Background(), "-e", fmt.Sprintf("DROP DATABASE IF EXISTS `%s`;", databaseName))
 	case "optimize":
-		// データベースを最適化
-		cmd = exec.Command("mysql", "-e", fmt.Sprintf("OPTIMIZE TABLE %s.*;", databaseName))
+		// データベース内の全テーブルを最適化
+		cmd = mysqlCommand(context.Background(), "--database="+databaseName, "-e", buildMySQLOptimizeQuery(databaseName))
 	default:
 		return CommandResult{Success: false, Message: "不明なアクション"}
 	}
@@ -383,6 +403,46 @@ func ExecuteMySQLCommand(databaseName, action string) CommandResult {
 	}
 }
 
+// buildMySQLOptimizeQuery builds OPTIMIZE TABLE for all tables in the database
+func buildMySQLOptimizeQuery(databaseName string) string {
+	tables := GetMySQLTables(databaseName)
+
+	var names []string
+	for _, t := range tables {
+		if IsValidIdentifier(t.Name) {
+			names = append(names, fmt.Sprintf("`%s`", t.Name))
+		}
+	}
+
+	if len(names) == 0 {
+		return "SELECT 1;"
+	}
+
+	return fmt.Sprintf("OPTIMIZE TABLE %s;", strings.Join(names, ", "))
+}
+
+// ExecuteMySQLKillQuery kills the running query of a MySQL connection
+func ExecuteMySQLKillQuery(processID string) CommandResult {
+	// セキュリティバリデーション: 接続IDが数字のみであることを確認
+	if !IsValidPID(processID) {
+		return CommandResult{Success: false, Message: "不正な接続IDです"}
+	}
+
+	cmd := mysqlCommand(context.Background(), "-e", fmt.Sprintf("KILL QUERY %s;", processID))
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return CommandResult{
+			Success: false,
+			Message: fmt.Sprintf("クエリ停止失敗: %s", string(output)),
+		}
+	}
+
+	return CommandResult{
+		Success: true,
+		Message: fmt.Sprintf("MySQL接続 %s のクエリを停止しました", processID),
+	}
+}
+
 // ExecuteRedisCommand executes a Redis command
 func ExecuteRedisCommand(dbIndex, action string) CommandResult {
 	// セキュリティバリデーション: dbIndexが安全な形式であることを確認
@@ -391,21 +451,20 @@ func ExecuteRedisCommand(dbIndex, action string) CommandResult {
 		return CommandResult{Success: false, Message: "不正なデータベースインデックスです"}
 	}
 
-	var cmd *exec.Cmd
+	var args []string
 
 	switch action {
 	case "flushdb":
 		// データベースをクリア
-		cmd = exec.Command("redis-cli", "-n", dbNum, "FLUSHDB")
+		args = []string{"FLUSHDB"}
 	default:
 		return CommandResult{Success: false, Message: "不明なアクション"}
 	}
 
-	output, err := cmd.CombinedOutput()
-	if err != nil {
+	if _, err := runRedisCommand(redisDBNumber(dbIndex), args...); err != nil {
 		return CommandResult{
 			Success: false,
-			Message: fmt.Sprintf("Redis操作失敗: %s", string(output)),
+			Message: fmt.Sprintf("Redis操作失敗: %s", err.Error()),
 		}
 	}
 
@@ -421,6 +480,153 @@ func ExecuteRedisCommand(dbIndex, action string) CommandResult {
 	}
 }
 
+// ExecuteRedisDeleteKey deletes a key
+// target は "db0:key" の形式（キー名にはコロンを含められるため最初のコロンで分割）
+func ExecuteRedisDeleteKey(target string) CommandResult {
+	parts := strings.SplitN(target, ":", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return CommandResult{Success: false, Message: "不正なキー指定です"}
+	}
+
+	dbIndex, key := parts[0], parts[1]
+	dbNum := strings.TrimPrefix(dbIndex, "db")
+	if !IsValidPID(dbNum) {
+		return CommandResult{Success: false, Message: "不正なデータベースインデックスです"}
+	}
+
+	// RESPで送信するためキー名のエスケープは不要
+	reply, err := runRedisCommand(redisDBNumber(dbIndex), "DEL", key)
+	if err != nil {
+		return CommandResult{
+			Success: false,
+			Message: fmt.Sprintf("Redis操作失敗: %s", err.Error()),
+		}
+	}
+
+	if redisInt(reply) == 0 {
+		return CommandResult{
+			Success: false,
+			Message: fmt.Sprintf("キー %s は存在しません", key),
+		}
+	}
+
+	return CommandResult{
+		Success: true,
+		Message: fmt.Sprintf("Redis %s のキー %s を削除しました", dbIndex, key),
+	}
+}
+
+// ExecuteMongoCommand executes a MongoDB database command
+func ExecuteMongoCommand(databaseName, action string) CommandResult {
+	// セキュリティバリデーション: データベース名が安全な形式であることを確認
+	if !IsValidIdentifier(databaseName) {
+		return CommandResult{Success: false, Message: "不正なデータベース名です"}
+	}
+
+	// システムデータベースは操作させない
+	if IsMongoSystemDatabase(databaseName) {
+		return CommandResult{Success: false, Message: "システムデータベースは操作できません"}
+	}
+
+	var script string
+
+	switch action {
+	case "drop":
+		// データベースを削除
+		script = fmt.Sprintf(`JSON.stringify(db.getSiblingDB(%q).dropDatabase())`, databaseName)
+	default:
+		return CommandResult{Success: false, Message: "不明なアクション"}
+	}
+
+	var result struct {
+		OK float64 `json:"ok"`
+	}
+	if err := runMongoEval(config.Load().Mongo, script, &result); err != nil {
+		return CommandResult{
+			Success: false,
+			Message: fmt.Sprintf("MongoDB操作失敗: %s", err.Error()),
+		}
+	}
+	if result.OK != 1 {
+		return CommandResult{Success: false, Message: "MongoDB操作失敗"}
+	}
+
+	actionJP := ""
+	switch action {
+	case "drop":
+		actionJP = "削除"
+	}
+
+	return CommandResult{
+		Success: true,
+		Message: fmt.Sprintf("MongoDB %s を%sしました", databaseName, actionJP),
+	}
+}
+
+// ExecuteRabbitMQPurge purges all ready messages of a queue
+// target は RabbitMQQueueKey で作成した識別子
+func ExecuteRabbitMQPurge(target string) CommandResult {
+	vhost, name, ok := splitRabbitMQQueueKey(target)
+	if !ok {
+		return CommandResult{Success: false, Message: "不正なキュー指定です"}
+	}
+
+	// vhost・キュー名はURLエンコードしてパスに埋め込む
+	path := fmt.Sprintf("/api/queues/%s/%s/contents", url.PathEscape(vhost), url.PathEscape(name))
+	if _, err := rabbitMQRequest(config.Load().RabbitMQ, http.MethodDelete, path); err != nil {
+		return CommandResult{
+			Success: false,
+			Message: fmt.Sprintf("RabbitMQ操作失敗: %s", err.Error()),
+		}
+	}
+
+	return CommandResult{
+		Success: true,
+		Message: fmt.Sprintf("キュー %s をパージしました", name),
+	}
+}
+
+// ExecuteElasticsearchCommand executes an Elasticsearch/OpenSearch index command
+func ExecuteElasticsearchCommand(indexName, action string) CommandResult {
+	// セキュリティバリデーション: ワイルドカードや _all による一括操作を防ぐ
+	if !IsValidElasticsearchIndex(indexName) {
+		return CommandResult{Success: false, Message: "不正なインデックス名です"}
+	}
+
+	var method, path string
+
+	switch action {
+	case "delete_index":
+		// インデックスを削除
+		method, path = http.MethodDelete, "/"+indexName
+	case "clear_cache":
+		// キャッシュをクリア
+		method, path = http.MethodPost, "/"+indexName+"/_cache/clear"
+	default:
+		return CommandResult{Success: false, Message: "不明なアクション"}
+	}
+
+	if _, err := elasticsearchRequest(method, path); err != nil {
+		return CommandResult{
+			Success: false,
+			Message: fmt.Sprintf("Elasticsearch操作失敗: %s", err.Error()),
+		}
+	}
+
+	actionJP := ""
+	switch action {
+	case "delete_index":
+		actionJP = "削除"
+	case "clear_cache":
+		actionJP = "キャッシュクリア"
+	}
+
+	return CommandResult{
+		Success: true,
+		Message: fmt.Sprintf("インデックス %s を%sしました", indexName, actionJP),
+	}
+}
+
 // ExecutePythonCommand executes a Python process command
 func ExecutePythonCommand(pid, action string) CommandResult {
 	// セキュリティバリデーション: PIDが数字のみであることを確認
@@ -463,6 +669,48 @@ func ExecutePythonCommand(pid, action string) CommandResult {
 	}
 }
 
+// ExecuteRuntimeCommand executes a runtime (Go, Ruby, JVM, Deno/Bun, PHP) process command
+func ExecuteRuntimeCommand(pid, action string) CommandResult {
+	// セキュリティバリデーション: PIDが数字のみであることを確認
+	if !IsValidPID(pid) {
+		return CommandResult{Success: false, Message: "不正なPIDです"}
+	}
+
+	var cmd *exec.Cmd
+
+	switch action {
+	case "kill":
+		// プロセスを停止
+		cmd = exec.Command("kill", pid)
+	case "force_kill":
+		// プロセスを強制停止
+		cmd = exec.Command("kill", "-9", pid)
+	default:
+		return CommandResult{Success: false, Message: "不明なアクション"}
+	}
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return CommandResult{
+			Success: false,
+			Message: fmt.Sprintf("プロセス操作失敗: %s", string(output)),
+		}
+	}
+
+	actionJP := ""
+	switch action {
+	case "kill":
+		actionJP = "停止"
+	case "force_kill":
+		actionJP = "強制停止"
+	}
+
+	return CommandResult{
+		Success: true,
+		Message: fmt.Sprintf("プロセス (PID: %s) を%sしました", pid, actionJP),
+	}
+}
+
 // ExecutePortCommand executes a command on a port (process)
 func ExecutePortCommand(pid, action string) CommandResult {
 	// セキュリティバリデーション: PIDが数字のみであることを確認
@@ -507,7 +755,7 @@ func ExecutePortCommand(pid, action string) CommandResult {
 
 // CleanDanglingImages removes all dangling images
 func CleanDanglingImages() CommandResult {
-	cmd := exec.Command("docker", "image", "prune", "-f")
+	cmd := exec.Command(ContainerCLI(), "image", "prune", "-f")
 	output, err := cmd.CombinedOutput()
 
 	if err != nil {
@@ -522,3 +770,153 @@ func CleanDanglingImages() CommandResult {
 		Message: "ダングリングイメージを削除しました",
 	}
 }
+
+// ExecuteDockerResourceCommand removes a Docker volume, network or image
+func ExecuteDockerResourceCommand(target, action string) CommandResult {
+	var cmd *exec.Cmd
+	kindJP := ""
+
+	switch action {
+	case "remove_volume":
+		if !IsValidDockerObjectName(target) {
+			return CommandResult{Success: false, Message: "不正なボリューム名です"}
+		}
+		cmd = exec.Command(ContainerCLI(), "volume", "rm", target)
+		kindJP = "ボリューム"
+	case "remove_network":
+		if !IsValidDockerObjectName(target) {
+			return CommandResult{Success: false, Message: "不正なネットワーク名です"}
+		}
+		if (DockerNetwork{Name: target}).Builtin() {
+			return CommandResult{Success: false, Message: "デフォルトネットワークは削除できません"}
+		}
+		cmd = exec.Command(ContainerCLI(), "network", "rm", target)
+		kindJP = "ネットワーク"
+	case "remove_image":
+		// セキュリティバリデーション: イメージIDが16進数のみであることを確認
+		if !IsValidContainerID(target) {
+			return CommandResult{Success: false, Message: "不正なイメージIDです"}
+		}
+		cmd = exec.Command(ContainerCLI(), "image", "rm", target)
+		kindJP = "イメージ"
+	default:
+		return CommandResult{Success: false, Message: "不明なアクション"}
+	}
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return CommandResult{
+			Success: false,
+			Message: fmt.Sprintf("%sの削除失敗: %s", kindJP, strings.TrimSpace(string(output))),
+		}
+	}
+
+	return CommandResult{
+		Success: true,
+		Message: fmt.Sprintf("%s %s を削除しました", kindJP, target),
+	}
+}
+
+// PruneOrphanedVolumes removes volumes that no container uses
+func PruneOrphanedVolumes() CommandResult {
+	volumes, err := loadDockerVolumes()
+	if err != nil {
+		// 使用中のボリュームを未使用と誤って削除しないよう中止する
+		return CommandResult{Success: false, Message: fmt.Sprintf("ボリュームの使用状況を確認できないため中止しました: %s", err.Error())}
+	}
+
+	removed := 0
+	var failed []string
+	for _, v := range volumes {
+		if !v.Orphaned() || !IsValidDockerObjectName(v.Name) {
+			continue
+		}
+		if err := exec.Command(ContainerCLI(), "volume", "rm", v.Name).Run(); err != nil {
+			failed = append(failed, v.Name)
+			continue
+		}
+		removed++
+	}
+
+	if len(failed) > 0 {
+		return CommandResult{
+			Success: false,
+			Message: fmt.Sprintf("%d個のボリュームを削除しました（削除失敗: %s）", removed, strings.Join(failed, ", ")),
+		}
+	}
+	return CommandResult{Success: true, Message: fmt.Sprintf("未使用のボリュームを%d個削除しました", removed)}
+}
+
+// PruneNetworks removes networks that no container is attached to
+func PruneNetworks() CommandResult {
+	output, err := exec.Command(ContainerCLI(), "network", "prune", "-f").CombinedOutput()
+	if err != nil {
+		return CommandResult{
+			Success: false,
+			Message: fmt.Sprintf("ネットワークの削除失敗: %s", string(output)),
+		}
+	}
+	return CommandResult{Success: true, Message: "未使用のネットワークを削除しました"}
+}
+
+// PruneUnusedImages removes images without containers that have been unused for UnusedImageDays
+func PruneUnusedImages() CommandResult {
+	images, err := loadDockerImages()
+	if err != nil {
+		// 停止中のコンテナが使うイメージを未使用と誤って削除しないよう中止する
+		return CommandResult{Success: false, Message: fmt.Sprintf("イメージの使用状況を確認できないため中止しました: %s", err.Error())}
+	}
+
+	removed := 0
+	var reclaimed int64
+	var failed []string
+	for _, image := range images {
+		if !image.Unused() || !IsValidContainerID(image.ID) {
+			continue
+		}
+		// -f を付けずにタグ（タグがなければID）で削除し、コンテナが使っているイメージはランタイムに拒否させる
+		targets := []string{image.ID}
+		if len(image.Tags) > 0 {
+			targets = image.Tags
+		}
+		if slices.ContainsFunc(targets, func(t string) bool { return !IsValidImageReference(t) }) {
+			failed = append(failed, image.ID)
+			continue
+		}
+		if err := exec.Command(ContainerCLI(), append([]string{"image", "rm"}, targets...)...).Run(); err != nil {
+			failed = append(failed, image.ID)
+			continue
+		}
+		removed++
+		reclaimed += image.SizeBytes
+	}
+
+	if len(failed) > 0 {
+		return CommandResult{
+			Success: false,
+			Message: fmt.Sprintf("%d個のイメージを削除しました（削除失敗: %s）", removed, strings.Join(failed, ", ")),
+		}
+	}
+	return CommandResult{
+		Success: true,
+		Message: fmt.Sprintf("%d日以上未使用のイメージを%d個削除しました（約%s）", UnusedImageDays, removed, formatBytes(reclaimed)),
+	}
+}
+
+// PruneBuildCache removes the build cache
+func PruneBuildCache() CommandResult {
+	output, err := exec.Command(ContainerCLI(), "builder", "prune", "-f").CombinedOutput()
+	if err != nil {
+		return CommandResult{
+			Success: false,
+			Message: fmt.Sprintf("ビルドキャッシュの削除失敗: %s", string(output)),
+		}
+	}
+
+	// 最終行に "Total reclaimed space: 1.2GB" が出力される
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	return CommandResult{
+		Success: true,
+		Message: "ビルドキャッシュを削除しました: " + lines[len(lines)-1],
+	}
+}
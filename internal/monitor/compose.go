@@ -0,0 +1,424 @@
+package monitor
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ComposeProject describes how a Docker Compose project was brought up
+type ComposeProject struct {
+	Name        string
+	WorkingDir  string
+	ConfigFiles []string // -f で渡すファイル（オーバーライドを含む、指定順）
+	EnvFiles    []string // --env-file で渡すファイル
+	Profiles    []string // --profile で有効化するプロファイル
+	LastSeen    time.Time
+}
+
+// composeFileNames はComposeが既定で探すファイル名（優先順）
+var composeFileNames = []string{
+	"compose.yaml",
+	"compose.yml",
+	"docker-compose.yaml",
+	"docker-compose.yml",
+}
+
+// composeServiceNamePattern はComposeのサービス名として許可する文字
+var composeServiceNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+
+// composeLabelSeparator はdocker inspectの出力でラベルを区切る文字
+const composeLabelSeparator = "|"
+
+// 既知のComposeプロジェクト（コンテナがすべて削除されても起動できるように保持）
+var (
+	knownComposeProjectsMu sync.RWMutex
+	knownComposeProjects   = make(map[string]ComposeProject)
+)
+
+// ComposeProjectExpiry はコンテナが見られなくなったプロジェクトを忘れるまでの期間
+const ComposeProjectExpiry = 30 * 24 * time.Hour
+
+// composeProjectTouchInterval は設定が変わらなくても最終確認日時を更新する間隔
+const composeProjectTouchInterval = time.Hour
+
+// RememberComposeProjects registers compose projects so they can be started without containers
+// 新しいか設定が変わったプロジェクト（保存が必要なもの）を返します
+func RememberComposeProjects(projects []ComposeProject) []ComposeProject {
+	knownComposeProjectsMu.Lock()
+	defer knownComposeProjectsMu.Unlock()
+
+	var changed []ComposeProject
+	for _, p := range projects {
+		if p.Name == "" || p.WorkingDir == "" {
+			continue
+		}
+		// 更新のたびに保存しないよう、同じ設定なら最終確認日時を一定間隔でのみ進める
+		if old, exists := knownComposeProjects[p.Name]; exists && old.sameSetup(p) && p.LastSeen.Sub(old.LastSeen) < composeProjectTouchInterval {
+			continue
+		}
+		knownComposeProjects[p.Name] = p
+		changed = append(changed, p)
+	}
+
+	// 長く見られていないプロジェクトは忘れる
+	for name, p := range knownComposeProjects {
+		if !p.LastSeen.IsZero() && time.Since(p.LastSeen) > ComposeProjectExpiry {
+			delete(knownComposeProjects, name)
+		}
+	}
+
+	return changed
+}
+
+// sameSetup reports whether two descriptors start the project the same way
+func (p ComposeProject) sameSetup(other ComposeProject) bool {
+	return p.WorkingDir == other.WorkingDir &&
+		slices.Equal(p.ConfigFiles, other.ConfigFiles) &&
+		slices.Equal(p.EnvFiles, other.EnvFiles) &&
+		slices.Equal(p.Profiles, other.Profiles)
+}
+
+// KnownComposeProjects returns all remembered compose projects sorted by name
+func KnownComposeProjects() []ComposeProject {
+	knownComposeProjectsMu.RLock()
+	defer knownComposeProjectsMu.RUnlock()
+
+	projects := make([]ComposeProject, 0, len(knownComposeProjects))
+	for _, p := range knownComposeProjects {
+		projects = append(projects, p)
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].Name < projects[j].Name
+	})
+	return projects
+}
+
+// ResolveComposeProject returns the compose configuration for a project name
+func ResolveComposeProject(projectName string) (ComposeProject, bool) {
+	// コンテナが残っていればラベルから最新の設定を取得
+	projects := GetComposeProjects(GetDockerContainers())
+	for _, p := range projects {
+		if p.Name == projectName {
+			return p, true
+		}
+	}
+
+	// コンテナがない場合は記憶しているプロジェクトから探す
+	knownComposeProjectsMu.RLock()
+	defer knownComposeProjectsMu.RUnlock()
+	p, ok := knownComposeProjects[projectName]
+	return p, ok
+}
+
+// GetComposeProjects builds compose project descriptors from container labels
+func GetComposeProjects(containers []DockerContainer) []ComposeProject {
+	// プロジェクトごとにコンテナとサービスを集める
+	containerIDs := make(map[string]string)
+	services := make(map[string][]string)
+	var names []string
+	for _, c := range containers {
+		if c.ComposeProject == "" {
+			continue
+		}
+		if _, exists := containerIDs[c.ComposeProject]; !exists {
+			containerIDs[c.ComposeProject] = c.ID
+			names = append(names, c.ComposeProject)
+		}
+		services[c.ComposeProject] = append(services[c.ComposeProject], c.ComposeService)
+	}
+	sort.Strings(names)
+
+	var projects []ComposeProject
+	for _, name := range names {
+		project, ok := inspectComposeProject(containerIDs[name])
+		if !ok {
+			continue
+		}
+		project.Name = name
+		project.Profiles = resolveComposeProfiles(project, services[name])
+		project.LastSeen = time.Now()
+		projects = append(projects, project)
+	}
+
+	return projects
+}
+
+// inspectComposeProject reads the compose project labels of a container
+func inspectComposeProject(containerID string) (ComposeProject, bool) {
+	// 入力検証
+	if !IsValidContainerID(containerID) && !IsValidIdentifier(containerID) {
+		return ComposeProject{}, false
+	}
+
+	format := strings.Join([]string{
+		`{{index .Config.Labels "com.docker.compose.project.working_dir"}}`,
+		`{{index .Config.Labels "com.docker.compose.project.config_files"}}`,
+		`{{index .Config.Labels "com.docker.compose.project.environment_file"}}`,
+	}, composeLabelSeparator)
+
+	output, err := RunCommandWithTimeout(ContainerCLI(), "inspect", containerID, "--format", format)
+	if err != nil {
+		return ComposeProject{}, false
+	}
+
+	return parseComposeLabels(strings.TrimSpace(string(output)))
+}
+
+// parseComposeLabels parses "working_dir|config_files|environment_file" label values
+func parseComposeLabels(output string) (ComposeProject, bool) {
+	parts := strings.Split(output, composeLabelSeparator)
+	for len(parts) < 3 {
+		parts = append(parts, "")
+	}
+
+	workDir := composeLabelValue(parts[0])
+	if workDir == "" {
+		return ComposeProject{}, false
+	}
+
+	project := ComposeProject{
+		WorkingDir:  workDir,
+		ConfigFiles: existingComposePaths(workDir, splitComposeLabelList(parts[1])),
+		EnvFiles:    existingComposePaths(workDir, splitComposeLabelList(parts[2])),
+	}
+
+	// ラベルのファイルが見つからない場合（古いComposeや移動後）は作業ディレクトリから探す
+	if len(project.ConfigFiles) == 0 {
+		project.ConfigFiles = discoverComposeFiles(workDir)
+	}
+
+	return project, true
+}
+
+// composeLabelValue normalizes an empty docker inspect label value
+func composeLabelValue(value string) string {
+	value = strings.TrimSpace(value)
+	if value == "<no value>" {
+		return ""
+	}
+	return value
+}
+
+// splitComposeLabelList splits a comma separated label value
+func splitComposeLabelList(value string) []string {
+	value = composeLabelValue(value)
+	if value == "" {
+		return nil
+	}
+
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// existingComposePaths resolves paths against the working directory and drops missing files
+func existingComposePaths(workDir string, paths []string) []string {
+	var existing []string
+	for _, p := range paths {
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(workDir, p)
+		}
+		if info, err := os.Stat(p); err == nil && !info.IsDir() {
+			existing = append(existing, p)
+		}
+	}
+	return existing
+}
+
+// discoverComposeFiles finds the default compose file and its override in a directory
+func discoverComposeFiles(dir string) []string {
+	for _, name := range composeFileNames {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+
+		files := []string{path}
+
+		// docker compose と同様に同じ系統のオーバーライドファイルを追加
+		ext := filepath.Ext(name)
+		override := filepath.Join(dir, strings.TrimSuffix(name, ext)+".override"+ext)
+		if _, err := os.Stat(override); err == nil {
+			files = append(files, override)
+		}
+		return files
+	}
+	return nil
+}
+
+// resolveComposeProfiles returns the profiles needed to bring the running services back up
+func resolveComposeProfiles(project ComposeProject, services []string) []string {
+	seen := make(map[string]bool)
+	var profiles []string
+	add := func(profile string) {
+		if profile != "" && !seen[profile] {
+			seen[profile] = true
+			profiles = append(profiles, profile)
+		}
+	}
+
+	// COMPOSE_PROFILES が環境ファイルで指定されている場合はそれを使う
+	envFiles := project.EnvFiles
+	if len(envFiles) == 0 {
+		envFiles = existingComposePaths(project.WorkingDir, []string{".env"})
+	}
+	for _, envFile := range envFiles {
+		for _, profile := range strings.Split(readEnvFileValue(envFile, "COMPOSE_PROFILES"), ",") {
+			add(strings.TrimSpace(profile))
+		}
+	}
+
+	// 存在するコンテナのサービスに付いているプロファイルを有効化
+	serviceProfiles := readComposeServiceProfiles(project)
+	for _, service := range services {
+		for _, profile := range serviceProfiles[service] {
+			add(profile)
+		}
+	}
+
+	sort.Strings(profiles)
+	return profiles
+}
+
+// readEnvFileValue reads a single KEY=VALUE entry from a dotenv file
+func readEnvFileValue(path, key string) string {
+	file, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		line = strings.TrimPrefix(line, "export ")
+		name, value, found := strings.Cut(line, "=")
+		if !found || strings.TrimSpace(name) != key {
+			continue
+		}
+		return strings.Trim(strings.TrimSpace(value), `"'`)
+	}
+	return ""
+}
+
+// composeProfilesEntry は compose config から得たサービスのプロファイル
+type composeProfilesEntry struct {
+	key      string // Composeファイルと環境ファイルの更新日時（変わったら取り直す）
+	profiles map[string][]string
+}
+
+// compose config は更新のたびに実行しないよう、ファイルが変わるまで結果を使い回す
+var (
+	composeProfilesCacheMu sync.Mutex
+	composeProfilesCache   = make(map[string]composeProfilesEntry)
+)
+
+// readComposeServiceProfiles returns the profiles of each service resolved by "compose config"
+func readComposeServiceProfiles(project ComposeProject) map[string][]string {
+	composeCmd := getComposeCommand()
+	if composeCmd == nil || len(project.ConfigFiles) == 0 {
+		return nil
+	}
+
+	var key strings.Builder
+	for _, path := range append(append([]string{}, project.ConfigFiles...), project.EnvFiles...) {
+		key.WriteString(path)
+		if info, err := os.Stat(path); err == nil {
+			fmt.Fprintf(&key, "@%d", info.ModTime().UnixNano())
+		}
+		key.WriteString("|")
+	}
+
+	composeProfilesCacheMu.Lock()
+	entry, ok := composeProfilesCache[project.Name]
+	composeProfilesCacheMu.Unlock()
+	if ok && entry.key == key.String() {
+		return entry.profiles
+	}
+
+	// 無効なプロファイルのサービスは出力されないため、すべてのプロファイルを有効にして解決する
+	project.Profiles = []string{"*"}
+	config, err := runComposeConfig(composeCommandLine(composeCmd, project, "config", "--format", "json"))
+	if err != nil {
+		return nil
+	}
+
+	profiles := make(map[string][]string)
+	for _, svc := range config.Services {
+		profiles[svc.Name] = svc.Profiles
+	}
+
+	composeProfilesCacheMu.Lock()
+	composeProfilesCache[project.Name] = composeProfilesEntry{key: key.String(), profiles: profiles}
+	composeProfilesCacheMu.Unlock()
+	return profiles
+}
+
+// composeArgs returns the global compose options that reproduce the project setup
+func (p ComposeProject) composeArgs(composeCmd []string) []string {
+	args := []string{"-p", p.Name}
+	// podman-compose は --project-directory に対応していないため、-f のディレクトリを基準にさせる
+	if composeCmd[0] != "podman-compose" {
+		args = append(args, "--project-directory", p.WorkingDir)
+	}
+	for _, f := range p.ConfigFiles {
+		args = append(args, "-f", f)
+	}
+	for _, f := range p.EnvFiles {
+		args = append(args, "--env-file", f)
+	}
+	for _, profile := range p.Profiles {
+		args = append(args, "--profile", profile)
+	}
+	return args
+}
+
+// newComposeCommand builds a compose command (docker compose / docker-compose / podman compose / nerdctl compose) for the project
+func newComposeCommand(composeCmd []string, project ComposeProject, args ...string) *exec.Cmd {
+	argv := composeCommandLine(composeCmd, project, args...)
+	return exec.Command(argv[0], argv[1:]...)
+}
+
+// composeCommandLine returns the full argv for a compose subcommand of the project
+func composeCommandLine(composeCmd []string, project ComposeProject, args ...string) []string {
+	argv := append([]string{}, composeCmd...)
+	argv = append(argv, project.composeArgs(composeCmd)...)
+	return append(argv, args...)
+}
+
+// BuildComposeCommand returns the argv to run a compose subcommand for a known project
+func BuildComposeCommand(projectName string, args ...string) ([]string, error) {
+	if !IsValidIdentifier(projectName) {
+		return nil, fmt.Errorf("不正なプロジェクト名です")
+	}
+
+	project, ok := ResolveComposeProject(projectName)
+	if !ok || len(project.ConfigFiles) == 0 {
+		return nil, fmt.Errorf("プロジェクト %s のComposeファイルが見つかりません", projectName)
+	}
+
+	composeCmd := getComposeCommand()
+	if composeCmd == nil {
+		return nil, fmt.Errorf("composeコマンド（docker compose / podman-compose / nerdctl compose）が見つかりません")
+	}
+
+	return composeCommandLine(composeCmd, project, args...), nil
+}
+
+// IsValidComposeServiceName checks a compose service name (英数字、ドット、アンダースコア、ハイフン)
+func IsValidComposeServiceName(name string) bool {
+	return composeServiceNamePattern.MatchString(name)
+}
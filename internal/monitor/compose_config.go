@@ -0,0 +1,237 @@
+package monitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ComposeService is a service definition resolved by "compose config"
+type ComposeService struct {
+	Name           string
+	Image          string
+	DependsOn      []ComposeDependency
+	Networks       []string
+	Volumes        []string // 名前付きボリュームのみ（バインドマウントは含まない）
+	HasHealthcheck bool
+	Profiles       []string
+}
+
+// ComposeDependency is a depends_on entry of a service
+type ComposeDependency struct {
+	Service   string
+	Condition string // service_started / service_healthy / service_completed_successfully
+}
+
+// ComposeConfig is the resolved configuration of a compose project
+type ComposeConfig struct {
+	Project  string
+	Services []ComposeService
+}
+
+// composeConfigJSON は "docker compose config --format json" の出力のうち使用する部分
+type composeConfigJSON struct {
+	Name     string `json:"name"`
+	Services map[string]struct {
+		Image     string `json:"image"`
+		DependsOn map[string]struct {
+			Condition string `json:"condition"`
+		} `json:"depends_on"`
+		Networks map[string]interface{} `json:"networks"`
+		Volumes  []struct {
+			Type   string `json:"type"`
+			Source string `json:"source"`
+		} `json:"volumes"`
+		Healthcheck *struct {
+			Test    []string `json:"test"`
+			Disable bool     `json:"disable"`
+		} `json:"healthcheck"`
+		Profiles []string `json:"profiles"`
+	} `json:"services"`
+}
+
+// GetComposeConfig resolves the compose files of a project (overrides, env and profiles applied)
+func GetComposeConfig(projectName string) (*ComposeConfig, error) {
+	argv, err := BuildComposeCommand(projectName, "config", "--format", "json")
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := runComposeConfig(argv)
+	if err != nil {
+		return nil, err
+	}
+	config.Project = projectName
+	return config, nil
+}
+
+// runComposeConfig runs a "compose config --format json" command line and parses its output
+func runComposeConfig(argv []string) (*ComposeConfig, error) {
+	output, err := RunCommandWithCustomTimeout(10*time.Second, argv[0], argv[1:]...)
+	if err != nil {
+		return nil, fmt.Errorf("compose config の取得に失敗しました（Compose v2 が必要です）")
+	}
+	return parseComposeConfig(output)
+}
+
+// parseComposeConfig parses the JSON output of "compose config"
+func parseComposeConfig(data []byte) (*ComposeConfig, error) {
+	var raw composeConfigJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("compose config の解析に失敗しました: %v", err)
+	}
+
+	config := &ComposeConfig{Project: raw.Name}
+	for name, svc := range raw.Services {
+		service := ComposeService{
+			Name:           name,
+			Image:          svc.Image,
+			HasHealthcheck: svc.Healthcheck != nil && !svc.Healthcheck.Disable && len(svc.Healthcheck.Test) > 0 && svc.Healthcheck.Test[0] != "NONE",
+			Profiles:       svc.Profiles,
+		}
+
+		for dep, opts := range svc.DependsOn {
+			condition := opts.Condition
+			if condition == "" {
+				condition = "service_started"
+			}
+			service.DependsOn = append(service.DependsOn, ComposeDependency{Service: dep, Condition: condition})
+		}
+		sort.Slice(service.DependsOn, func(i, j int) bool {
+			return service.DependsOn[i].Service < service.DependsOn[j].Service
+		})
+
+		for network := range svc.Networks {
+			service.Networks = append(service.Networks, network)
+		}
+		sort.Strings(service.Networks)
+
+		for _, v := range svc.Volumes {
+			if v.Type == "volume" && v.Source != "" {
+				service.Volumes = append(service.Volumes, v.Source)
+			}
+		}
+		sort.Strings(service.Volumes)
+
+		config.Services = append(config.Services, service)
+	}
+
+	// 依存される側が先に来るように並べる（同じ深さは名前順）
+	depth := make(map[string]int)
+	var depthOf func(name string, visiting map[string]bool) int
+	depthOf = func(name string, visiting map[string]bool) int {
+		if d, ok := depth[name]; ok {
+			return d
+		}
+		if visiting[name] {
+			return 0 // 循環依存は打ち切る
+		}
+		visiting[name] = true
+		d := 0
+		if svc := config.Service(name); svc != nil {
+			for _, dep := range svc.DependsOn {
+				if dd := depthOf(dep.Service, visiting) + 1; dd > d {
+					d = dd
+				}
+			}
+		}
+		depth[name] = d
+		return d
+	}
+	for _, svc := range config.Services {
+		depthOf(svc.Name, make(map[string]bool))
+	}
+	sort.Slice(config.Services, func(i, j int) bool {
+		a, b := config.Services[i], config.Services[j]
+		if depth[a.Name] != depth[b.Name] {
+			return depth[a.Name] < depth[b.Name]
+		}
+		return a.Name < b.Name
+	})
+
+	return config, nil
+}
+
+// Service returns a service definition by name
+func (c *ComposeConfig) Service(name string) *ComposeService {
+	for i := range c.Services {
+		if c.Services[i].Name == name {
+			return &c.Services[i]
+		}
+	}
+	return nil
+}
+
+// SharedResources returns networks or volumes used by two or more services
+func (c *ComposeConfig) SharedResources(volumes bool) map[string][]string {
+	users := make(map[string][]string)
+	for _, svc := range c.Services {
+		resources := svc.Networks
+		if volumes {
+			resources = svc.Volumes
+		}
+		for _, r := range resources {
+			users[r] = append(users[r], svc.Name)
+		}
+	}
+
+	for r, services := range users {
+		if len(services) < 2 {
+			delete(users, r)
+		}
+	}
+	return users
+}
+
+// ComposeServiceState summarizes the containers of a service
+func ComposeServiceState(projectName, service string, containers []DockerContainer) (running, total int, health string) {
+	for _, c := range containers {
+		if c.ComposeProject != projectName || c.ComposeService != service {
+			continue
+		}
+		total++
+		if c.Status == "running" {
+			running++
+		}
+		// 1つでも不健全なレプリカがあればそれを優先
+		switch {
+		case c.Health == "unhealthy":
+			health = "unhealthy"
+		case c.Health == "starting" && health != "unhealthy":
+			health = "starting"
+		case c.Health == "healthy" && health == "":
+			health = "healthy"
+		}
+	}
+	return running, total, health
+}
+
+// UnhealthyDependencies returns dependencies of a service that do not satisfy their depends_on condition
+func (c *ComposeConfig) UnhealthyDependencies(service string, containers []DockerContainer) []string {
+	svc := c.Service(service)
+	if svc == nil {
+		return nil
+	}
+
+	var unhealthy []string
+	for _, dep := range svc.DependsOn {
+		running, total, health := ComposeServiceState(c.Project, dep.Service, containers)
+
+		ok := true
+		switch dep.Condition {
+		case "service_healthy":
+			ok = running > 0 && health == "healthy"
+		case "service_completed_successfully":
+			// 完了済み（停止）が期待される状態なので、コンテナがあればよしとする
+			ok = total > 0
+		default:
+			ok = running > 0 && health != "unhealthy"
+		}
+
+		if !ok {
+			unhealthy = append(unhealthy, dep.Service)
+		}
+	}
+	return unhealthy
+}
@@ -0,0 +1,112 @@
+//go:build !windows
+
+package monitor
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeComposeScript は compose config の呼び出しを記録し、プロファイル付きのサービスを返す docker
+const fakeComposeScript = `#!/bin/sh
+[ "$1 $2" = "compose version" ] && exit 0
+echo "$*" >> "$DOCKER_LOG"
+echo '{"name": "shop", "services": {"web": {"image": "nginx"}, "worker": {"image": "app", "profiles": ["debug"]}}}'
+`
+
+func TestResolveComposeProfiles(t *testing.T) {
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "docker"), []byte(fakeComposeScript), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	logFile := filepath.Join(bin, "log")
+	t.Setenv("DOCKER_LOG", logFile)
+	withDockerRuntime(t)
+
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"compose.yaml": "services: {}\n",
+		".env":         "COMPOSE_PROFILES=tools\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	project := ComposeProject{Name: "shop-profiles-test", WorkingDir: dir, ConfigFiles: discoverComposeFiles(dir)}
+	t.Cleanup(func() {
+		composeProfilesCacheMu.Lock()
+		delete(composeProfilesCache, project.Name)
+		composeProfilesCacheMu.Unlock()
+	})
+
+	tests := []struct {
+		services []string
+		want     []string
+	}{
+		{[]string{"web"}, []string{"tools"}},
+		{[]string{"web", "worker"}, []string{"debug", "tools"}},
+	}
+	for _, tt := range tests {
+		if got := resolveComposeProfiles(project, tt.services); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("services %v: profiles = %v, want %v", tt.services, got, tt.want)
+		}
+	}
+
+	// ファイルが変わらない限り compose config は1回だけ実行する
+	data, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(calls) != 1 || !strings.Contains(calls[0], "--profile * config --format json") {
+		t.Errorf("compose calls = %q, want one config call with all profiles", calls)
+	}
+}
+
+func TestRememberComposeProjects(t *testing.T) {
+	now := time.Now()
+	project := ComposeProject{Name: "remember-test", WorkingDir: "/srv/app", ConfigFiles: []string{"/srv/app/compose.yaml"}, LastSeen: now}
+	expired := ComposeProject{Name: "remember-test-old", WorkingDir: "/srv/old", LastSeen: now.Add(-ComposeProjectExpiry - time.Hour)}
+	t.Cleanup(func() {
+		knownComposeProjectsMu.Lock()
+		delete(knownComposeProjects, project.Name)
+		delete(knownComposeProjects, expired.Name)
+		knownComposeProjectsMu.Unlock()
+	})
+
+	withProfile := project
+	withProfile.Profiles = []string{"debug"}
+	withProfile.LastSeen = now.Add(2 * time.Minute)
+	later := withProfile
+	later.LastSeen = withProfile.LastSeen.Add(composeProjectTouchInterval)
+
+	tests := []struct {
+		name    string
+		project ComposeProject
+		saved   bool
+	}{
+		{"new project", project, true},
+		{"unchanged", ComposeProject{Name: project.Name, WorkingDir: project.WorkingDir, ConfigFiles: project.ConfigFiles, LastSeen: now.Add(time.Minute)}, false},
+		{"profile added", withProfile, true},
+		{"last seen refreshed", later, true},
+	}
+	for _, tt := range tests {
+		changed := RememberComposeProjects([]ComposeProject{tt.project})
+		if saved := len(changed) == 1; saved != tt.saved {
+			t.Errorf("%s: changed = %+v, want saved %v", tt.name, changed, tt.saved)
+		}
+	}
+
+	// 期限切れのプロジェクトは記憶から消える
+	RememberComposeProjects([]ComposeProject{expired})
+	for _, p := range KnownComposeProjects() {
+		if p.Name == expired.Name {
+			t.Errorf("expired project %s is still remembered", p.Name)
+		}
+	}
+}
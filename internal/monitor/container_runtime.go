@@ -0,0 +1,249 @@
+package monitor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/config"
+)
+
+// ContainerRuntime abstracts a Docker compatible container CLI (docker / podman / nerdctl)
+type ContainerRuntime interface {
+	// Name は表示名（Docker / Podman / nerdctl）
+	Name() string
+	// CLI は docker 互換のサブコマンド（ps, inspect, logs, exec など）を受け付けるバイナリ
+	CLI() string
+	// ComposeCommand は compose のコマンド（例: ["docker", "compose"]）、見つからない場合は nil
+	ComposeCommand() []string
+	// Available はデーモンやソケットに接続できるかを返す
+	Available() bool
+	// PortProcessNames は公開ポートをlistenするプロセス名（lsofの先頭9文字）
+	PortProcessNames() []string
+}
+
+// dockerRuntime is the Docker CLI (Docker Desktop / dockerd)
+type dockerRuntime struct{}
+
+func (dockerRuntime) Name() string { return "Docker" }
+func (dockerRuntime) CLI() string  { return "docker" }
+
+func (dockerRuntime) ComposeCommand() []string {
+	// docker compose (v2) を優先し、なければ docker-compose (v1)
+	if err := exec.Command("docker", "compose", "version").Run(); err == nil {
+		return []string{"docker", "compose"}
+	}
+	if _, err := exec.LookPath("docker-compose"); err == nil {
+		return []string{"docker-compose"}
+	}
+	return nil
+}
+
+func (dockerRuntime) Available() bool {
+	_, err := RunCommandWithTimeout("docker", "info", "--format", "{{.ServerVersion}}")
+	return err == nil
+}
+
+func (dockerRuntime) PortProcessNames() []string {
+	return []string{"com.docke", "docker-pr", "vpnkit"}
+}
+
+// podmanSocketRuntime is the Docker CLI talking to the Podman socket API (DOCKER_HOST)
+// podman コマンドがなくても Podman Desktop のソケット経由で操作できる
+type podmanSocketRuntime struct {
+	dockerRuntime
+}
+
+func (podmanSocketRuntime) Name() string { return "Podman (Docker API)" }
+
+func (podmanSocketRuntime) PortProcessNames() []string {
+	return podmanRuntime{}.PortProcessNames()
+}
+
+// podmanRuntime is the Podman CLI
+type podmanRuntime struct{}
+
+func (podmanRuntime) Name() string { return "Podman" }
+func (podmanRuntime) CLI() string  { return "podman" }
+
+func (podmanRuntime) ComposeCommand() []string {
+	// podman compose (4.7以降) を優先し、なければ podman-compose
+	if err := exec.Command("podman", "compose", "version").Run(); err == nil {
+		return []string{"podman", "compose"}
+	}
+	if _, err := exec.LookPath("podman-compose"); err == nil {
+		return []string{"podman-compose"}
+	}
+	return nil
+}
+
+func (podmanRuntime) Available() bool {
+	_, err := RunCommandWithTimeout("podman", "info", "--format", "{{.Host.Arch}}")
+	return err == nil
+}
+
+func (podmanRuntime) PortProcessNames() []string {
+	// rootlessではrootlessport、macOSのpodman machineではgvproxyがポートを公開する
+	return []string{"rootlessp", "gvproxy"}
+}
+
+// nerdctlRuntime is the nerdctl CLI for containerd
+type nerdctlRuntime struct{}
+
+func (nerdctlRuntime) Name() string { return "nerdctl" }
+func (nerdctlRuntime) CLI() string  { return "nerdctl" }
+
+func (nerdctlRuntime) ComposeCommand() []string {
+	return []string{"nerdctl", "compose"}
+}
+
+func (nerdctlRuntime) Available() bool {
+	_, err := RunCommandWithTimeout("nerdctl", "info", "--format", "{{.ServerVersion}}")
+	return err == nil
+}
+
+func (nerdctlRuntime) PortProcessNames() []string {
+	// rootlessではrootlesskitがポートを公開する（rootfulはiptablesで転送するためプロセスなし）
+	return []string{"rootlessk"}
+}
+
+var (
+	currentRuntime   ContainerRuntime
+	currentRuntimeMu sync.Mutex
+)
+
+// CurrentContainerRuntime returns the runtime selected by config or detection (検出結果はキャッシュ)
+func CurrentContainerRuntime() ContainerRuntime {
+	currentRuntimeMu.Lock()
+	defer currentRuntimeMu.Unlock()
+
+	if currentRuntime == nil {
+		currentRuntime = selectContainerRuntime(config.Load().Container)
+	}
+	return currentRuntime
+}
+
+// ContainerCLI returns the CLI binary of the current container runtime
+func ContainerCLI() string {
+	return CurrentContainerRuntime().CLI()
+}
+
+// IsContainerRuntimeRunning reports whether the current container runtime is reachable
+func IsContainerRuntimeRunning() bool {
+	_, err := RunCommandWithTimeout(ContainerCLI(), "ps", "-q")
+	return err == nil
+}
+
+// selectContainerRuntime picks the runtime from config, falling back to detection
+func selectContainerRuntime(cfg config.ContainerConfig) ContainerRuntime {
+	// ソケットが指定されていればDocker CLIをそのソケットに向ける（Podmanのソケット APIなど）
+	if cfg.Socket != "" {
+		socket := cfg.Socket
+		if !strings.Contains(socket, "://") {
+			socket = "unix://" + socket
+		}
+		os.Setenv("DOCKER_HOST", socket)
+		if strings.EqualFold(cfg.Runtime, "podman") || strings.Contains(socket, "podman") {
+			return podmanSocketRuntime{}
+		}
+		return dockerRuntime{}
+	}
+
+	switch strings.ToLower(cfg.Runtime) {
+	case "docker":
+		return dockerRuntime{}
+	case "podman":
+		if _, err := exec.LookPath("podman"); err != nil {
+			if socketRuntime := detectPodmanSocket(); socketRuntime != nil {
+				return socketRuntime
+			}
+		}
+		return podmanRuntime{}
+	case "nerdctl":
+		return nerdctlRuntime{}
+	}
+
+	return detectContainerRuntime()
+}
+
+// detectContainerRuntime returns the first runtime that is installed and reachable
+func detectContainerRuntime() ContainerRuntime {
+	candidates := []ContainerRuntime{dockerRuntime{}, podmanRuntime{}, nerdctlRuntime{}}
+
+	var installed []ContainerRuntime
+	for _, candidate := range candidates {
+		if _, err := exec.LookPath(candidate.CLI()); err != nil {
+			continue
+		}
+		installed = append(installed, candidate)
+		if candidate.Available() {
+			return candidate
+		}
+	}
+
+	// docker CLIだけがあり、Podmanのソケットが起動している場合
+	if socketRuntime := detectPodmanSocket(); socketRuntime != nil {
+		return socketRuntime
+	}
+
+	// どれも接続できない場合はインストール済みのものを使う（起動後に操作できるように）
+	if len(installed) > 0 {
+		return installed[0]
+	}
+	return dockerRuntime{}
+}
+
+// detectPodmanSocket points the Docker CLI at a running Podman socket if one exists
+func detectPodmanSocket() ContainerRuntime {
+	if _, err := exec.LookPath("docker"); err != nil {
+		return nil
+	}
+
+	for _, path := range podmanSocketPaths() {
+		if info, err := os.Stat(path); err != nil || info.Mode()&os.ModeSocket == 0 {
+			continue
+		}
+		previous, hadPrevious := os.LookupEnv("DOCKER_HOST")
+		os.Setenv("DOCKER_HOST", "unix://"+path)
+		if (dockerRuntime{}).Available() {
+			return podmanSocketRuntime{}
+		}
+
+		// 接続できなければ元の DOCKER_HOST に戻す
+		if hadPrevious {
+			os.Setenv("DOCKER_HOST", previous)
+		} else {
+			os.Unsetenv("DOCKER_HOST")
+		}
+	}
+	return nil
+}
+
+// podmanSocketPaths returns well-known Podman API socket locations
+func podmanSocketPaths() []string {
+	var paths []string
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		paths = append(paths, filepath.Join(dir, "podman", "podman.sock"))
+	}
+	paths = append(paths, fmt.Sprintf("/run/user/%d/podman/podman.sock", os.Getuid()))
+	if home, err := os.UserHomeDir(); err == nil {
+		// macOS の podman machine
+		paths = append(paths, filepath.Join(home, ".local", "share", "containers", "podman", "machine", "podman.sock"))
+		paths = append(paths, filepath.Join(home, ".local", "share", "containers", "podman", "machine", "podman-machine-default", "podman.sock"))
+	}
+	paths = append(paths, "/run/podman/podman.sock")
+	return paths
+}
+
+// isContainerPortProcess reports whether an lsof command name belongs to the container runtime
+func isContainerPortProcess(processName string) bool {
+	for _, name := range CurrentContainerRuntime().PortProcessNames() {
+		if strings.HasPrefix(processName, name) {
+			return true
+		}
+	}
+	return false
+}
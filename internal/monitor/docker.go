@@ -11,17 +11,21 @@ type DockerContainer struct {
 	Name           string
 	Status         string
 	Image          string
-	ComposeProject string // Composeプロジェクト名（空の場合は単体）
-	ComposeService string // Composeサービス名
-	ProjectDir     string // プロジェクトディレクトリ（Composeの場合はdocker-compose.ymlのあるディレクトリ）
-	Port           string // 公開されているポート番号
+	ComposeProject string         // Composeプロジェクト名（空の場合は単体）
+	ComposeService string         // Composeサービス名
+	ProjectDir     string         // プロジェクトディレクトリ（Composeの場合はdocker-compose.ymlのあるディレクトリ）
+	Port           string         // 公開されているポート番号
+	Health         string         // ヘルスチェック状態（healthy / unhealthy / starting、なしの場合は空）
+	State          ContainerState // inspectから取得した状態の詳細（終了コード、再起動回数など）
 }
 
 // CheckDocker checks if Docker is running and counts containers
 func CheckDocker() string {
-	output, err := RunCommandWithTimeout("docker", "ps", "-q")
+	runtimeName := CurrentContainerRuntime().Name()
+
+	output, err := RunCommandWithTimeout(ContainerCLI(), "ps", "-q")
 	if err != nil {
-		return "✗ Docker: 停止中"
+		return fmt.Sprintf("✗ %s: 停止中", runtimeName)
 	}
 
 	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
@@ -32,13 +36,13 @@ func CheckDocker() string {
 	}
 
 	if count == 0 {
-		return "✓ Docker: 実行中（コンテナ0個）"
+		return fmt.Sprintf("✓ %s: 実行中（コンテナ0個）", runtimeName)
 	}
 
 	// コンテナ詳細情報を取得
 	containers := getDockerContainerDetails()
 
-	result := fmt.Sprintf("✓ Docker: %d個のコンテナ\n", count)
+	result := fmt.Sprintf("✓ %s: %d個のコンテナ\n", runtimeName, count)
 	for _, container := range containers {
 		result += container
 	}
@@ -48,7 +52,7 @@ func CheckDocker() string {
 
 // getDockerContainerDetails returns detailed info for each container
 func getDockerContainerDetails() []string {
-	output, err := RunCommandWithTimeout("docker", "ps", "--format", "{{.Names}}|{{.Ports}}|{{.Status}}|{{.Image}}|{{.ID}}")
+	output, err := RunCommandWithTimeout(ContainerCLI(), "ps", "--format", "{{.Names}}|{{.Ports}}|{{.Status}}|{{.Image}}|{{.ID}}")
 	if err != nil {
 		return []string{}
 	}
@@ -122,7 +126,7 @@ func GetDockerImageSize(imageName string) string {
 		return ""
 	}
 
-	output, err := RunCommandWithTimeout("docker", "images", imageName, "--format", "{{.Size}}")
+	output, err := RunCommandWithTimeout(ContainerCLI(), "images", imageName, "--format", "{{.Size}}")
 	if err != nil {
 		return ""
 	}
@@ -143,7 +147,7 @@ func GetDockerContainerStats(containerID string) DockerStats {
 		return DockerStats{}
 	}
 
-	output, err := RunCommandWithTimeout("docker", "stats", "--no-stream", "--format", "{{.CPUPerc}}|{{.MemUsage}}", containerID)
+	output, err := RunCommandWithTimeout(ContainerCLI(), "stats", "--no-stream", "--format", "{{.CPUPerc}}|{{.MemUsage}}", containerID)
 	if err != nil {
 		return DockerStats{}
 	}
@@ -188,7 +192,7 @@ func getContainerWorkDir(containerName string) string {
 		return ""
 	}
 
-	output, err := RunCommandWithTimeout("docker", "inspect", "--format", "{{.Config.WorkingDir}}", containerName)
+	output, err := RunCommandWithTimeout(ContainerCLI(), "inspect", "--format", "{{.Config.WorkingDir}}", containerName)
 	if err != nil {
 		return ""
 	}
@@ -203,7 +207,7 @@ func getContainerMounts(containerName string) []string {
 		return []string{}
 	}
 
-	output, err := RunCommandWithTimeout("docker", "inspect", "--format", "{{range .Mounts}}{{.Source}} -> {{.Destination}}{{\"\n\"}}{{end}}", containerName)
+	output, err := RunCommandWithTimeout(ContainerCLI(), "inspect", "--format", "{{range .Mounts}}{{.Source}} -> {{.Destination}}{{\"\n\"}}{{end}}", containerName)
 	if err != nil {
 		return []string{}
 	}
@@ -276,7 +280,7 @@ func extractMainPort(ports string) string {
 
 // GetDockerContainers returns list of all Docker containers (simple version)
 func GetDockerContainers() []DockerContainer {
-	output, err := RunCommandWithTimeout("docker", "ps", "-a", "--format", "{{.ID}}|{{.Names}}|{{.Status}}|{{.Image}}")
+	output, err := RunCommandWithTimeout(ContainerCLI(), "ps", "-a", "--format", "{{.ID}}|{{.Names}}|{{.Status}}|{{.Image}}")
 	if err != nil {
 		return []DockerContainer{}
 	}
@@ -300,6 +304,7 @@ func GetDockerContainers() []DockerContainer {
 		}
 
 		containerID := parts[0]
+		health := parseContainerHealth(parts[2])
 
 		// Compose情報を取得（軽量版）
 		composeProject, composeService := getComposeInfo(containerID)
@@ -322,12 +327,43 @@ func GetDockerContainers() []DockerContainer {
 			ComposeService: composeService,
 			ProjectDir:     projectDir,
 			Port:           port,
+			Health:         health,
 		})
 	}
 
+	// 終了コード・再起動回数・ヘルスチェック出力はinspectでまとめて取得
+	ids := make([]string, 0, len(containers))
+	for _, c := range containers {
+		ids = append(ids, c.ID)
+	}
+	states := GetContainerStates(ids)
+	for i := range containers {
+		if state, ok := states[containers[i].ID]; ok {
+			containers[i].State = state
+			if state.Health != "" {
+				containers[i].Health = state.Health
+			}
+		}
+	}
+
 	return containers
 }
 
+// parseContainerHealth extracts the health state from docker ps status text
+func parseContainerHealth(status string) string {
+	// 例: "Up 2 minutes (healthy)", "Up 5 seconds (health: starting)"
+	switch {
+	case strings.Contains(status, "(unhealthy)"):
+		return "unhealthy"
+	case strings.Contains(status, "(healthy)"):
+		return "healthy"
+	case strings.Contains(status, "(health: starting)"):
+		return "starting"
+	default:
+		return ""
+	}
+}
+
 // getComposeInfo returns compose project and service for a container (lightweight)
 func getComposeInfo(containerID string) (project, service string) {
 	// 入力検証
@@ -336,7 +372,7 @@ func getComposeInfo(containerID string) (project, service string) {
 	}
 
 	// Composeプロジェクト名を取得
-	output, err := RunCommandWithTimeout("docker", "inspect", containerID, "--format", "{{index .Config.Labels \"com.docker.compose.project\"}}")
+	output, err := RunCommandWithTimeout(ContainerCLI(), "inspect", containerID, "--format", "{{index .Config.Labels \"com.docker.compose.project\"}}")
 	if err == nil {
 		project = strings.TrimSpace(string(output))
 		if project == "<no value>" {
@@ -345,7 +381,7 @@ func getComposeInfo(containerID string) (project, service string) {
 	}
 
 	// Composeサービス名を取得
-	output, err = RunCommandWithTimeout("docker", "inspect", containerID, "--format", "{{index .Config.Labels \"com.docker.compose.service\"}}")
+	output, err = RunCommandWithTimeout(ContainerCLI(), "inspect", containerID, "--format", "{{index .Config.Labels \"com.docker.compose.service\"}}")
 	if err == nil {
 		service = strings.TrimSpace(string(output))
 		if service == "<no value>" {
@@ -368,7 +404,7 @@ func getContainerProjectDir(containerID string) string {
 		return ""
 	}
 
-	output, err := RunCommandWithTimeout("docker", "inspect", containerID, "--format", "{{index .Config.Labels \"com.docker.compose.project.working_dir\"}}")
+	output, err := RunCommandWithTimeout(ContainerCLI(), "inspect", containerID, "--format", "{{index .Config.Labels \"com.docker.compose.project.working_dir\"}}")
 	if err != nil {
 		return ""
 	}
@@ -388,7 +424,7 @@ func getContainerPort(containerID string) string {
 		return ""
 	}
 
-	output, err := RunCommandWithTimeout("docker", "port", containerID)
+	output, err := RunCommandWithTimeout(ContainerCLI(), "port", containerID)
 	if err != nil {
 		return ""
 	}
@@ -415,9 +451,45 @@ func getContainerPort(containerID string) string {
 	return ""
 }
 
+// ServiceContainer represents a running container that provides a service (DB, broker, etc.)
+type ServiceContainer struct {
+	ID    string
+	Name  string
+	Image string
+}
+
+// FindContainerByImage returns the first running container whose image name starts with one of the prefixes
+// レジストリやリポジトリ部分は無視して比較します（例: bitnami/mongodb:7 -> mongodb）
+func FindContainerByImage(prefixes ...string) *ServiceContainer {
+	output, err := RunCommandWithTimeout(ContainerCLI(), "ps", "--format", "{{.ID}}|{{.Names}}|{{.Image}}")
+	if err != nil {
+		return nil
+	}
+
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		parts := strings.Split(line, "|")
+		if len(parts) < 3 {
+			continue
+		}
+
+		image := parts[2]
+		if idx := strings.LastIndex(image, "/"); idx >= 0 {
+			image = image[idx+1:]
+		}
+
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(image, prefix) {
+				return &ServiceContainer{ID: parts[0], Name: parts[1], Image: parts[2]}
+			}
+		}
+	}
+
+	return nil
+}
+
 // GetDanglingImagesCount returns the count of dangling images
 func GetDanglingImagesCount() int {
-	output, err := RunCommandWithTimeout("docker", "images", "-f", "dangling=true", "-q")
+	output, err := RunCommandWithTimeout(ContainerCLI(), "images", "-f", "dangling=true", "-q")
 	if err != nil {
 		return 0
 	}
@@ -431,7 +503,7 @@ func GetDanglingImagesCount() int {
 
 // GetDanglingImagesSize returns the total size of dangling images
 func GetDanglingImagesSize() string {
-	output, err := RunCommandWithTimeout("docker", "images", "-f", "dangling=true", "--format", "{{.Size}}")
+	output, err := RunCommandWithTimeout(ContainerCLI(), "images", "-f", "dangling=true", "--format", "{{.Size}}")
 	if err != nil {
 		return "0B"
 	}
@@ -0,0 +1,69 @@
+package monitor
+
+import (
+	"strings"
+	"testing"
+)
+
+// withDockerRuntime uses the Docker CLI for the duration of the test (設定や検出を使わない)
+func withDockerRuntime(t *testing.T) {
+	t.Helper()
+	currentRuntimeMu.Lock()
+	saved := currentRuntime
+	currentRuntime = dockerRuntime{}
+	currentRuntimeMu.Unlock()
+	t.Cleanup(func() {
+		currentRuntimeMu.Lock()
+		currentRuntime = saved
+		currentRuntimeMu.Unlock()
+	})
+}
+
+func TestIsSecretEnv(t *testing.T) {
+	tests := []struct {
+		key   string
+		value string
+		want  bool
+	}{
+		{"POSTGRES_PASSWORD", "postgres", true},
+		{"GITHUB_TOKEN", "ghp_x", true},
+		{"BASIC_AUTH", "user:pass", true},
+		{"AUTH_TOKEN", "x", true},
+		{"PGPASSWORD", "x", true},
+		{"SECRET_KEY_BASE", "x", true},
+		{"SENTRY_DSN", "https://key@sentry.io/1", true},
+		{"DB_PASS", "x", true},
+		{"MAX_TOKENS", "512", false},
+		{"DATABASE_URL", "postgres://app:hunter2@db/app", true},
+		// AUTH を含むがシークレットではない
+		{"AUTHOR", "alice", false},
+		{"OAUTH_CALLBACK_URL", "http://localhost:3000/callback", false},
+		{"NODE_ENV", "development", false},
+	}
+	for _, tt := range tests {
+		if got := IsSecretEnv(tt.key, tt.value); got != tt.want {
+			t.Errorf("IsSecretEnv(%q, %q) = %v, want %v", tt.key, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestDockerRunCommandSecrets(t *testing.T) {
+	withDockerRuntime(t)
+	raw := inspectContainer{Name: "/api"}
+	raw.Config.Image = "app:latest"
+	raw.Config.Env = []string{"POSTGRES_PASSWORD=hunter2", "NODE_ENV=production", "PATH=/usr/bin"}
+	config := parseContainerConfig(raw, inspectImageConfig{Env: []string{"PATH=/usr/bin"}})
+
+	// シークレットの値は書かず、実行時の環境変数から渡す
+	command := config.DockerRunCommand(false)
+	if strings.Contains(command, "hunter2") || !strings.Contains(command, "-e POSTGRES_PASSWORD ") {
+		t.Errorf("command = %q, want the password passed without its value", command)
+	}
+	if !strings.Contains(command, "-e NODE_ENV=production") || strings.Contains(command, "PATH=") {
+		t.Errorf("command = %q, want NODE_ENV and no image env", command)
+	}
+
+	if command := config.DockerRunCommand(true); !strings.Contains(command, "-e POSTGRES_PASSWORD=hunter2") {
+		t.Errorf("command = %q, want the password value when secrets are included", command)
+	}
+}
@@ -0,0 +1,451 @@
+package monitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// UnusedImageDays はこの日数以上コンテナから使われていないイメージを未使用として扱う
+const UnusedImageDays = 30
+
+// DockerDiskUsage is a row of "docker system df"
+type DockerDiskUsage struct {
+	Type        string // Images / Containers / Local Volumes / Build Cache
+	TotalCount  string
+	Active      string
+	Size        string
+	Reclaimable string
+}
+
+// DockerVolume holds volume information
+type DockerVolume struct {
+	Name           string
+	Driver         string
+	Mountpoint     string
+	Size           string
+	ComposeProject string
+	UsedBy         []string // このボリュームをマウントしているコンテナ（停止中を含む）
+}
+
+// Orphaned reports whether no container (running or stopped) uses the volume
+func (v DockerVolume) Orphaned() bool {
+	return len(v.UsedBy) == 0
+}
+
+// DockerNetwork holds network information
+type DockerNetwork struct {
+	ID         string
+	Name       string
+	Driver     string
+	Scope      string
+	Subnets    []string
+	Containers []string // 接続中のコンテナ
+}
+
+// Builtin reports whether the network is a default network that cannot be removed
+func (n DockerNetwork) Builtin() bool {
+	return n.Name == "bridge" || n.Name == "host" || n.Name == "none"
+}
+
+// DockerImage holds image information
+type DockerImage struct {
+	ID         string // 短縮ID（12桁）
+	Repository string
+	Tags       []string
+	Size       string
+	SizeBytes  int64
+	CreatedAt  time.Time
+	Containers []string  // このイメージから作られたコンテナ（停止中を含む）
+	Running    bool      // 稼働中のコンテナがあるか
+	LastUsed   time.Time // コンテナが最後に起動/停止した時刻
+	LastUsedBy string
+}
+
+// Dangling reports whether the image has no repository and tag
+func (i DockerImage) Dangling() bool {
+	return i.Repository == "<none>" && len(i.Tags) == 0
+}
+
+// UnusedDays returns days since the image was last used by a container (0 if in use)
+func (i DockerImage) UnusedDays() int {
+	if i.Running {
+		return 0
+	}
+	since := i.LastUsed
+	if since.IsZero() {
+		// コンテナから使われた記録がなければ作成日時から数える
+		since = i.CreatedAt
+	}
+	if since.IsZero() {
+		return 0
+	}
+	return int(time.Since(since).Hours() / 24)
+}
+
+// Unused reports whether the image has no containers and has not been used for UnusedImageDays
+func (i DockerImage) Unused() bool {
+	return len(i.Containers) == 0 && i.UnusedDays() >= UnusedImageDays
+}
+
+// dockerObjectNamePattern はボリューム・ネットワーク名として許可する文字
+var dockerObjectNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// IsValidDockerObjectName checks a volume or network name
+func IsValidDockerObjectName(name string) bool {
+	return dockerObjectNamePattern.MatchString(name)
+}
+
+// imageReferencePattern はイメージのタグ（repository:tag）として許可する文字
+var imageReferencePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_./:@-]*$`)
+
+// IsValidImageReference checks an image reference such as "postgres:16"
+func IsValidImageReference(ref string) bool {
+	return imageReferencePattern.MatchString(ref)
+}
+
+// GetDockerDiskUsage returns the "docker system df" summary including build cache
+func GetDockerDiskUsage() []DockerDiskUsage {
+	// Podmanは TotalCount ではなく Total のため、JSONで受け取って両方に対応する
+	output, err := RunCommandWithCustomTimeout(10*time.Second, ContainerCLI(), "system", "df", "--format", "{{json .}}")
+	if err != nil {
+		return []DockerDiskUsage{}
+	}
+	return parseSystemDfJSON(string(output))
+}
+
+// parseSystemDfJSON parses one JSON object per line of "system df --format {{json .}}"
+func parseSystemDfJSON(output string) []DockerDiskUsage {
+	var usage []DockerDiskUsage
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		var row map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &row); err != nil {
+			continue
+		}
+
+		value := func(keys ...string) string {
+			for _, key := range keys {
+				if v, ok := row[key]; ok && v != nil {
+					return fmt.Sprint(v)
+				}
+			}
+			return ""
+		}
+		usage = append(usage, DockerDiskUsage{
+			Type:        value("Type"),
+			TotalCount:  value("TotalCount", "Total"),
+			Active:      value("Active"),
+			Size:        value("Size"),
+			Reclaimable: value("Reclaimable"),
+		})
+	}
+	return usage
+}
+
+// GetDockerVolumes returns volumes with size and the containers that mount them
+func GetDockerVolumes() []DockerVolume {
+	// マウントしているコンテナを取得できなくても、一覧は表示する
+	volumes, _ := loadDockerVolumes()
+	if volumes == nil {
+		return []DockerVolume{}
+	}
+	return volumes
+}
+
+// loadDockerVolumes returns volumes, and an error if they or the containers that mount them cannot be read
+// マウントしているコンテナを取得できない場合は使用状況のない一覧とエラーを返す（削除の前はエラーなら中止する）
+func loadDockerVolumes() ([]DockerVolume, error) {
+	output, err := RunCommandWithTimeout(ContainerCLI(), "volume", "ls", "-q")
+	if err != nil {
+		return nil, fmt.Errorf("ボリューム一覧を取得できません: %w", err)
+	}
+	names := strings.Fields(string(output))
+	if len(names) == 0 {
+		return []DockerVolume{}, nil
+	}
+
+	// ラベルのテンプレート構文はランタイムごとに異なるため、inspectのJSONでまとめて取得
+	output, err = RunCommandWithTimeout(ContainerCLI(), append([]string{"volume", "inspect"}, names...)...)
+	if err != nil {
+		return nil, fmt.Errorf("ボリュームの情報を取得できません: %w", err)
+	}
+	var raw []struct {
+		Name       string            `json:"Name"`
+		Driver     string            `json:"Driver"`
+		Mountpoint string            `json:"Mountpoint"`
+		Labels     map[string]string `json:"Labels"`
+	}
+	if err := json.Unmarshal(output, &raw); err != nil {
+		return nil, err
+	}
+
+	usedBy, usersErr := getVolumeUsers()
+	sizes := getVolumeSizes()
+
+	var volumes []DockerVolume
+	for _, v := range raw {
+		volumes = append(volumes, DockerVolume{
+			Name:           v.Name,
+			Driver:         v.Driver,
+			Mountpoint:     v.Mountpoint,
+			ComposeProject: v.Labels["com.docker.compose.project"],
+			Size:           sizes[v.Name],
+			UsedBy:         usedBy[v.Name],
+		})
+	}
+
+	sort.Slice(volumes, func(i, j int) bool {
+		return volumes[i].Name < volumes[j].Name
+	})
+	return volumes, usersErr
+}
+
+// getVolumeUsers maps volume names to the containers that mount them
+func getVolumeUsers() (map[string][]string, error) {
+	users := make(map[string][]string)
+
+	output, err := RunCommandWithTimeout(ContainerCLI(), "ps", "-a", "--no-trunc", "--format", "{{.Names}}|{{.Mounts}}")
+	if err != nil {
+		return nil, fmt.Errorf("コンテナ一覧を取得できません: %w", err)
+	}
+
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		name, mounts, found := strings.Cut(line, "|")
+		if !found {
+			continue
+		}
+		for _, mount := range strings.Split(mounts, ",") {
+			if mount = strings.TrimSpace(mount); mount != "" {
+				users[mount] = append(users[mount], name)
+			}
+		}
+	}
+	return users, nil
+}
+
+// getVolumeSizes returns volume sizes from "docker system df -v" (slow, so use a long timeout)
+func getVolumeSizes() map[string]string {
+	output, err := RunCommandWithCustomTimeout(15*time.Second, ContainerCLI(), "system", "df", "-v")
+	if err != nil {
+		return map[string]string{}
+	}
+	return parseSystemDfVolumeSizes(string(output))
+}
+
+// parseSystemDfVolumeSizes parses the "Local Volumes space usage" table of "docker system df -v"
+func parseSystemDfVolumeSizes(output string) map[string]string {
+	sizes := make(map[string]string)
+
+	inVolumes := false
+	for _, line := range strings.Split(output, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "Local Volumes space usage") {
+			inVolumes = true
+			continue
+		}
+		if !inVolumes || trimmed == "" || strings.HasPrefix(trimmed, "VOLUME NAME") {
+			continue
+		}
+		// 次のセクションに入ったら終了
+		if strings.HasSuffix(trimmed, "space usage:") {
+			break
+		}
+
+		// VOLUME NAME   LINKS   SIZE
+		fields := strings.Fields(trimmed)
+		if len(fields) >= 3 {
+			sizes[fields[0]] = fields[len(fields)-1]
+		}
+	}
+	return sizes
+}
+
+// GetDockerNetworks returns networks with subnets and attached containers
+func GetDockerNetworks() []DockerNetwork {
+	output, err := RunCommandWithTimeout(ContainerCLI(), "network", "ls", "--no-trunc", "--format", "{{.ID}}|{{.Name}}|{{.Driver}}|{{.Scope}}")
+	if err != nil {
+		return []DockerNetwork{}
+	}
+
+	var networks []DockerNetwork
+	var ids []string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		parts := strings.Split(line, "|")
+		if len(parts) < 4 || !IsValidContainerID(parts[0]) {
+			continue
+		}
+		networks = append(networks, DockerNetwork{
+			ID:     parts[0],
+			Name:   parts[1],
+			Driver: parts[2],
+			Scope:  parts[3],
+		})
+		ids = append(ids, parts[0])
+	}
+	if len(ids) == 0 {
+		return networks
+	}
+
+	// サブネットと接続中のコンテナはinspectでまとめて取得
+	args := append([]string{"network", "inspect", "--format", "{{.Id}}|{{range .IPAM.Config}}{{.Subnet}} {{end}}|{{range .Containers}}{{.Name}} {{end}}"}, ids...)
+	output, err = RunCommandWithTimeout(ContainerCLI(), args...)
+	if err == nil {
+		for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+			parts := strings.Split(line, "|")
+			if len(parts) < 3 {
+				continue
+			}
+			for i := range networks {
+				if networks[i].ID == parts[0] {
+					networks[i].Subnets = strings.Fields(parts[1])
+					networks[i].Containers = strings.Fields(parts[2])
+					sort.Strings(networks[i].Containers)
+				}
+			}
+		}
+	}
+
+	sort.Slice(networks, func(i, j int) bool {
+		return networks[i].Name < networks[j].Name
+	})
+	return networks
+}
+
+// GetDockerImages returns images with tags, size and last container usage
+func GetDockerImages() []DockerImage {
+	// コンテナの使用状況を取得できなくても、一覧は表示する
+	images, _ := loadDockerImages()
+	if images == nil {
+		return []DockerImage{}
+	}
+	return images
+}
+
+// loadDockerImages returns images, and an error if they or the containers that use them cannot be read
+// 使用状況を取得できない場合は使用状況のない一覧とエラーを返す（削除の前はエラーなら中止する）
+func loadDockerImages() ([]DockerImage, error) {
+	output, err := RunCommandWithTimeout(ContainerCLI(), "image", "ls", "--no-trunc", "--format", "{{.ID}}|{{.Repository}}|{{.Tag}}|{{.Size}}|{{.CreatedAt}}")
+	if err != nil {
+		return nil, fmt.Errorf("イメージ一覧を取得できません: %w", err)
+	}
+
+	// 同じイメージIDの複数タグを1つにまとめる
+	images := make(map[string]*DockerImage)
+	var order []string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		parts := strings.Split(line, "|")
+		if len(parts) < 5 {
+			continue
+		}
+
+		id := shortImageID(parts[0])
+		image, exists := images[id]
+		if !exists {
+			createdAt, _ := time.Parse("2006-01-02 15:04:05 -0700 MST", parts[4])
+			image = &DockerImage{
+				ID:         id,
+				Repository: parts[1],
+				Size:       parts[3],
+				SizeBytes:  parseSizeString(parts[3]),
+				CreatedAt:  createdAt,
+			}
+			images[id] = image
+			order = append(order, id)
+		}
+		if parts[1] != "<none>" && parts[2] != "<none>" {
+			image.Tags = append(image.Tags, parts[1]+":"+parts[2])
+		}
+	}
+
+	// コンテナの使用状況を反映
+	usages, usageErr := getContainerImageUsage()
+	for _, usage := range usages {
+		image, exists := images[usage.imageID]
+		if !exists {
+			continue
+		}
+		image.Containers = append(image.Containers, usage.name)
+		if usage.running {
+			image.Running = true
+		}
+		if usage.lastUsed.After(image.LastUsed) {
+			image.LastUsed = usage.lastUsed
+			image.LastUsedBy = usage.name
+		}
+	}
+
+	result := make([]DockerImage, 0, len(order))
+	for _, id := range order {
+		result = append(result, *images[id])
+	}
+
+	// サイズの大きい順（ディスクを空ける候補を上に）
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].SizeBytes > result[j].SizeBytes
+	})
+	return result, usageErr
+}
+
+// containerImageUsage is a container's image and last activity
+type containerImageUsage struct {
+	name     string
+	imageID  string
+	running  bool
+	lastUsed time.Time
+}
+
+// getContainerImageUsage returns image usage of all containers (running and stopped)
+// 一部のコンテナを取得できなかった場合（タイムアウト、取得中の削除など）もエラーを返す
+func getContainerImageUsage() ([]containerImageUsage, error) {
+	output, err := RunCommandWithTimeout(ContainerCLI(), "ps", "-aq", "--no-trunc")
+	if err != nil {
+		return nil, fmt.Errorf("コンテナ一覧を取得できません: %w", err)
+	}
+	ids := strings.Fields(string(output))
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	args := append([]string{"inspect", "--format", "{{.Name}}|{{.Image}}|{{.State.Running}}|{{.State.StartedAt}}|{{.State.FinishedAt}}"}, ids...)
+	output, err = RunCommandWithTimeout(ContainerCLI(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("コンテナの情報を取得できません: %w", err)
+	}
+
+	var usage []containerImageUsage
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		parts := strings.Split(line, "|")
+		if len(parts) < 5 {
+			continue
+		}
+
+		u := containerImageUsage{
+			name:    strings.TrimPrefix(parts[0], "/"),
+			imageID: shortImageID(parts[1]),
+			running: parts[2] == "true",
+		}
+		if u.running {
+			u.lastUsed = time.Now()
+		} else {
+			for _, ts := range parts[3:5] {
+				if t, err := time.Parse(time.RFC3339Nano, ts); err == nil && t.After(u.lastUsed) {
+					u.lastUsed = t
+				}
+			}
+		}
+		usage = append(usage, u)
+	}
+	return usage, nil
+}
+
+// shortImageID converts "sha256:abcdef..." to the 12 character short ID
+func shortImageID(id string) string {
+	id = strings.TrimPrefix(id, "sha256:")
+	if len(id) > 12 {
+		id = id[:12]
+	}
+	return id
+}
@@ -0,0 +1,99 @@
+//go:build !windows
+
+package monitor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeDockerScript はコマンドを記録し、イメージ2つ（bbbb は停止中のコンテナが使用）とボリューム1つを返す docker
+const fakeDockerScript = `#!/bin/sh
+echo "$*" >> "$DOCKER_LOG"
+case "$1 $2" in
+"image ls")
+	echo "sha256:aaaaaaaaaaaaaaaa|app|v1|100MB|2020-01-02 15:04:05 +0000 UTC"
+	echo "sha256:aaaaaaaaaaaaaaaa|app|latest|100MB|2020-01-02 15:04:05 +0000 UTC"
+	echo "sha256:bbbbbbbbbbbbbbbb|app|v0|100MB|2020-01-02 15:04:05 +0000 UTC" ;;
+"ps -aq")
+	echo c1 ;;
+"ps -a")
+	[ -n "$FAIL_PS" ] && exit 1
+	echo "old|" ;;
+"inspect --format")
+	# 取得中に削除されたコンテナがあると失敗する
+	[ -n "$FAIL_INSPECT" ] && exit 1
+	echo "/old|sha256:bbbbbbbbbbbbbbbb|false|2020-01-02T15:04:05Z|2020-01-03T15:04:05Z" ;;
+"volume ls")
+	echo data ;;
+"volume inspect")
+	echo '[{"Name":"data","Driver":"local","Mountpoint":"/var/lib/docker/volumes/data"}]' ;;
+esac
+`
+
+// withFakeDocker replaces the container CLI with fakeDockerScript and returns the file it logs to
+func withFakeDocker(t *testing.T) string {
+	t.Helper()
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "docker"), []byte(fakeDockerScript), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	logFile := filepath.Join(bin, "log")
+	t.Setenv("DOCKER_LOG", logFile)
+	withDockerRuntime(t)
+	return logFile
+}
+
+// removeCommands returns the logged rm commands
+func removeCommands(t *testing.T, logFile string) []string {
+	t.Helper()
+	data, err := os.ReadFile(logFile)
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+	var commands []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(line, "image rm") || strings.HasPrefix(line, "volume rm") {
+			commands = append(commands, line)
+		}
+	}
+	return commands
+}
+
+func TestPruneUnusedImages(t *testing.T) {
+	logFile := withFakeDocker(t)
+
+	result := PruneUnusedImages()
+	if !result.Success {
+		t.Fatalf("prune failed: %s", result.Message)
+	}
+	// 停止中のコンテナが使うイメージは残し、-f を付けずにタグで削除する
+	got := removeCommands(t, logFile)
+	if len(got) != 1 || got[0] != "image rm app:v1 app:latest" {
+		t.Errorf("rm commands = %q, want [\"image rm app:v1 app:latest\"]", got)
+	}
+}
+
+func TestPruneStopsWhenUsageIsUnknown(t *testing.T) {
+	logFile := withFakeDocker(t)
+	t.Setenv("FAIL_INSPECT", "1")
+	t.Setenv("FAIL_PS", "1")
+
+	if result := PruneUnusedImages(); result.Success || !strings.Contains(result.Message, "中止") {
+		t.Errorf("image prune = %+v, want it to stop", result)
+	}
+	if result := PruneOrphanedVolumes(); result.Success || !strings.Contains(result.Message, "中止") {
+		t.Errorf("volume prune = %+v, want it to stop", result)
+	}
+	if got := removeCommands(t, logFile); len(got) != 0 {
+		t.Errorf("removed %q although the container usage could not be read", got)
+	}
+
+	// 一覧の表示は使用状況なしで続ける
+	if images := GetDockerImages(); len(images) != 2 {
+		t.Errorf("got %d images, want 2", len(images))
+	}
+}
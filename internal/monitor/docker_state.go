@@ -0,0 +1,184 @@
+package monitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RestartLoopThreshold はこの回数以上再起動しているコンテナを再起動ループとして扱う
+const RestartLoopThreshold = 3
+
+// ContainerState holds state details from "docker inspect"
+type ContainerState struct {
+	State         string // running / restarting / exited / paused / created / dead
+	Health        string // healthy / unhealthy / starting（ヘルスチェックなしの場合は空）
+	HealthOutput  string // 最後のヘルスチェックの出力
+	FailingStreak int    // ヘルスチェックの連続失敗回数
+	ExitCode      int
+	OOMKilled     bool
+	RestartCount  int
+	Error         string
+	StartedAt     time.Time
+	FinishedAt    time.Time
+}
+
+// inspectState is the JSON shape of "{{json .State}}"
+type inspectState struct {
+	Status     string `json:"Status"`
+	OOMKilled  bool   `json:"OOMKilled"`
+	ExitCode   int    `json:"ExitCode"`
+	Error      string `json:"Error"`
+	StartedAt  string `json:"StartedAt"`
+	FinishedAt string `json:"FinishedAt"`
+	Health     *struct {
+		Status        string `json:"Status"`
+		FailingStreak int    `json:"FailingStreak"`
+		Log           []struct {
+			ExitCode int    `json:"ExitCode"`
+			Output   string `json:"Output"`
+		} `json:"Log"`
+	} `json:"Health"`
+}
+
+// GetContainerStates returns state details keyed by container ID using a single docker inspect
+func GetContainerStates(containerIDs []string) map[string]ContainerState {
+	states := make(map[string]ContainerState)
+
+	var ids []string
+	for _, id := range containerIDs {
+		if IsValidContainerID(id) {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return states
+	}
+
+	args := append([]string{"inspect", "--format", "{{.Id}}|{{.RestartCount}}|{{json .State}}"}, ids...)
+	output, err := RunCommandWithTimeout(ContainerCLI(), args...)
+	if err != nil {
+		return states
+	}
+
+	for id, state := range parseContainerStates(string(output)) {
+		// docker ps の短縮IDでも引けるようにする
+		for _, requested := range ids {
+			if strings.HasPrefix(id, requested) {
+				states[requested] = state
+			}
+		}
+	}
+	return states
+}
+
+// parseContainerStates parses "<id>|<restart count>|<state json>" lines
+func parseContainerStates(output string) map[string]ContainerState {
+	states := make(map[string]ContainerState)
+
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		parts := strings.SplitN(line, "|", 3)
+		if len(parts) < 3 {
+			continue
+		}
+
+		var raw inspectState
+		if err := json.Unmarshal([]byte(parts[2]), &raw); err != nil {
+			continue
+		}
+
+		state := ContainerState{
+			State:     raw.Status,
+			ExitCode:  raw.ExitCode,
+			OOMKilled: raw.OOMKilled,
+			Error:     raw.Error,
+		}
+		state.RestartCount, _ = strconv.Atoi(parts[1])
+		state.StartedAt, _ = time.Parse(time.RFC3339Nano, raw.StartedAt)
+		state.FinishedAt, _ = time.Parse(time.RFC3339Nano, raw.FinishedAt)
+
+		if raw.Health != nil {
+			state.Health = raw.Health.Status
+			state.FailingStreak = raw.Health.FailingStreak
+			if n := len(raw.Health.Log); n > 0 {
+				state.HealthOutput = strings.TrimSpace(raw.Health.Log[n-1].Output)
+			}
+		}
+
+		states[parts[0]] = state
+	}
+	return states
+}
+
+// RestartLooping reports whether the container is restarting repeatedly
+func (s ContainerState) RestartLooping() bool {
+	return s.State == "restarting" || s.RestartCount >= RestartLoopThreshold
+}
+
+// Crashed reports whether the container stopped with an error
+func (s ContainerState) Crashed() bool {
+	return (s.State == "exited" || s.State == "dead") && (s.ExitCode != 0 || s.OOMKilled)
+}
+
+// ExitReason explains the exit code (137 = SIGKILL / OOM など)
+func (s ContainerState) ExitReason() string {
+	switch {
+	case s.OOMKilled:
+		return "OOM"
+	case s.ExitCode == 137:
+		return "SIGKILL"
+	case s.ExitCode == 143:
+		return "SIGTERM"
+	case s.ExitCode == 139:
+		return "SIGSEGV"
+	case s.ExitCode == 126:
+		return "command not executable"
+	case s.ExitCode == 127:
+		return "command not found"
+	default:
+		return ""
+	}
+}
+
+// Summary returns a one-line English description for AI analysis (空の場合は問題なし)
+func (s ContainerState) Summary() string {
+	var notes []string
+
+	if s.RestartCount > 0 {
+		notes = append(notes, fmt.Sprintf("restarted %d times", s.RestartCount))
+	}
+	if s.State == "restarting" {
+		notes = append(notes, "currently restarting")
+	}
+	if s.RestartCount > 0 || s.Crashed() || s.State == "restarting" {
+		exit := fmt.Sprintf("last exit %d", s.ExitCode)
+		if reason := s.ExitReason(); reason != "" {
+			exit += fmt.Sprintf(" (%s)", reason)
+		}
+		notes = append(notes, exit)
+	}
+	if s.Health == "unhealthy" {
+		health := fmt.Sprintf("unhealthy (%d consecutive failures)", s.FailingStreak)
+		if s.HealthOutput != "" {
+			health += ": " + firstLine(s.HealthOutput)
+		}
+		notes = append(notes, health)
+	}
+	if s.Error != "" {
+		notes = append(notes, "error: "+s.Error)
+	}
+
+	return strings.Join(notes, ", ")
+}
+
+// firstLine returns the first non-empty line of text
+func firstLine(text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
@@ -0,0 +1,282 @@
+package monitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/config"
+)
+
+// ElasticsearchCluster represents cluster health and version information
+type ElasticsearchCluster struct {
+	IsReachable      bool
+	Distribution     string // "Elasticsearch" または "OpenSearch"
+	Version          string
+	ClusterName      string
+	Health           string // green / yellow / red
+	Nodes            int
+	DataNodes        int
+	ActiveShards     int
+	UnassignedShards int
+	Container        string // コンテナで動作している場合のコンテナ名
+	Error            string // 接続できない場合のエラー内容
+}
+
+// ElasticsearchNode represents a node from _cat/nodes
+type ElasticsearchNode struct {
+	Name        string
+	Roles       string
+	HeapPercent string
+	HeapCurrent string
+	HeapMax     string
+	CPU         string
+	Load1m      string
+}
+
+// ElasticsearchIndex represents an index from _cat/indices
+type ElasticsearchIndex struct {
+	Name          string
+	Health        string
+	Status        string
+	DocsCount     string
+	StoreSize     string
+	PrimaryShards string
+	Replicas      string
+}
+
+// ElasticsearchPendingTask represents a task from _cluster/pending_tasks
+type ElasticsearchPendingTask struct {
+	InsertOrder int64
+	Priority    string
+	Source      string
+	TimeInQueue string
+}
+
+// elasticsearchContainerImages はElasticsearch/OpenSearchコンテナとみなすイメージ名
+var elasticsearchContainerImages = []string{"elasticsearch", "opensearch"}
+
+// elasticsearchIndexPattern はインデックス名として許可する形式
+// ワイルドカード（*）やカンマ区切り、_all による一括操作を防ぐ
+var elasticsearchIndexPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._+-]*$`)
+
+// CheckElasticsearch checks if Elasticsearch/OpenSearch is running (local process or container)
+func CheckElasticsearch() string {
+	// ローカルプロセス（JVMのメインクラスで検索）
+	if output, err := RunCommandWithTimeout("pgrep", "-f", "org.elasticsearch.bootstrap|org.opensearch.bootstrap"); err == nil {
+		pids := strings.Fields(string(output))
+
+		result := "✓ Elasticsearch: 実行中"
+		if len(pids) > 0 {
+			if port := getProcessPort(pids[0]); port != "" {
+				result += " [:" + port + "]"
+			}
+			if statsStr := formatStatsString(getProcessStats(pids[0])); statsStr != "" {
+				result += fmt.Sprintf(" | %s", statsStr)
+			}
+		}
+		return result
+	}
+
+	// コンテナで動作しているか確認
+	container := FindContainerByImage(elasticsearchContainerImages...)
+	if container == nil {
+		return "✗ Elasticsearch: 停止中"
+	}
+
+	portInfo := ""
+	if port := getContainerPort(container.ID); port != "" {
+		portInfo = " [:" + port + "]"
+	}
+
+	result := fmt.Sprintf("✓ Elasticsearch: 実行中 (コンテナ: %s)%s", container.Name, portInfo)
+
+	stats := GetDockerContainerStats(container.ID)
+	if statsStr := formatDockerStatsString(stats); statsStr != "" {
+		result += fmt.Sprintf(" | %s", statsStr)
+	}
+
+	return result
+}
+
+// IsElasticsearchRunning reports whether Elasticsearch/OpenSearch is running locally or in a container
+func IsElasticsearchRunning() bool {
+	if _, err := RunCommandWithTimeout("pgrep", "-f", "org.elasticsearch.bootstrap|org.opensearch.bootstrap"); err == nil {
+		return true
+	}
+	return FindContainerByImage(elasticsearchContainerImages...) != nil
+}
+
+// elasticsearchRequest sends a request to the REST API
+func elasticsearchRequest(method, path string) ([]byte, error) {
+	cfg := config.Load().Elasticsearch
+	return doHTTPRequest(method, strings.TrimRight(cfg.URL, "/")+path, cfg.User, cfg.Password)
+}
+
+// GetElasticsearchCluster returns cluster health and version
+func GetElasticsearchCluster() ElasticsearchCluster {
+	// バージョン情報（ルートエンドポイント）
+	body, err := elasticsearchRequest(http.MethodGet, "/")
+	if err != nil {
+		return ElasticsearchCluster{IsReachable: false, Error: err.Error()}
+	}
+
+	var root struct {
+		ClusterName string `json:"cluster_name"`
+		Version     struct {
+			Number       string `json:"number"`
+			Distribution string `json:"distribution"`
+		} `json:"version"`
+	}
+	if err := json.Unmarshal(body, &root); err != nil {
+		return ElasticsearchCluster{IsReachable: false, Error: err.Error()}
+	}
+
+	cluster := ElasticsearchCluster{
+		IsReachable:  true,
+		Distribution: "Elasticsearch",
+		Version:      root.Version.Number,
+		ClusterName:  root.ClusterName,
+	}
+	if root.Version.Distribution == "opensearch" {
+		cluster.Distribution = "OpenSearch"
+	}
+
+	// クラスタヘルス
+	if body, err := elasticsearchRequest(http.MethodGet, "/_cluster/health"); err == nil {
+		var health struct {
+			Status           string `json:"status"`
+			NumberOfNodes    int    `json:"number_of_nodes"`
+			NumberOfDataNode int    `json:"number_of_data_nodes"`
+			ActiveShards     int    `json:"active_shards"`
+			UnassignedShards int    `json:"unassigned_shards"`
+		}
+		if json.Unmarshal(body, &health) == nil {
+			cluster.Health = health.Status
+			cluster.Nodes = health.NumberOfNodes
+			cluster.DataNodes = health.NumberOfDataNode
+			cluster.ActiveShards = health.ActiveShards
+			cluster.UnassignedShards = health.UnassignedShards
+		}
+	}
+
+	if container := FindContainerByImage(elasticsearchContainerImages...); container != nil {
+		cluster.Container = container.Name
+	}
+
+	return cluster
+}
+
+// GetElasticsearchNodes returns nodes with heap usage
+func GetElasticsearchNodes() []ElasticsearchNode {
+	body, err := elasticsearchRequest(http.MethodGet, "/_cat/nodes?format=json&h=name,node.role,heap.percent,heap.current,heap.max,cpu,load_1m")
+	if err != nil {
+		return []ElasticsearchNode{}
+	}
+
+	var result []map[string]interface{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return []ElasticsearchNode{}
+	}
+
+	var nodes []ElasticsearchNode
+	for _, n := range result {
+		nodes = append(nodes, ElasticsearchNode{
+			Name:        catValue(n, "name"),
+			Roles:       catValue(n, "node.role"),
+			HeapPercent: catValue(n, "heap.percent"),
+			HeapCurrent: catValue(n, "heap.current"),
+			HeapMax:     catValue(n, "heap.max"),
+			CPU:         catValue(n, "cpu"),
+			Load1m:      catValue(n, "load_1m"),
+		})
+	}
+
+	return nodes
+}
+
+// GetElasticsearchIndices returns indices ordered by size (system indices are excluded)
+func GetElasticsearchIndices() []ElasticsearchIndex {
+	body, err := elasticsearchRequest(http.MethodGet, "/_cat/indices?format=json&h=health,status,index,pri,rep,docs.count,store.size&s=store.size:desc")
+	if err != nil {
+		return []ElasticsearchIndex{}
+	}
+
+	var result []map[string]interface{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return []ElasticsearchIndex{}
+	}
+
+	var indices []ElasticsearchIndex
+	for _, idx := range result {
+		// "." で始まるシステムインデックスはスキップ
+		if IsElasticsearchSystemIndex(catValue(idx, "index")) {
+			continue
+		}
+
+		indices = append(indices, ElasticsearchIndex{
+			Name:          catValue(idx, "index"),
+			Health:        catValue(idx, "health"),
+			Status:        catValue(idx, "status"),
+			DocsCount:     catValue(idx, "docs.count"),
+			StoreSize:     catValue(idx, "store.size"),
+			PrimaryShards: catValue(idx, "pri"),
+			Replicas:      catValue(idx, "rep"),
+		})
+	}
+
+	return indices
+}
+
+// GetElasticsearchPendingTasks returns cluster-level pending tasks
+func GetElasticsearchPendingTasks() []ElasticsearchPendingTask {
+	body, err := elasticsearchRequest(http.MethodGet, "/_cluster/pending_tasks")
+	if err != nil {
+		return []ElasticsearchPendingTask{}
+	}
+
+	var result struct {
+		Tasks []struct {
+			InsertOrder int64  `json:"insert_order"`
+			Priority    string `json:"priority"`
+			Source      string `json:"source"`
+			TimeInQueue string `json:"time_in_queue"`
+		} `json:"tasks"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return []ElasticsearchPendingTask{}
+	}
+
+	var tasks []ElasticsearchPendingTask
+	for _, t := range result.Tasks {
+		tasks = append(tasks, ElasticsearchPendingTask{
+			InsertOrder: t.InsertOrder,
+			Priority:    t.Priority,
+			Source:      t.Source,
+			TimeInQueue: t.TimeInQueue,
+		})
+	}
+
+	return tasks
+}
+
+// IsElasticsearchSystemIndex reports whether the index is a system/hidden index
+func IsElasticsearchSystemIndex(name string) bool {
+	return strings.HasPrefix(name, ".")
+}
+
+// IsValidElasticsearchIndex reports whether the index name is safe to operate on
+func IsValidElasticsearchIndex(name string) bool {
+	return name != "_all" && elasticsearchIndexPattern.MatchString(name)
+}
+
+// catValue returns a column of _cat API (JSON) as string
+// 値がnullの場合は空文字を返します
+func catValue(row map[string]interface{}, key string) string {
+	if v, ok := row[key]; ok && v != nil {
+		return fmt.Sprint(v)
+	}
+	return ""
+}
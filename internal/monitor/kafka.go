@@ -0,0 +1,324 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/config"
+)
+
+// KafkaTopic represents a topic from kafka-topics --describe
+type KafkaTopic struct {
+	Name              string
+	Partitions        int
+	ReplicationFactor string
+}
+
+// KafkaConsumerGroupLag represents lag of a consumer group for a topic
+type KafkaConsumerGroupLag struct {
+	Group      string
+	Topic      string
+	Partitions int
+	Lag        int64
+	Consumers  int // アクティブなコンシューマー数（0なら誰も消費していない）
+}
+
+// KafkaStatus represents topics and consumer group lag
+type KafkaStatus struct {
+	IsReachable bool
+	Topics      []KafkaTopic
+	Groups      []KafkaConsumerGroupLag
+	Container   string // コンテナで動作している場合のコンテナ名
+	Error       string // 接続できない場合のエラー内容
+}
+
+// KafkaLagWarningThreshold は遅延とみなすラグ
+const KafkaLagWarningThreshold = 1000
+
+// kafkaToolTimeout はKafka CLI（JVM起動を含む）のタイムアウト
+const kafkaToolTimeout = 15 * time.Second
+
+// kafkaContainerImages はKafkaコンテナとみなすイメージ名
+var kafkaContainerImages = []string{"kafka", "cp-kafka", "cp-server"}
+
+// CheckKafka checks if Kafka is running (local process or container)
+func CheckKafka() string {
+	// ローカルプロセス（JVMのメインクラスで検索）
+	if output, err := RunCommandWithTimeout("pgrep", "-f", "kafka.Kafka"); err == nil {
+		pids := strings.Fields(string(output))
+
+		result := "✓ Kafka: 実行中"
+		if len(pids) > 0 {
+			if port := getProcessPort(pids[0]); port != "" {
+				result += " [:" + port + "]"
+			}
+			if statsStr := formatStatsString(getProcessStats(pids[0])); statsStr != "" {
+				result += fmt.Sprintf(" | %s", statsStr)
+			}
+		}
+		return result
+	}
+
+	// コンテナで動作しているか確認
+	container := FindContainerByImage(kafkaContainerImages...)
+	if container == nil {
+		return "✗ Kafka: 停止中"
+	}
+
+	portInfo := ""
+	if port := getContainerPort(container.ID); port != "" {
+		portInfo = " [:" + port + "]"
+	}
+
+	result := fmt.Sprintf("✓ Kafka: 実行中 (コンテナ: %s)%s", container.Name, portInfo)
+
+	stats := GetDockerContainerStats(container.ID)
+	if statsStr := formatDockerStatsString(stats); statsStr != "" {
+		result += fmt.Sprintf(" | %s", statsStr)
+	}
+
+	return result
+}
+
+// IsKafkaRunning reports whether Kafka is running locally or in a container
+func IsKafkaRunning() bool {
+	if _, err := RunCommandWithTimeout("pgrep", "-f", "kafka.Kafka"); err == nil {
+		return true
+	}
+	return FindContainerByImage(kafkaContainerImages...) != nil
+}
+
+// kafkaToolCommand builds a command that runs a Kafka CLI tool (kafka-topics etc.)
+// ローカルにツールがない場合はKafkaコンテナ内のツールを利用します
+func kafkaToolCommand(ctx context.Context, cfg config.KafkaConfig, tool string, args ...string) (*exec.Cmd, string, error) {
+	// ディストリビューションにより .sh 付きの場合がある
+	for _, name := range []string{tool, tool + ".sh"} {
+		if path, err := exec.LookPath(name); err == nil {
+			fullArgs := append([]string{"--bootstrap-server", cfg.BootstrapServer}, args...)
+			return exec.CommandContext(ctx, path, fullArgs...), "", nil
+		}
+	}
+
+	container := FindContainerByImage(kafkaContainerImages...)
+	if container == nil {
+		return nil, "", fmt.Errorf("%s が見つかりません", tool)
+	}
+
+	// コンテナ内ではPATHにない場合もあるため候補を順に探す
+	script := fmt.Sprintf(`for c in %[1]s %[1]s.sh /opt/kafka/bin/%[1]s.sh /opt/bitnami/kafka/bin/%[1]s.sh; do
+	if command -v "$c" >/dev/null 2>&1; then exec "$c" "$@"; fi
+done
+echo "%[1]s not found" >&2
+exit 127`, tool)
+
+	// コンテナ内からはブローカーが広告するリスナーで接続する
+	bootstrap := getKafkaContainerListener(container.ID)
+	if bootstrap == "" {
+		bootstrap = cfg.BootstrapServer
+	}
+
+	fullArgs := append([]string{"exec", container.ID, "sh", "-c", script, "sh", "--bootstrap-server", bootstrap}, args...)
+	return exec.CommandContext(ctx, ContainerCLI(), fullArgs...), container.Name, nil
+}
+
+// getKafkaContainerListener returns the advertised listener of a Kafka container
+func getKafkaContainerListener(containerID string) string {
+	output, err := RunCommandWithTimeout(ContainerCLI(), "inspect", "--format", "{{range .Config.Env}}{{println .}}{{end}}", containerID)
+	if err != nil {
+		return ""
+	}
+	return parseKafkaAdvertisedListener(string(output))
+}
+
+// parseKafkaAdvertisedListener picks a bootstrap address from the container environment
+// 例: "KAFKA_ADVERTISED_LISTENERS=PLAINTEXT://kafka:29092,PLAINTEXT_HOST://localhost:9092"
+// コンテナ内から確実に届く localhost のリスナーを優先します
+func parseKafkaAdvertisedListener(env string) string {
+	for _, line := range strings.Split(env, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+		// Bitnamiのイメージは KAFKA_CFG_ を付けて設定する
+		if !ok || (key != "KAFKA_ADVERTISED_LISTENERS" && key != "KAFKA_CFG_ADVERTISED_LISTENERS") {
+			continue
+		}
+
+		first := ""
+		for _, listener := range strings.Split(value, ",") {
+			_, address, found := strings.Cut(strings.TrimSpace(listener), "://")
+			if !found || address == "" {
+				continue
+			}
+			host := address
+			if idx := strings.LastIndex(address, ":"); idx >= 0 {
+				host = address[:idx]
+			}
+			if host == "localhost" || host == "127.0.0.1" {
+				return address
+			}
+			if first == "" {
+				first = address
+			}
+		}
+		return first
+	}
+	return ""
+}
+
+// runKafkaTool runs a Kafka CLI tool and returns its stdout
+func runKafkaTool(cfg config.KafkaConfig, tool string, args ...string) (string, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), kafkaToolTimeout)
+	defer cancel()
+
+	cmd, containerName, err := kafkaToolCommand(ctx, cfg, tool, args...)
+	if err != nil {
+		return "", "", err
+	}
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		message := strings.TrimSpace(string(output))
+		if message == "" {
+			message = err.Error()
+		}
+		// JVMのスタックトレースは長いため先頭行のみ
+		return "", containerName, fmt.Errorf("%s", strings.SplitN(message, "\n", 2)[0])
+	}
+
+	return string(output), containerName, nil
+}
+
+// GetKafkaStatus returns topics and consumer group lag
+func GetKafkaStatus() KafkaStatus {
+	cfg := config.Load().Kafka
+
+	topicsOutput, containerName, err := runKafkaTool(cfg, "kafka-topics", "--describe")
+	if err != nil {
+		return KafkaStatus{IsReachable: false, Error: err.Error()}
+	}
+
+	status := KafkaStatus{
+		IsReachable: true,
+		Topics:      parseKafkaTopics(topicsOutput),
+		Container:   containerName,
+	}
+
+	// コンシューマーグループがない場合もエラーにはしない
+	if groupsOutput, _, err := runKafkaTool(cfg, "kafka-consumer-groups", "--describe", "--all-groups"); err == nil {
+		status.Groups = parseKafkaConsumerGroups(groupsOutput)
+	}
+
+	return status
+}
+
+// parseKafkaTopics parses output of kafka-topics --describe
+// 例: "Topic: orders\tTopicId: xxx\tPartitionCount: 3\tReplicationFactor: 1\tConfigs: "
+func parseKafkaTopics(output string) []KafkaTopic {
+	var topics []KafkaTopic
+
+	for _, line := range strings.Split(output, "\n") {
+		// パーティション行（先頭がタブ）は無視
+		if !strings.HasPrefix(line, "Topic:") {
+			continue
+		}
+
+		fields := make(map[string]string)
+		for _, part := range strings.Split(line, "\t") {
+			kv := strings.SplitN(part, ":", 2)
+			if len(kv) == 2 {
+				fields[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+			}
+		}
+
+		// 内部トピックは除外
+		name := fields["Topic"]
+		if name == "" || strings.HasPrefix(name, "__") {
+			continue
+		}
+
+		partitions, _ := strconv.Atoi(fields["PartitionCount"])
+		topics = append(topics, KafkaTopic{
+			Name:              name,
+			Partitions:        partitions,
+			ReplicationFactor: fields["ReplicationFactor"],
+		})
+	}
+
+	sort.Slice(topics, func(i, j int) bool {
+		return topics[i].Name < topics[j].Name
+	})
+
+	return topics
+}
+
+// parseKafkaConsumerGroups parses output of kafka-consumer-groups --describe --all-groups
+// パーティションごとの行をグループ×トピック単位に集計します
+func parseKafkaConsumerGroups(output string) []KafkaConsumerGroupLag {
+	type groupKey struct{ group, topic string }
+
+	lags := make(map[groupKey]*KafkaConsumerGroupLag)
+	consumers := make(map[groupKey]map[string]bool)
+	var order []groupKey
+
+	var columns map[string]int
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			// グループの区切り（空行）でヘッダーをリセット
+			columns = nil
+			continue
+		}
+
+		// ヘッダー行から列位置を取得（グループごとに繰り返し出力される）
+		if fields[0] == "GROUP" {
+			columns = make(map[string]int)
+			for i, name := range fields {
+				columns[name] = i
+			}
+			continue
+		}
+		if columns == nil {
+			continue
+		}
+
+		get := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(fields) {
+				return fields[i]
+			}
+			return ""
+		}
+
+		key := groupKey{group: get("GROUP"), topic: get("TOPIC")}
+		if key.group == "" || key.topic == "" || key.topic == "-" {
+			continue
+		}
+
+		entry, exists := lags[key]
+		if !exists {
+			entry = &KafkaConsumerGroupLag{Group: key.group, Topic: key.topic}
+			lags[key] = entry
+			consumers[key] = make(map[string]bool)
+			order = append(order, key)
+		}
+
+		entry.Partitions++
+		if lag, err := strconv.ParseInt(get("LAG"), 10, 64); err == nil {
+			entry.Lag += lag
+		}
+		if consumerID := get("CONSUMER-ID"); consumerID != "" && consumerID != "-" {
+			consumers[key][consumerID] = true
+		}
+	}
+
+	var result []KafkaConsumerGroupLag
+	for _, key := range order {
+		entry := lags[key]
+		entry.Consumers = len(consumers[key])
+		result = append(result, *entry)
+	}
+
+	return result
+}
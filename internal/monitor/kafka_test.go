@@ -0,0 +1,82 @@
+package monitor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseKafkaTopics(t *testing.T) {
+	output := "Topic: orders\tTopicId: q1\tPartitionCount: 3\tReplicationFactor: 1\tConfigs: \n" +
+		"\tTopic: orders\tPartition: 0\tLeader: 1\tReplicas: 1\tIsr: 1\n" +
+		"\tTopic: orders\tPartition: 1\tLeader: 1\tReplicas: 1\tIsr: 1\n" +
+		"Topic: __consumer_offsets\tTopicId: q2\tPartitionCount: 50\tReplicationFactor: 1\tConfigs: compression.type=producer\n" +
+		"Topic: events\tTopicId: q3\tPartitionCount: 1\tReplicationFactor: 3\tConfigs: retention.ms=60000\n"
+
+	tests := []struct {
+		name   string
+		output string
+		want   []KafkaTopic
+	}{
+		{"topics sorted without internal ones", output, []KafkaTopic{
+			{Name: "events", Partitions: 1, ReplicationFactor: "3"},
+			{Name: "orders", Partitions: 3, ReplicationFactor: "1"},
+		}},
+		{"no topics", "", nil},
+	}
+	for _, tt := range tests {
+		if got := parseKafkaTopics(tt.output); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseKafkaConsumerGroups(t *testing.T) {
+	output := `
+GROUP           TOPIC           PARTITION  CURRENT-OFFSET  LOG-END-OFFSET  LAG             CONSUMER-ID     HOST            CLIENT-ID
+billing         orders          0          100             1600            1500            consumer-1-a    /172.18.0.5     consumer-1
+billing         orders          1          50              60              10              consumer-1-a    /172.18.0.5     consumer-1
+billing         orders          2          -               0               -               consumer-2-b    /172.18.0.6     consumer-2
+
+Consumer group 'idle' has no active members.
+
+GROUP           TOPIC           PARTITION  CURRENT-OFFSET  LOG-END-OFFSET  LAG             CONSUMER-ID     HOST            CLIENT-ID
+idle            events          0          5               8               3               -               -               -
+`
+
+	tests := []struct {
+		name   string
+		output string
+		want   []KafkaConsumerGroupLag
+	}{
+		{"lag summed per group and topic", output, []KafkaConsumerGroupLag{
+			{Group: "billing", Topic: "orders", Partitions: 3, Lag: 1510, Consumers: 2},
+			// アクティブなメンバーがいないグループ
+			{Group: "idle", Topic: "events", Partitions: 1, Lag: 3, Consumers: 0},
+		}},
+		{"rows without a header", "billing orders 0 100 1600 1500 consumer-1 /host client\n", nil},
+		{"no groups", "", nil},
+	}
+	for _, tt := range tests {
+		if got := parseKafkaConsumerGroups(tt.output); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseKafkaAdvertisedListener(t *testing.T) {
+	tests := []struct {
+		name string
+		env  string
+		want string
+	}{
+		{"prefers localhost", "PATH=/usr/bin\nKAFKA_ADVERTISED_LISTENERS=PLAINTEXT://kafka:29092,PLAINTEXT_HOST://localhost:9092\n", "localhost:9092"},
+		{"first listener", "KAFKA_ADVERTISED_LISTENERS=PLAINTEXT://broker:19092,EXTERNAL://10.0.0.5:9094\n", "broker:19092"},
+		{"bitnami", "KAFKA_CFG_ADVERTISED_LISTENERS=PLAINTEXT://127.0.0.1:9092\n", "127.0.0.1:9092"},
+		{"not set", "PATH=/usr/bin\nKAFKA_LISTENERS=PLAINTEXT://:9092\n", ""},
+	}
+	for _, tt := range tests {
+		if got := parseKafkaAdvertisedListener(tt.env); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -4,18 +4,68 @@ import (
 	"context"
 	"fmt"
 	"os/exec"
+	"strings"
 	"time"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
 )
 
 // GetContainerLogs returns the last N lines of container logs (optimized)
 func GetContainerLogs(containerID string, lines int) (string, error) {
+	return getContainerLogs(containerID, lines, "1h", false)
+}
+
+// GetContainerLogLines returns the last N lines of container logs with their timestamps
+func GetContainerLogLines(containerID, source string, lines int) ([]Line, error) {
+	return getContainerLogLines(containerID, source, lines, "1h")
+}
+
+// GetContainerLogLinesSince returns container log lines written after since (最大N行)
+func GetContainerLogLinesSince(containerID, source string, since time.Time, lines int) ([]Line, error) {
+	result, err := getContainerLogLines(containerID, source, lines, since.UTC().Format(time.RFC3339Nano))
+	if err != nil {
+		return nil, err
+	}
+
+	// --since の精度は実装によって異なるため、since 以前の行は捨てる
+	filtered := result[:0]
+	for _, line := range result {
+		if line.Time.After(since) {
+			filtered = append(filtered, line)
+		}
+	}
+	return filtered, nil
+}
+
+// getContainerLogLines parses "docker logs --timestamps" output into lines
+func getContainerLogLines(containerID, source string, lines int, since string) ([]Line, error) {
+	output, err := getContainerLogs(containerID, lines, since, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Line
+	for _, text := range strings.Split(strings.TrimRight(output, "\n"), "\n") {
+		if text != "" {
+			result = append(result, parseTimestampedLine(source, text))
+		}
+	}
+	return result, nil
+}
+
+// getContainerLogs runs "docker logs --tail N --since <since>" (timestamps=true で各行の先頭に時刻を付ける)
+func getContainerLogs(containerID string, lines int, since string, timestamps bool) (string, error) {
 	// タイムアウト付きコンテキスト（3秒）
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	// docker logs <container_id> --tail <lines> --since 1h (最近1時間のみ)
+	// docker logs <container_id> --tail <lines> --since 1h (通常は最近1時間のみ)
 	// --sinceオプションで大量のログがある場合の検索時間を短縮
-	cmd := exec.CommandContext(ctx, "docker", "logs", containerID, "--tail", fmt.Sprintf("%d", lines), "--since", "1h")
+	args := []string{"logs", containerID, "--tail", fmt.Sprintf("%d", lines), "--since", since}
+	if timestamps {
+		args = append(args, "--timestamps")
+	}
+	cmd := exec.CommandContext(ctx, monitor.ContainerCLI(), args...)
 	output, err := cmd.CombinedOutput()
 
 	if err != nil {
@@ -28,3 +78,32 @@ func GetContainerLogs(containerID string, lines int) (string, error) {
 
 	return string(output), nil
 }
+
+// GetComposeServiceLogs returns the last N lines of all replicas of a compose service
+func GetComposeServiceLogs(projectName, service string, lines int) (string, error) {
+	if !monitor.IsValidComposeServiceName(service) {
+		return "", fmt.Errorf("不正なサービス名です")
+	}
+
+	// -f / --env-file / --profile はプロジェクトの設定から組み立てる
+	argv, err := monitor.BuildComposeCommand(projectName, "logs", "--no-color", "--tail", fmt.Sprintf("%d", lines), service)
+	if err != nil {
+		return "", err
+	}
+
+	// タイムアウト付きコンテキスト（5秒、composeの起動分を含む）
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	output, err := cmd.CombinedOutput()
+
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return "", fmt.Errorf("ログ取得タイムアウト（5秒）")
+		}
+		return "", fmt.Errorf("ログ取得失敗: %s", string(output))
+	}
+
+	return string(output), nil
+}
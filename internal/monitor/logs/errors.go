@@ -0,0 +1,231 @@
+package logs
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// errorEventMaxFrames はシグネチャに含めるスタックトレースのフレーム数
+const errorEventMaxFrames = 3
+
+// errorEventMaxLines は1つのエラーとして保持する最大行数
+const errorEventMaxLines = 20
+
+// スパイク判定: 直近1分のエラー数が spikeMinErrors 以上かつ、それ以前の平均の spikeFactor 倍以上
+const (
+	spikeWindow    = time.Minute
+	spikeBaseline  = 15 * time.Minute
+	spikeMinErrors = 10
+	spikeFactor    = 3
+	spikeCooldown  = 10 * time.Minute
+)
+
+// IssueKind is the kind of problem found in logs
+type IssueKind string
+
+const (
+	IssueErrorSpike IssueKind = "spike"     // エラーの急増
+	IssueNewError   IssueKind = "new_error" // これまでに見たことのないエラー
+)
+
+// ErrorEvent is one error in a log, including the stack trace that follows it
+type ErrorEvent struct {
+	Source    string
+	Signature string   // 数値やIDを除いて正規化したメッセージとスタックのハッシュ
+	Message   string   // エラーの1行目（Pythonのトレースバックは例外の行）
+	Lines     []string // スタックトレースを含む元の行
+	Time      time.Time
+}
+
+// LogIssue is a problem detected in the logs of a source
+type LogIssue struct {
+	Kind      IssueKind
+	Source    string
+	Group     string // 左メニューの項目名
+	Message   string
+	Signature string
+	Count     int // スパイク: 直近1分のエラー数
+	Time      time.Time
+}
+
+var (
+	// スタックトレースの続きとみなす行
+	stackFrameRegex = regexp.MustCompile(`^(\s+\S|\s*at\s|\s*File "|Caused by:|goroutine \d+|\s*\.\.\. \d+ more)`)
+
+	// 正規化で置き換える値（順序が重要）
+	normalizeRules = []struct {
+		re   *regexp.Regexp
+		repl string
+	}{
+		{regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`), "<uuid>"},
+		{regexp.MustCompile(`"[^"]*"|'[^']*'`), "<str>"},
+		{regexp.MustCompile(`\b0x[0-9a-fA-F]+\b|\b[0-9a-fA-F]*\d[0-9a-fA-F]*[a-fA-F][0-9a-fA-F]*\b|\b[0-9a-fA-F]*[a-fA-F][0-9a-fA-F]*\d[0-9a-fA-F]*\b`), "<hex>"},
+		{regexp.MustCompile(`\d+`), "<n>"},
+		{regexp.MustCompile(`\s+`), " "},
+	}
+)
+
+// ExtractErrorEvents groups ERROR lines with the stack traces that follow them
+func ExtractErrorEvents(lines []Line) []ErrorEvent {
+	var events []ErrorEvent
+	var current *ErrorEvent
+	traceback := false
+
+	flush := func() {
+		if current != nil {
+			current.Signature = errorSignature(current.Message, current.Lines)
+			events = append(events, *current)
+		}
+		current = nil
+		traceback = false
+	}
+
+	for _, line := range lines {
+		text := strings.TrimRight(line.Text, " \t")
+
+		if current != nil && len(current.Lines) < errorEventMaxLines {
+			if stackFrameRegex.MatchString(text) {
+				current.Lines = append(current.Lines, text)
+				continue
+			}
+			// Pythonのトレースバックは最後の行（例外の種類とメッセージ）までを1つのエラーとする
+			if traceback && text != "" {
+				current.Lines = append(current.Lines, text)
+				current.Message = text
+				flush()
+				continue
+			}
+		}
+
+		startsTraceback := strings.HasPrefix(text, "Traceback (most recent call last)")
+		if line.Level != LevelError && !startsTraceback && !strings.HasPrefix(text, "panic: ") {
+			flush()
+			continue
+		}
+
+		// 直前のエラーの直後に出るトレースバックは同じエラーとして扱う
+		if startsTraceback && current != nil {
+			current.Lines = append(current.Lines, text)
+			traceback = true
+			continue
+		}
+
+		flush()
+		current = &ErrorEvent{
+			Source:  line.Source,
+			Message: stripLeadingTime(text),
+			Lines:   []string{text},
+			Time:    line.Time,
+		}
+		traceback = startsTraceback
+	}
+	flush()
+	return events
+}
+
+// errorSignature normalises a message and the top stack frames into a stable hash
+// 数値・ID・文字列リテラルを置き換えるため、同じ原因のエラーは同じシグネチャになる
+func errorSignature(message string, lines []string) string {
+	parts := []string{NormalizeErrorMessage(message)}
+	frames := 0
+	for _, text := range lines[1:] {
+		if frames >= errorEventMaxFrames {
+			break
+		}
+		if stackFrameRegex.MatchString(text) {
+			parts = append(parts, NormalizeErrorMessage(text))
+			frames++
+		}
+	}
+	sum := sha1.Sum([]byte(strings.Join(parts, "\n")))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// NormalizeErrorMessage removes the timestamp and variable values from an error message
+func NormalizeErrorMessage(text string) string {
+	text = stripLeadingTime(text)
+	for _, rule := range normalizeRules {
+		text = rule.re.ReplaceAllString(text, rule.repl)
+	}
+	return strings.TrimSpace(text)
+}
+
+// stripLeadingTime removes a timestamp at the start of a log line
+func stripLeadingTime(text string) string {
+	if loc := leadingTimeRegex.FindStringIndex(text); loc != nil {
+		text = strings.TrimLeft(strings.TrimPrefix(text[loc[1]:], "]"), " \t")
+	}
+	return text
+}
+
+// SpikeDetector detects sudden increases of the error rate per source
+type SpikeDetector struct {
+	mu        sync.Mutex
+	events    map[string][]time.Time // ソースごとのエラー発生時刻（基準期間ぶん）
+	lastAlert map[string]time.Time
+}
+
+// NewSpikeDetector creates a detector with no history
+func NewSpikeDetector() *SpikeDetector {
+	return &SpikeDetector{
+		events:    make(map[string][]time.Time),
+		lastAlert: make(map[string]time.Time),
+	}
+}
+
+// Observe records errors of a source and reports a spike (同じソースの通知は一定時間に1回まで)
+func (d *SpikeDetector) Observe(source string, events []ErrorEvent, now time.Time) (int, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// 過去ログの時刻ではなく、収集した時刻で数える（まとめて届いた過去ログを急増とみなさない）
+	times := d.events[source]
+	for range events {
+		times = append(times, now)
+	}
+
+	cutoff := now.Add(-spikeWindow - spikeBaseline)
+	kept := times[:0]
+	for _, t := range times {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	d.events[source] = kept
+
+	recent, before := 0, 0
+	for _, t := range kept {
+		if t.After(now.Add(-spikeWindow)) {
+			recent++
+		} else {
+			before++
+		}
+	}
+	baseline := float64(before) / float64(spikeBaseline/spikeWindow)
+
+	if recent < spikeMinErrors || float64(recent) < spikeFactor*baseline {
+		return recent, false
+	}
+	if last, ok := d.lastAlert[source]; ok && now.Sub(last) < spikeCooldown {
+		return recent, false
+	}
+	d.lastAlert[source] = now
+	return recent, true
+}
+
+// Forget drops the history of sources that are no longer collected
+func (d *SpikeDetector) Forget(active map[string]bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for source := range d.events {
+		if !active[source] {
+			delete(d.events, source)
+			delete(d.lastAlert, source)
+		}
+	}
+}
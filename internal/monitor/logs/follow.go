@@ -0,0 +1,314 @@
+package logs
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
+)
+
+// FollowBufferLines はフォロー中のログビューが保持する最大行数
+const FollowBufferLines = 5000
+
+// filePollInterval はログファイルの追記・ローテーションを確認する間隔
+const filePollInterval = 500 * time.Millisecond
+
+// maxLineBytes は1行として扱う最大サイズ（これを超える行は分割する）
+const maxLineBytes = 1024 * 1024
+
+// LogStream is a running log follow that delivers lines until it is stopped
+type LogStream struct {
+	Lines  <-chan Line // 新しい行（終了するとクローズされる）
+	Source string      // ログの取得元（コマンドやファイルパス）
+
+	cancel context.CancelFunc
+	mu     sync.Mutex
+	err    error
+}
+
+// Stop stops following and releases the underlying process or file
+func (s *LogStream) Stop() {
+	s.cancel()
+}
+
+// Err returns why the stream ended (Stopで止めた場合はnil)
+func (s *LogStream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+func (s *LogStream) setErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+// lineParser converts a raw text line into a Line (falseを返した行は捨てる)
+type lineParser func(text string) (Line, bool)
+
+// plainLine is the parser for single-source logs
+func plainLine(text string) (Line, bool) {
+	return Line{Text: text}, true
+}
+
+// FollowContainerLogs streams container logs like "docker logs -f", starting with the last N lines
+func FollowContainerLogs(containerID string, lines int) (*LogStream, error) {
+	if !monitor.IsValidContainerID(containerID) {
+		return nil, fmt.Errorf("不正なコンテナIDです")
+	}
+	return followCommand([]string{monitor.ContainerCLI(), "logs", "-f", "--tail", fmt.Sprintf("%d", lines), containerID})
+}
+
+// FollowComposeServiceLogs streams the logs of all replicas of a compose service
+func FollowComposeServiceLogs(projectName, service string, lines int) (*LogStream, error) {
+	if !monitor.IsValidComposeServiceName(service) {
+		return nil, fmt.Errorf("不正なサービス名です")
+	}
+
+	argv, err := monitor.BuildComposeCommand(projectName, "logs", "-f", "--no-color", "--tail", fmt.Sprintf("%d", lines), service)
+	if err != nil {
+		return nil, err
+	}
+	return followCommand(argv)
+}
+
+// FollowProcessLogs tails the newest log file of a project, following rotation and truncation
+func FollowProcessLogs(projectDir string, lines int) (*LogStream, error) {
+	logFile, err := findProcessLogFile(projectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(logFile)
+	if err != nil {
+		return nil, fmt.Errorf("ログ取得失敗: %v", err)
+	}
+	lastLines, offset, err := tailLines(file, lines)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("ログ取得失敗: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	out := make(chan Line, 256)
+	stream := &LogStream{Lines: out, Source: logFile, cancel: cancel}
+
+	go func() {
+		defer close(out)
+		for _, text := range lastLines {
+			if !sendLine(ctx, out, Line{Text: text}) {
+				file.Close()
+				return
+			}
+		}
+		if err := tailFile(ctx, logFile, file, offset, out, plainLine); err != nil && ctx.Err() == nil {
+			stream.setErr(err)
+		}
+	}()
+	return stream, nil
+}
+
+// followCommand runs a long-lived command and streams its stdout and stderr line by line
+func followCommand(argv []string) (*LogStream, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	out := make(chan Line, 256)
+	stream := &LogStream{Lines: out, Source: strings.Join(argv, " "), cancel: cancel}
+
+	wait, err := startCommand(ctx, argv, out, plainLine)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	go func() {
+		err := wait()
+		if ctx.Err() == nil {
+			if err != nil {
+				stream.setErr(fmt.Errorf("ログのフォローが終了しました: %v", err))
+			} else {
+				stream.setErr(fmt.Errorf("ログのフォローが終了しました"))
+			}
+		}
+		cancel()
+		close(out)
+	}()
+	return stream, nil
+}
+
+// startCommand starts argv and sends each line of stdout and stderr to out
+// 返り値の wait は出力を読み切ってコマンドの終了を待つ
+func startCommand(ctx context.Context, argv []string, out chan<- Line, parse lineParser) (func() error, error) {
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("ログのフォローを開始できません: %v", err)
+	}
+
+	// docker logs はコンテナの stderr を stderr に出すため両方を読む
+	var wg sync.WaitGroup
+	for _, reader := range []io.Reader{stdout, stderr} {
+		wg.Add(1)
+		go func(reader io.Reader) {
+			defer wg.Done()
+			scanLines(ctx, reader, out, parse)
+		}(reader)
+	}
+
+	return func() error {
+		wg.Wait()
+		return cmd.Wait()
+	}, nil
+}
+
+// scanLines sends each line of reader to out until EOF or cancellation
+func scanLines(ctx context.Context, reader io.Reader, out chan<- Line, parse lineParser) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), maxLineBytes)
+	for scanner.Scan() {
+		line, ok := parse(strings.TrimSuffix(scanner.Text(), "\r"))
+		if !ok {
+			continue
+		}
+		if !sendLine(ctx, out, line) {
+			return
+		}
+	}
+}
+
+// sendLine sends a line unless the stream has been stopped
+func sendLine(ctx context.Context, out chan<- Line, line Line) bool {
+	select {
+	case out <- line:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// tailFile sends every line appended to file after offset
+// ファイルが置き換えられた（ローテーション）場合は新しいファイルを先頭から、切り詰められた場合は先頭から読み直す
+func tailFile(ctx context.Context, path string, file *os.File, offset int64, out chan<- Line, parse lineParser) error {
+	defer func() { file.Close() }()
+
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	openedInfo, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	var pending []byte
+	buf := make([]byte, 32*1024)
+
+	send := func(text string) bool {
+		line, ok := parse(text)
+		if !ok {
+			return true
+		}
+		return sendLine(ctx, out, line)
+	}
+
+	// readAppended は追記された分を読み、改行で区切られた行を送る
+	readAppended := func() bool {
+		for {
+			n, err := file.Read(buf)
+			offset += int64(n)
+			pending = append(pending, buf[:n]...)
+			for {
+				i := bytes.IndexByte(pending, '\n')
+				if i < 0 {
+					break
+				}
+				text := strings.TrimSuffix(string(pending[:i]), "\r")
+				pending = pending[i+1:]
+				if !send(text) {
+					return false
+				}
+			}
+			if len(pending) >= maxLineBytes {
+				if !send(string(pending)) {
+					return false
+				}
+				pending = nil
+			}
+			if n == 0 || err != nil {
+				return true
+			}
+		}
+	}
+
+	ticker := time.NewTicker(filePollInterval)
+	defer ticker.Stop()
+
+	for {
+		if !readAppended() {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			// ローテーション中でファイルが一時的に存在しない
+			continue
+		}
+
+		switch {
+		case !os.SameFile(info, openedInfo):
+			// 古いファイルの残りを読み切ってから新しいファイルに切り替える
+			if !readAppended() {
+				return nil
+			}
+			if len(pending) > 0 {
+				if !send(string(pending)) {
+					return nil
+				}
+				pending = nil
+			}
+			newFile, err := os.Open(path)
+			if err != nil {
+				continue
+			}
+			file.Close()
+			file = newFile
+			openedInfo, _ = file.Stat()
+			offset = 0
+			if !send("--- ログファイルがローテーションされました ---") {
+				return nil
+			}
+
+		case info.Size() < offset:
+			if _, err := file.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+			offset = 0
+			pending = nil
+			if !send("--- ログファイルが切り詰められました ---") {
+				return nil
+			}
+		}
+	}
+}
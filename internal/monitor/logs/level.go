@@ -0,0 +1,182 @@
+package logs
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Level is the severity detected from a log line
+type Level int
+
+const (
+	LevelNone Level = iota // レベルを判定できない行
+	LevelDebug
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// String returns the level name
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	default:
+		return ""
+	}
+}
+
+// Line is a log line with its detected level
+type Line struct {
+	Text   string
+	Level  Level
+	JSON   bool      // 構造化ログ（JSON）の行
+	Source string    // 統合ログでの取得元（コンテナ名・プロセス名）
+	Time   time.Time // 統合ログで並べ替えに使う時刻（不明ならゼロ値）
+}
+
+var (
+	// level=error / "severity": "WARNING" のようなキーと値
+	levelKeyValueRegex = regexp.MustCompile(`(?i)\b(?:level|lvl|severity|loglevel)["']?\s*[=:]\s*["']?([a-z]+)`)
+	// 大文字のレベル表記（ERROR, [WARN], INFO: など）
+	levelWordRegex = regexp.MustCompile(`\b(FATAL|PANIC|CRITICAL|CRIT|ERROR|ERR|SEVERE|WARNING|WARN|INFO|NOTICE|DEBUG|TRACE)\b`)
+	// [error] / [warn] のような小文字の括弧表記（nginx など）
+	levelBracketRegex = regexp.MustCompile(`(?i)\[(emerg|alert|crit|error|warn|warning|notice|info|debug)\]`)
+	// klog / glog 形式（E1018 12:34:56.789 ...）、docker compose の "service | " の後ろも対象
+	levelKlogRegex = regexp.MustCompile(`^(?:\S+\s+\|\s+)?([EWIF])\d{4}\s`)
+	// スタックトレースや例外
+	errorPatternRegex = regexp.MustCompile(`Traceback \(most recent call last\)|\bpanic:|\bUnhandled|\bUncaught|\b[A-Z]\w*(?:Error|Exception)\b`)
+	// 警告
+	warnPatternRegex = regexp.MustCompile(`\b\w*Warning:|\bDeprecationWarning\b`)
+)
+
+// jsonLevelKeys are the keys that hold the level in structured logs
+var jsonLevelKeys = []string{"level", "lvl", "severity", "log.level", "levelname", "@l", "loglevel"}
+
+// ParseLine detects the level of a log line
+func ParseLine(text string) Line {
+	line := Line{Text: text}
+	line.detectLevel()
+	return line
+}
+
+// detectLevel fills Level and JSON from the text
+func (l *Line) detectLevel() {
+	if level, ok := jsonLevel(l.Text); ok {
+		l.Level, l.JSON = level, true
+		return
+	}
+	l.Level, l.JSON = DetectLevel(l.Text), false
+}
+
+// DetectLevel detects the level of a plain text log line
+func DetectLevel(text string) Level {
+	if match := levelKeyValueRegex.FindStringSubmatch(text); match != nil {
+		if level := levelFromName(match[1]); level != LevelNone {
+			return level
+		}
+	}
+	if match := levelWordRegex.FindStringSubmatch(text); match != nil {
+		return levelFromName(match[1])
+	}
+	if match := levelBracketRegex.FindStringSubmatch(text); match != nil {
+		return levelFromName(match[1])
+	}
+	if match := levelKlogRegex.FindStringSubmatch(text); match != nil {
+		return levelFromName(match[1])
+	}
+	if errorPatternRegex.MatchString(text) {
+		return LevelError
+	}
+	if warnPatternRegex.MatchString(text) {
+		return LevelWarn
+	}
+	return LevelNone
+}
+
+// levelFromName maps a level name (error, warning, E, ...) to a Level
+func levelFromName(name string) Level {
+	switch strings.ToLower(name) {
+	case "fatal", "panic", "critical", "crit", "emerg", "emergency", "alert", "error", "err", "severe", "e", "f":
+		return LevelError
+	case "warning", "warn", "w":
+		return LevelWarn
+	case "info", "information", "notice", "i":
+		return LevelInfo
+	case "debug", "trace", "verbose":
+		return LevelDebug
+	default:
+		return LevelNone
+	}
+}
+
+// jsonLevel reads the level field of a JSON structured log line
+// docker compose の "service | {...}" のようにJSONの前に接頭辞がある行も扱う
+func jsonLevel(text string) (Level, bool) {
+	_, object, ok := jsonObject(text)
+	if !ok {
+		return LevelNone, false
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(object), &fields); err != nil {
+		return LevelNone, false
+	}
+
+	for _, key := range jsonLevelKeys {
+		switch value := fields[key].(type) {
+		case string:
+			return levelFromName(value), true
+		case float64:
+			// pino / bunyan の数値レベル（10: trace, 20: debug, 30: info, 40: warn, 50: error, 60: fatal）
+			switch {
+			case value >= 50:
+				return LevelError, true
+			case value >= 40:
+				return LevelWarn, true
+			case value >= 30:
+				return LevelInfo, true
+			default:
+				return LevelDebug, true
+			}
+		}
+	}
+	return LevelNone, true
+}
+
+// jsonObject splits a line into the prefix and the JSON object part
+func jsonObject(text string) (string, string, bool) {
+	trimmed := strings.TrimSpace(text)
+	start := strings.IndexByte(trimmed, '{')
+	if start < 0 || !strings.HasSuffix(trimmed, "}") {
+		return "", "", false
+	}
+	return trimmed[:start], trimmed[start:], true
+}
+
+// PrettyJSON indents a JSON structured log line (JSONでない場合はfalse)
+func PrettyJSON(text string) ([]string, bool) {
+	prefix, object, ok := jsonObject(text)
+	if !ok {
+		return nil, false
+	}
+
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(object), "", "  "); err != nil {
+		return nil, false
+	}
+
+	lines := strings.Split(buf.String(), "\n")
+	// 接頭辞（compose のサービス名など）は1行目に残す
+	lines[0] = prefix + lines[0]
+	return lines, true
+}
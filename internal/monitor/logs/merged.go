@@ -0,0 +1,239 @@
+package logs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
+)
+
+// MergedSource is one container or process shown in a merged log view
+type MergedSource struct {
+	Name        string // 表示名（サービス名・コンテナ名・プロジェクト名）
+	ContainerID string // コンテナの場合
+	ProjectDir  string // プロセスの場合（ログファイルを探すディレクトリ）
+}
+
+// leadingTimeRegex matches a timestamp at the start of an application log line
+// 例: 2024-01-02T15:04:05.123Z / 2024-01-02 15:04:05,123 / [2024-01-02 15:04:05]
+var leadingTimeRegex = regexp.MustCompile(`^\[?(\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(?:[.,]\d+)?(?:Z|[+-]\d{2}:?\d{2})?)`)
+
+// leadingTimeLayouts are tried in order to parse leadingTimeRegex matches
+var leadingTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// FollowMerged streams the logs of several containers and processes as one timestamp-ordered view
+// 過去のN行は時刻順に並べてから送り、以降は届いた順に追記する
+func FollowMerged(sources []MergedSource, lines int) (*LogStream, error) {
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("ログを表示する対象がありません")
+	}
+	for _, source := range sources {
+		if source.ContainerID != "" && !monitor.IsValidContainerID(source.ContainerID) {
+			return nil, fmt.Errorf("不正なコンテナIDです: %s", source.Name)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	out := make(chan Line, 256)
+	names := make([]string, len(sources))
+	for i, source := range sources {
+		names[i] = source.Name
+	}
+	stream := &LogStream{Lines: out, Source: strings.Join(names, ", "), cancel: cancel}
+
+	go func() {
+		defer close(out)
+
+		// 過去ログより後の行だけをフォローするための基準時刻
+		since := time.Now()
+
+		histories := make([][]Line, len(sources))
+		live := make(chan Line, 1024)
+		var historyWG, liveWG sync.WaitGroup
+		for i, source := range sources {
+			historyWG.Add(1)
+			liveWG.Add(1)
+			go func(i int, source MergedSource) {
+				defer liveWG.Done()
+				followSource(ctx, source, lines, since, &histories[i], historyWG.Done, live)
+			}(i, source)
+		}
+		go func() {
+			liveWG.Wait()
+			close(live)
+		}()
+
+		historyWG.Wait()
+		for _, line := range mergeByTime(histories) {
+			if !sendLine(ctx, out, line) {
+				return
+			}
+		}
+
+		for line := range live {
+			if !sendLine(ctx, out, line) {
+				return
+			}
+		}
+		if ctx.Err() == nil {
+			stream.setErr(fmt.Errorf("すべてのログのフォローが終了しました"))
+		}
+	}()
+	return stream, nil
+}
+
+// followSource reads the history of a source, calls historyDone, then follows new lines into live
+func followSource(ctx context.Context, source MergedSource, lines int, since time.Time, history *[]Line, historyDone func(), live chan<- Line) {
+	notice := func(format string, args ...interface{}) Line {
+		return Line{Text: "--- " + fmt.Sprintf(format, args...) + " ---", Source: source.Name, Time: time.Now()}
+	}
+
+	if source.ContainerID != "" {
+		past, err := GetContainerLogLines(source.ContainerID, source.Name, lines)
+		if err != nil {
+			*history = []Line{notice("ログを取得できません: %v", err)}
+			historyDone()
+			return
+		}
+		*history = past
+
+		// 過去ログと重なる行（--since は秒単位で丸められる）は捨てる
+		var last time.Time
+		if len(past) > 0 {
+			last = past[len(past)-1].Time
+		}
+		historyDone()
+
+		argv := []string{monitor.ContainerCLI(), "logs", "-f", "--timestamps", "--since", fmt.Sprintf("%d", since.Unix()), source.ContainerID}
+		wait, err := startCommand(ctx, argv, live, func(text string) (Line, bool) {
+			line := parseTimestampedLine(source.Name, text)
+			return line, last.IsZero() || line.Time.After(last)
+		})
+		if err != nil {
+			sendLine(ctx, live, notice("%v", err))
+			return
+		}
+		if err := wait(); ctx.Err() == nil {
+			if err != nil {
+				sendLine(ctx, live, notice("ログのフォローが終了しました: %v", err))
+			} else {
+				sendLine(ctx, live, notice("ログのフォローが終了しました"))
+			}
+		}
+		return
+	}
+
+	// プロセスはログファイルを読む（時刻のない行は直前の行の時刻を引き継ぐ）
+	logFile, err := findProcessLogFile(source.ProjectDir)
+	if err != nil {
+		*history = []Line{notice("%v", err)}
+		historyDone()
+		return
+	}
+	file, err := os.Open(logFile)
+	if err != nil {
+		*history = []Line{notice("ログ取得失敗: %v", err)}
+		historyDone()
+		return
+	}
+	past, offset, err := tailLines(file, lines)
+	if err != nil {
+		file.Close()
+		*history = []Line{notice("ログ取得失敗: %v", err)}
+		historyDone()
+		return
+	}
+	*history = processHistory(source.Name, past, file)
+	historyDone()
+
+	if err := tailFile(ctx, logFile, file, offset, live, func(text string) (Line, bool) {
+		line := Line{Text: text, Source: source.Name, Time: time.Now()}
+		if t, ok := parseLeadingTime(text); ok {
+			line.Time = t
+		}
+		return line, true
+	}); err != nil && ctx.Err() == nil {
+		sendLine(ctx, live, notice("ログのフォローが終了しました: %v", err))
+	}
+}
+
+// processHistory assigns times to the past lines of a process log file
+// 時刻のない行は直前の行の時刻、先頭から時刻がない行は最初に見つかった時刻（なければファイルの更新日時）を使う
+func processHistory(source string, texts []string, file *os.File) []Line {
+	lines := make([]Line, len(texts))
+	var fallback time.Time
+	for _, text := range texts {
+		if t, ok := parseLeadingTime(text); ok {
+			fallback = t
+			break
+		}
+	}
+	if fallback.IsZero() {
+		if info, err := file.Stat(); err == nil {
+			fallback = info.ModTime()
+		}
+	}
+
+	current := fallback
+	for i, text := range texts {
+		if t, ok := parseLeadingTime(text); ok {
+			current = t
+		}
+		lines[i] = Line{Text: text, Source: source, Time: current}
+	}
+	return lines
+}
+
+// mergeByTime merges lines of several sources in timestamp order (同時刻は元の順序を保つ)
+func mergeByTime(histories [][]Line) []Line {
+	var merged []Line
+	for _, history := range histories {
+		merged = append(merged, history...)
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Time.Before(merged[j].Time)
+	})
+	return merged
+}
+
+// parseTimestampedLine splits the RFC3339 timestamp added by "docker logs --timestamps"
+func parseTimestampedLine(source, text string) Line {
+	line := Line{Text: text, Source: source, Time: time.Now()}
+	stamp, rest, found := strings.Cut(text, " ")
+	if !found {
+		return line
+	}
+	if t, err := time.Parse(time.RFC3339Nano, stamp); err == nil {
+		line.Text = rest
+		line.Time = t
+	}
+	return line
+}
+
+// parseLeadingTime parses a timestamp at the start of a log line (タイムゾーンがなければローカル時刻)
+func parseLeadingTime(text string) (time.Time, bool) {
+	match := leadingTimeRegex.FindStringSubmatch(text)
+	if match == nil {
+		return time.Time{}, false
+	}
+	value := strings.Replace(match[1], ",", ".", 1)
+	for _, layout := range leadingTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -1,16 +1,35 @@
 package logs
 
 import (
-	"bufio"
+	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
 )
 
 // GetProcessLogs returns the last N lines of process logs from project directory
 func GetProcessLogs(projectDir string, lines int) (string, error) {
+	logFile, err := findProcessLogFile(projectDir)
+	if err != nil {
+		return "", err
+	}
+
+	// Goでファイルの最後のN行を読み込み
+	content, err := readLastLines(logFile, lines)
+	if err != nil {
+		return "", fmt.Errorf("ログ取得失敗: %v", err)
+	}
+
+	return content, nil
+}
+
+// findProcessLogFile returns the newest log file in the project directory
+func findProcessLogFile(projectDir string) (string, error) {
 	if projectDir == "" {
 		return "", fmt.Errorf("プロジェクトディレクトリが見つかりません")
 	}
@@ -20,6 +39,13 @@ func GetProcessLogs(projectDir string, lines int) (string, error) {
 		return "", fmt.Errorf("不正なディレクトリパスです")
 	}
 
+	// devmon run で起動したプロセスは出力を保存したファイルを使う
+	if process, ok := monitor.FindManagedProcess(projectDir); ok {
+		if _, err := os.Stat(process.LogFile); err == nil {
+			return process.LogFile, nil
+		}
+	}
+
 	// 一般的なログファイルのパターンを試す
 	logPatterns := []string{
 		filepath.Join(projectDir, "logs", "*.log"),
@@ -34,9 +60,6 @@ func GetProcessLogs(projectDir string, lines int) (string, error) {
 		filepath.Join(projectDir, "yarn-error.log"),
 	}
 
-	// 最初に見つかったログファイルを使用
-	var logFile string
-
 	// パターンでGlob検索
 	for _, pattern := range logPatterns {
 		matches, err := filepath.Glob(pattern)
@@ -45,34 +68,20 @@ func GetProcessLogs(projectDir string, lines int) (string, error) {
 		}
 		if len(matches) > 0 {
 			// 更新日時でソート（最新ファイルを優先）
-			logFile = getNewestFile(matches)
-			if logFile != "" {
-				break
+			if logFile := getNewestFile(matches); logFile != "" {
+				return logFile, nil
 			}
 		}
 	}
 
 	// パターンで見つからない場合、固定ファイル名を確認
-	if logFile == "" {
-		for _, file := range fixedLogFiles {
-			if _, err := os.Stat(file); err == nil {
-				logFile = file
-				break
-			}
+	for _, file := range fixedLogFiles {
+		if _, err := os.Stat(file); err == nil {
+			return file, nil
 		}
 	}
 
-	if logFile == "" {
-		return "", fmt.Errorf("ログファイルが見つかりません")
-	}
-
-	// Goでファイルの最後のN行を読み込み
-	content, err := readLastLines(logFile, lines)
-	if err != nil {
-		return "", fmt.Errorf("ログ取得失敗: %v", err)
-	}
-
-	return content, nil
+	return "", fmt.Errorf("ログファイルが見つかりません")
 }
 
 // getNewestFile returns the newest file from the list based on modification time
@@ -118,23 +127,51 @@ func readLastLines(filePath string, n int) (string, error) {
 	}
 	defer file.Close()
 
-	var allLines []string
-	scanner := bufio.NewScanner(file)
+	lastLines, _, err := tailLines(file, n)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(lastLines, "\n"), nil
+}
 
-	for scanner.Scan() {
-		allLines = append(allLines, scanner.Text())
+// tailLines reads the last N lines by scanning backwards from the end of the file
+// ファイル全体を読み込まないため、巨大なログでもメモリを使わない。読み終えた位置（ファイル末尾）も返す
+func tailLines(file *os.File, n int) ([]string, int64, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return nil, 0, err
 	}
+	end := info.Size()
 
-	if err := scanner.Err(); err != nil {
-		return "", err
+	const chunkSize = 64 * 1024
+	var data []byte
+	offset := end
+	for offset > 0 && bytes.Count(data, []byte("\n")) <= n {
+		size := int64(chunkSize)
+		if offset < size {
+			size = offset
+		}
+		offset -= size
+
+		chunk := make([]byte, size)
+		if _, err := file.ReadAt(chunk, offset); err != nil && err != io.EOF {
+			return nil, 0, err
+		}
+		data = append(chunk, data...)
 	}
 
-	// 最後のN行を取得
-	startIndex := 0
-	if len(allLines) > n {
-		startIndex = len(allLines) - n
+	text := strings.TrimSuffix(string(data), "\n")
+	if text == "" {
+		return nil, end, nil
 	}
+	lines := strings.Split(text, "\n")
 
-	lastLines := allLines[startIndex:]
-	return strings.Join(lastLines, "\n"), nil
+	// 先頭はチャンク境界で途切れた行の可能性があるため、N行に切り詰める
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+	return lines, end, nil
 }
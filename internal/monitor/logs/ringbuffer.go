@@ -0,0 +1,59 @@
+package logs
+
+// RingBuffer keeps the most recent lines up to a fixed capacity
+// 追加時にログレベルを判定しておき、描画のたびに判定し直さないようにする
+type RingBuffer struct {
+	lines   []Line
+	start   int // 最も古い行の位置
+	size    int
+	dropped int // 容量を超えて捨てた行数の累計
+}
+
+// NewRingBuffer creates a ring buffer that holds at most capacity lines
+func NewRingBuffer(capacity int) *RingBuffer {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &RingBuffer{lines: make([]Line, capacity)}
+}
+
+// Append adds lines, dropping the oldest ones when the buffer is full
+func (b *RingBuffer) Append(lines ...Line) {
+	capacity := len(b.lines)
+	for _, line := range lines {
+		line.detectLevel()
+		if b.size < capacity {
+			b.lines[(b.start+b.size)%capacity] = line
+			b.size++
+			continue
+		}
+		b.lines[b.start] = line
+		b.start = (b.start + 1) % capacity
+		b.dropped++
+	}
+}
+
+// Lines returns a copy of the buffered lines from oldest to newest
+func (b *RingBuffer) Lines() []Line {
+	lines := make([]Line, b.size)
+	for i := 0; i < b.size; i++ {
+		lines[i] = b.lines[(b.start+i)%len(b.lines)]
+	}
+	return lines
+}
+
+// Len returns the number of buffered lines
+func (b *RingBuffer) Len() int {
+	return b.size
+}
+
+// Appended returns how many lines have been appended in total
+// 表示内容を作り直す必要があるかの判定に使う
+func (b *RingBuffer) Appended() int {
+	return b.size + b.dropped
+}
+
+// Dropped returns how many lines have been discarded because the buffer was full
+func (b *RingBuffer) Dropped() int {
+	return b.dropped
+}
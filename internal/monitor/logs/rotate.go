@@ -0,0 +1,139 @@
+package logs
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// RotatingWriter appends to a log file and rotates it to .1, .2, ... when it grows too large
+// 複数のゴルーチン（stdoutとstderr）から同時に書き込める
+type RotatingWriter struct {
+	path     string
+	maxBytes int64
+	backups  int
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// NewRotatingWriter opens path for appending, creating its directory if needed
+func NewRotatingWriter(path string, maxBytes int64, backups int) (*RotatingWriter, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	w := &RotatingWriter{path: path, maxBytes: maxBytes, backups: backups}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Write writes p, rotating the file first if p would exceed the size limit
+func (w *RotatingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return 0, fmt.Errorf("ログファイルは閉じられています")
+	}
+	if w.size > 0 && w.size+int64(len(p)) > w.maxBytes {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Close closes the current log file
+func (w *RotatingWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+func (w *RotatingWriter) open() error {
+	file, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	w.file = file
+	w.size = info.Size()
+	return nil
+}
+
+// rotate renames app.log → app.log.1 → app.log.2 ... and opens a new app.log
+// ログビューは os.SameFile で置き換わりを検知して新しいファイルを読み始める
+func (w *RotatingWriter) rotate() error {
+	w.file.Close()
+	w.file = nil
+
+	if w.backups > 0 {
+		os.Remove(fmt.Sprintf("%s.%d", w.path, w.backups))
+		for i := w.backups - 1; i >= 1; i-- {
+			os.Rename(fmt.Sprintf("%s.%d", w.path, i), fmt.Sprintf("%s.%d", w.path, i+1))
+		}
+		if err := os.Rename(w.path, w.path+".1"); err != nil {
+			return err
+		}
+	} else if err := os.Remove(w.path); err != nil {
+		return err
+	}
+	return w.open()
+}
+
+// LineWriter buffers writes and passes only complete lines to the underlying writer
+// stdoutとstderrを同じファイルに書くとき、行の途中で混ざらないようにする
+type LineWriter struct {
+	out     *RotatingWriter
+	pending []byte
+}
+
+// NewLineWriter creates a LineWriter that writes to out
+func NewLineWriter(out *RotatingWriter) *LineWriter {
+	return &LineWriter{out: out}
+}
+
+// Write buffers p and writes every complete line
+func (w *LineWriter) Write(p []byte) (int, error) {
+	w.pending = append(w.pending, p...)
+	i := bytes.LastIndexByte(w.pending, '\n')
+	if i < 0 {
+		if len(w.pending) < maxLineBytes {
+			return len(p), nil
+		}
+		// 改行のない巨大な出力は1行として書き出す
+		i = len(w.pending) - 1
+	}
+	if _, err := w.out.Write(w.pending[:i+1]); err != nil {
+		return 0, err
+	}
+	w.pending = append(w.pending[:0], w.pending[i+1:]...)
+	return len(p), nil
+}
+
+// Flush writes the remaining partial line, terminated with a newline
+func (w *LineWriter) Flush() error {
+	if len(w.pending) == 0 {
+		return nil
+	}
+	_, err := w.out.Write(append(w.pending, '\n'))
+	w.pending = nil
+	return err
+}
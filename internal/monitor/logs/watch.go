@@ -0,0 +1,261 @@
+package logs
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
+)
+
+// WatchInitialLines は監視を始めたときに読む過去の行数
+const WatchInitialLines = 200
+
+// watchMaxLines は1回の収集で1つのソースから読む最大行数
+const watchMaxLines = 2000
+
+// watchMaxReadBytes は1回の収集でプロセスのログファイルから読む最大バイト数（超えた分は読み飛ばす）
+const watchMaxReadBytes = 1024 * 1024
+
+// WatchSource is a container or process whose logs are collected in the background
+type WatchSource struct {
+	MergedSource
+	Group string // 左メニューの項目名（Docker / Node.js / Python など）
+}
+
+// Key identifies the source across collections
+func (s WatchSource) Key() string {
+	if s.ContainerID != "" {
+		return "container:" + s.ContainerID
+	}
+	return "dir:" + s.ProjectDir
+}
+
+// LogBatch is the lines collected from one source
+type LogBatch struct {
+	Source  WatchSource
+	Lines   []Line
+	Initial bool // 監視を始めて最初の収集（過去ログを含むため新規エラーの判定には使わない）
+}
+
+// watchCursor は前回どこまで読んだかを保持する
+type watchCursor struct {
+	since  time.Time   // コンテナ: 最後に読んだ行の時刻
+	path   string      // プロセス: 読んでいるログファイル
+	info   os.FileInfo // プロセス: ローテーションの検知用
+	offset int64
+}
+
+// Collector reads only the log lines added since the previous collection
+type Collector struct {
+	mu      sync.Mutex
+	cursors map[string]*watchCursor
+}
+
+// NewCollector creates a collector with no history
+func NewCollector() *Collector {
+	return &Collector{cursors: make(map[string]*watchCursor)}
+}
+
+// DiscoverWatchSources lists running containers and dev processes that have logs to collect
+// コンテナとプロセスの一覧は呼び出し側で取得したもの（UIのキャッシュなど）を使い、ここでは検出し直さない
+func DiscoverWatchSources(containers []monitor.DockerContainer, nodes []monitor.NodeProcess, pythons []monitor.PythonProcess) []WatchSource {
+	var sources []WatchSource
+	names := make(map[string]int)
+	add := func(source WatchSource) {
+		// 同じ名前のソースは区別できるよう番号を付ける
+		names[source.Name]++
+		if n := names[source.Name]; n > 1 {
+			source.Name = fmt.Sprintf("%s#%d", source.Name, n)
+		}
+		sources = append(sources, source)
+	}
+
+	for _, c := range containers {
+		if c.Status == "running" && monitor.IsValidContainerID(c.ID) {
+			add(WatchSource{MergedSource: MergedSource{Name: c.Name, ContainerID: c.ID}, Group: "Docker"})
+		}
+	}
+
+	// プロセスはログファイルが見つかるプロジェクトだけ（同じディレクトリは1つにまとめる）
+	dirs := make(map[string]bool)
+	addProcess := func(name, dir, group string) {
+		if dir == "" || dirs[dir] {
+			return
+		}
+		if _, err := findProcessLogFile(dir); err != nil {
+			return
+		}
+		dirs[dir] = true
+		if name == "" {
+			name = filepath.Base(dir)
+		}
+		add(WatchSource{MergedSource: MergedSource{Name: name, ProjectDir: dir}, Group: group})
+	}
+	for _, p := range nodes {
+		addProcess(p.ProjectName, p.ProjectDir, "Node.js")
+	}
+	for _, p := range pythons {
+		addProcess(filepath.Base(p.ProjectDir), p.ProjectDir, "Python")
+	}
+	for _, p := range monitor.KnownManagedProcesses() {
+		if p.Running() {
+			addProcess(p.Name, p.Dir, "")
+		}
+	}
+	return sources
+}
+
+// Collect reads the new lines of each source
+// 初めて見るソースは直近の行を読み、以降は前回の続きから読む。なくなったソースの状態は捨てる
+func (c *Collector) Collect(sources []WatchSource) []LogBatch {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	active := make(map[string]bool)
+	var batches []LogBatch
+	for _, source := range sources {
+		key := source.Key()
+		active[key] = true
+
+		cursor, known := c.cursors[key]
+		if !known {
+			cursor = &watchCursor{}
+		}
+
+		var lines []Line
+		var err error
+		if source.ContainerID != "" {
+			lines, err = collectContainer(source, cursor, !known)
+		} else {
+			lines, err = collectProcess(source, cursor, !known)
+		}
+		if err != nil {
+			// 取得できなかったソースは次回また最初から読む
+			delete(c.cursors, key)
+			continue
+		}
+		c.cursors[key] = cursor
+
+		if len(lines) > 0 || !known {
+			batches = append(batches, LogBatch{Source: source, Lines: lines, Initial: !known})
+		}
+	}
+
+	for key := range c.cursors {
+		if !active[key] {
+			delete(c.cursors, key)
+		}
+	}
+	return batches
+}
+
+// collectContainer reads container log lines written after the cursor
+func collectContainer(source WatchSource, cursor *watchCursor, initial bool) ([]Line, error) {
+	var lines []Line
+	var err error
+	if initial {
+		// 過去ログがなければ取得を始めた時刻から読む
+		cursor.since = time.Now()
+		lines, err = GetContainerLogLines(source.ContainerID, source.Name, WatchInitialLines)
+		if len(lines) > 0 {
+			cursor.since = lines[len(lines)-1].Time
+		}
+	} else {
+		lines, err = GetContainerLogLinesSince(source.ContainerID, source.Name, cursor.since, watchMaxLines)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range lines {
+		if line.Time.After(cursor.since) {
+			cursor.since = line.Time
+		}
+	}
+	return detectLevels(lines), nil
+}
+
+// collectProcess reads the lines appended to a process log file after the cursor
+func collectProcess(source WatchSource, cursor *watchCursor, initial bool) ([]Line, error) {
+	path, err := findProcessLogFile(source.ProjectDir)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	var texts []string
+	if initial || path != cursor.path {
+		texts, cursor.offset, err = tailLines(file, WatchInitialLines)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// ローテーション・切り詰めされたファイルは先頭から読む
+		if !os.SameFile(info, cursor.info) || info.Size() < cursor.offset {
+			cursor.offset = 0
+		}
+		if info.Size()-cursor.offset > watchMaxReadBytes {
+			cursor.offset = info.Size() - watchMaxReadBytes
+		}
+		texts, cursor.offset, err = readAppendedLines(file, cursor.offset)
+		if err != nil {
+			return nil, err
+		}
+	}
+	cursor.path = path
+	cursor.info = info
+
+	lines := make([]Line, 0, len(texts))
+	now := time.Now()
+	for _, text := range texts {
+		line := Line{Text: text, Source: source.Name, Time: now}
+		if t, ok := parseLeadingTime(text); ok {
+			line.Time = t
+		}
+		lines = append(lines, line)
+	}
+	return detectLevels(lines), nil
+}
+
+// readAppendedLines reads the complete lines after offset and returns the offset after the last newline
+// 書き込み途中の行は次回に読む
+func readAppendedLines(file *os.File, offset int64) ([]string, int64, error) {
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return nil, offset, err
+	}
+	data, err := io.ReadAll(io.LimitReader(file, watchMaxReadBytes))
+	if err != nil {
+		return nil, offset, err
+	}
+
+	end := strings.LastIndexByte(string(data), '\n')
+	if end < 0 {
+		return nil, offset, nil
+	}
+	var texts []string
+	for _, text := range strings.Split(string(data[:end]), "\n") {
+		texts = append(texts, strings.TrimSuffix(text, "\r"))
+	}
+	return texts, offset + int64(end) + 1, nil
+}
+
+// detectLevels sets the log level of each line
+func detectLevels(lines []Line) []Line {
+	for i := range lines {
+		lines[i].detectLevel()
+	}
+	return lines
+}
@@ -0,0 +1,332 @@
+package monitor
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ManagedProcess is a dev process started with "devmon run" whose stdout/stderr is captured to a log file
+type ManagedProcess struct {
+	Key          string   // 起動ディレクトリとコマンドから決まる識別子（再起動しても変わらない）
+	Name         string   // 表示名
+	Dir          string   // 起動ディレクトリ
+	Command      []string // 実行したコマンドと引数
+	PID          int
+	ProcessStart string // OSが記録したプロセスの開始時刻（PIDが別のプロセスに再利用されていないかの確認に使う）
+	OwnGroup     bool   // 独立したプロセスグループで起動したか（子プロセスごと停止できる）
+	LogFile      string // 出力を保存しているファイル
+	StartedAt    time.Time
+	ExitedAt     time.Time // 実行中はゼロ値
+	ExitCode     int
+}
+
+// Running reports whether the process has not been recorded as exited
+func (p ManagedProcess) Running() bool {
+	return p.ExitedAt.IsZero()
+}
+
+// CommandLine returns the command as it should be shown to the user
+func (p ManagedProcess) CommandLine() string {
+	return FormatManagedCommand(p.Command)
+}
+
+// ManagedLogMaxBytes はローテーションするまでのログファイルの最大サイズ
+const ManagedLogMaxBytes = 10 * 1024 * 1024
+
+// ManagedLogBackups は残しておくローテーション済みファイルの数（.log.1 〜 .log.N）
+const ManagedLogBackups = 3
+
+// managedRestartTimeout は再起動時にプロセスの終了を待つ時間（過ぎたらSIGKILL）
+const managedRestartTimeout = 10 * time.Second
+
+// managedNamePattern はログファイル名に使えない文字
+var managedNamePattern = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// shellMetaChars を含むコマンドは sh -c で実行する
+const shellMetaChars = "|&;<>()$`'\"*?~{}[]\\"
+
+// 起動したプロセス（DBから読み込んで保持する）
+var (
+	knownManagedProcessesMu sync.RWMutex
+	knownManagedProcesses   = make(map[string]ManagedProcess)
+)
+
+// NewManagedProcess describes a command to be run in dir and where its output is stored
+func NewManagedProcess(name, dir string, command []string) (ManagedProcess, error) {
+	if len(command) == 0 || command[0] == "" {
+		return ManagedProcess{}, fmt.Errorf("実行するコマンドを指定してください")
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return ManagedProcess{}, err
+	}
+	if info, err := os.Stat(absDir); err != nil || !info.IsDir() {
+		return ManagedProcess{}, fmt.Errorf("ディレクトリが見つかりません: %s", dir)
+	}
+
+	if name == "" {
+		name = filepath.Base(absDir)
+	}
+	key := ManagedProcessKey(absDir, command)
+
+	logDir, err := ManagedLogDir()
+	if err != nil {
+		return ManagedProcess{}, err
+	}
+	logName := strings.Trim(managedNamePattern.ReplaceAllString(name, "_"), "_")
+	if logName == "" {
+		logName = "process"
+	}
+
+	return ManagedProcess{
+		Key:     key,
+		Name:    name,
+		Dir:     absDir,
+		Command: command,
+		LogFile: filepath.Join(logDir, logName+"-"+key+".log"),
+	}, nil
+}
+
+// ManagedProcessKey identifies a command run in a directory
+func ManagedProcessKey(dir string, command []string) string {
+	sum := sha1.Sum([]byte(dir + "\x00" + strings.Join(command, "\x00")))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// ManagedLogDir returns the directory where captured process output is stored (~/.devmon/proc-logs)
+func ManagedLogDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".devmon", "proc-logs"), nil
+}
+
+// ParseManagedCommand splits a command line typed by the user
+// シェルの構文（パイプ・リダイレクト・クォート・変数など）を含む場合は sh -c で実行する
+func ParseManagedCommand(input string) []string {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil
+	}
+	if strings.ContainsAny(input, shellMetaChars) {
+		return []string{"sh", "-c", input}
+	}
+	return strings.Fields(input)
+}
+
+// FormatManagedCommand joins a command for display (ParseManagedCommand の逆変換)
+func FormatManagedCommand(command []string) string {
+	if len(command) == 3 && command[0] == "sh" && command[1] == "-c" {
+		return command[2]
+	}
+	parts := make([]string, len(command))
+	for i, arg := range command {
+		if arg == "" || strings.ContainsAny(arg, " \t"+shellMetaChars) {
+			arg = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+		parts[i] = arg
+	}
+	return strings.Join(parts, " ")
+}
+
+// RememberManagedProcesses replaces the known processes started with "devmon run"
+func RememberManagedProcesses(processes []ManagedProcess) {
+	knownManagedProcessesMu.Lock()
+	defer knownManagedProcessesMu.Unlock()
+
+	knownManagedProcesses = make(map[string]ManagedProcess, len(processes))
+	for _, p := range processes {
+		if p.Key == "" {
+			continue
+		}
+		knownManagedProcesses[p.Key] = p
+	}
+}
+
+// KnownManagedProcesses returns the processes started with "devmon run", newest first
+func KnownManagedProcesses() []ManagedProcess {
+	knownManagedProcessesMu.RLock()
+	defer knownManagedProcessesMu.RUnlock()
+
+	processes := make([]ManagedProcess, 0, len(knownManagedProcesses))
+	for _, p := range knownManagedProcesses {
+		processes = append(processes, p)
+	}
+	sort.Slice(processes, func(i, j int) bool {
+		return processes[i].StartedAt.After(processes[j].StartedAt)
+	})
+	return processes
+}
+
+// FindManagedProcess returns the process started with "devmon run" in dir
+// 実行中のものを優先し、なければ最後に起動したものを返す
+func FindManagedProcess(dir string) (ManagedProcess, bool) {
+	if dir == "" {
+		return ManagedProcess{}, false
+	}
+	dir = filepath.Clean(dir)
+
+	var found ManagedProcess
+	ok := false
+	for _, p := range KnownManagedProcesses() {
+		if p.Dir != dir {
+			continue
+		}
+		if p.Running() {
+			return p, true
+		}
+		if !ok {
+			found, ok = p, true
+		}
+	}
+	return found, ok
+}
+
+// LookupManagedProcess returns a known process by its key
+func LookupManagedProcess(key string) (ManagedProcess, bool) {
+	knownManagedProcessesMu.RLock()
+	defer knownManagedProcessesMu.RUnlock()
+	p, ok := knownManagedProcesses[key]
+	return p, ok
+}
+
+// ManagedCommandsForDir returns the distinct commands previously run in dir, newest first
+func ManagedCommandsForDir(dir string) []string {
+	dir = filepath.Clean(dir)
+	seen := make(map[string]bool)
+	var commands []string
+	for _, p := range KnownManagedProcesses() {
+		line := p.CommandLine()
+		if p.Dir != dir || seen[line] {
+			continue
+		}
+		seen[line] = true
+		commands = append(commands, line)
+	}
+	return commands
+}
+
+// LaunchManagedProcess starts "devmon run" in the background so the process outlives the TUI
+func LaunchManagedProcess(name, dir string, command []string) (ManagedProcess, error) {
+	process, err := NewManagedProcess(name, dir, command)
+	if err != nil {
+		return ManagedProcess{}, err
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return ManagedProcess{}, fmt.Errorf("devmonの実行ファイルが見つかりません: %v", err)
+	}
+
+	args := []string{"run", "--detach", "--name", process.Name, "--dir", process.Dir, "--"}
+	cmd := exec.Command(executable, append(args, command...)...)
+	cmd.Dir = process.Dir
+	// 端末から切り離し、TUIを終了しても動き続けるようにする
+	detachProcess(cmd)
+	if err := cmd.Start(); err != nil {
+		return ManagedProcess{}, fmt.Errorf("起動失敗: %v", err)
+	}
+	// 終了したラッパーがゾンビとして残らないように回収する
+	go cmd.Wait()
+
+	process.StartedAt = time.Now()
+	return process, nil
+}
+
+// RestartManagedProcess stops a process started with "devmon run" and starts the same command again
+func RestartManagedProcess(key string) CommandResult {
+	process, ok := LookupManagedProcess(key)
+	if !ok {
+		return CommandResult{Success: false, Message: "devmon run で起動したプロセスが見つかりません"}
+	}
+
+	// 記録したPIDが別のプロセスに再利用されている場合は停止しない（終了の記録は呼び出し側で行う）
+	if process.Running() && ManagedProcessAlive(process) {
+		if err := stopManagedProcess(process); err != nil {
+			return CommandResult{Success: false, Message: fmt.Sprintf("停止失敗: %v", err)}
+		}
+	}
+
+	restarted, err := LaunchManagedProcess(process.Name, process.Dir, process.Command)
+	if err != nil {
+		return CommandResult{Success: false, Message: err.Error()}
+	}
+	return CommandResult{
+		Success: true,
+		Message: fmt.Sprintf("%s を再起動しました: %s（ログ: %s）", restarted.Name, restarted.CommandLine(), restarted.LogFile),
+	}
+}
+
+// ManagedProcessAlive reports whether the recorded PID still belongs to the process started with "devmon run"
+// ラッパーが異常終了した場合やマシンを再起動した場合は終了が記録されず、PIDが別のプロセスに再利用されていることがある
+// 開始時刻を取得できなかった（確認できない）プロセスも、誤って停止しないよう終了したものとして扱う
+func ManagedProcessAlive(process ManagedProcess) bool {
+	if process.PID <= 0 || !isProcessAlive(strconv.Itoa(process.PID)) {
+		return false
+	}
+	return process.ProcessStart != "" && ProcessStartTime(process.PID) == process.ProcessStart
+}
+
+// ProcessStartTime returns when the OS started the process, as an opaque string ("" if unknown)
+// Linux は /proc/<pid>/stat の starttime、それ以外は ps -o lstart= を使う
+func ProcessStartTime(pid int) string {
+	if data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid)); err == nil {
+		// プロセス名は空白や ) を含むことがあるため、最後の ) の後から数える（starttime は22番目の項目）
+		if i := strings.LastIndexByte(string(data), ')'); i >= 0 {
+			if fields := strings.Fields(string(data[i+1:])); len(fields) > 19 {
+				return fields[19]
+			}
+		}
+		return ""
+	}
+
+	output, err := exec.Command("ps", "-o", "lstart=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// stopManagedProcess sends SIGTERM and waits for the process to exit, then SIGKILL
+func stopManagedProcess(process ManagedProcess) error {
+	pid := strconv.Itoa(process.PID)
+	if !ManagedProcessAlive(process) {
+		return nil
+	}
+
+	// 独立したプロセスグループなら sh -c や npm が起動した子プロセスもまとめて停止する
+	target := pid
+	if process.OwnGroup {
+		target = "-" + pid
+	}
+	if output, err := exec.Command("kill", "-s", "TERM", "--", target).CombinedOutput(); err != nil && isProcessAlive(pid) {
+		return fmt.Errorf("%s", strings.TrimSpace(string(output)))
+	}
+
+	deadline := time.Now().Add(managedRestartTimeout)
+	for time.Now().Before(deadline) {
+		if !isProcessAlive(pid) {
+			return nil
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	exec.Command("kill", "-s", "KILL", "--", target).Run()
+	return nil
+}
+
+// isProcessAlive reports whether a process with the PID exists
+func isProcessAlive(pid string) bool {
+	return exec.Command("kill", "-0", pid).Run() == nil
+}
@@ -0,0 +1,52 @@
+//go:build !windows
+
+package monitor
+
+import (
+	"os/exec"
+	"strconv"
+	"testing"
+)
+
+func TestManagedProcessAlive(t *testing.T) {
+	cmd := exec.Command("sleep", "30")
+	if err := cmd.Start(); err != nil {
+		t.Skipf("sleep is not available: %v", err)
+	}
+	defer func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	}()
+
+	pid := cmd.Process.Pid
+	start := ProcessStartTime(pid)
+	if start == "" {
+		t.Fatalf("ProcessStartTime(%d) is empty", pid)
+	}
+
+	tests := []struct {
+		name    string
+		process ManagedProcess
+		want    bool
+	}{
+		{"same process", ManagedProcess{PID: pid, ProcessStart: start}, true},
+		// 異常終了したラッパーのPIDが別のプロセスに再利用された場合
+		{"reused PID", ManagedProcess{PID: pid, ProcessStart: start + "0"}, false},
+		// 開始時刻を取得できなかったものは確認できないため停止しない
+		{"no start time", ManagedProcess{PID: pid}, false},
+		{"no PID", ManagedProcess{ProcessStart: start}, false},
+	}
+	for _, tt := range tests {
+		if got := ManagedProcessAlive(tt.process); got != tt.want {
+			t.Errorf("%s: ManagedProcessAlive() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// 別のPIDのプロセスとして停止を求めても、シグナルを送らない
+	if err := stopManagedProcess(ManagedProcess{PID: pid, ProcessStart: start + "0"}); err != nil {
+		t.Fatal(err)
+	}
+	if !isProcessAlive(strconv.Itoa(pid)) {
+		t.Error("the process was signalled although its start time did not match")
+	}
+}
@@ -0,0 +1,18 @@
+//go:build !windows
+
+package monitor
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// detachProcess starts the command in a new session, detached from the terminal
+func detachProcess(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+}
+
+// SetProcessGroup starts the command in its own process group so it can be stopped with its children
+func SetProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
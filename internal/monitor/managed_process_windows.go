@@ -0,0 +1,11 @@
+//go:build windows
+
+package monitor
+
+import "os/exec"
+
+// detachProcess is a no-op on Windows (セッションの概念がないためそのまま起動する)
+func detachProcess(cmd *exec.Cmd) {}
+
+// SetProcessGroup is a no-op on Windows
+func SetProcessGroup(cmd *exec.Cmd) {}
@@ -0,0 +1,337 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/config"
+)
+
+// MongoDatabase represents a MongoDB database
+type MongoDatabase struct {
+	Name        string
+	Size        string
+	SizeBytes   int64
+	Collections int
+	Empty       bool
+}
+
+// MongoCollection represents a collection in a MongoDB database
+type MongoCollection struct {
+	Name      string
+	Count     int64
+	Size      string
+	IndexSize string
+}
+
+// MongoServerStatus represents metrics from serverStatus
+type MongoServerStatus struct {
+	IsReachable    bool
+	Version        string
+	Uptime         string
+	Connections    string // current / available
+	ResidentMemory string
+	OpCounters     string // insert/query/update/delete
+	Container      string // コンテナで動作している場合のコンテナ名
+	Error          string // 接続できない場合のエラー内容
+}
+
+// MongoOperation represents an in-progress operation from currentOp
+type MongoOperation struct {
+	OpID        string
+	Op          string
+	Namespace   string
+	Client      string
+	SecsRunning int64
+	Description string
+}
+
+// mongoSystemDatabases はシステムデータベースの一覧
+var mongoSystemDatabases = []string{"admin", "local", "config"}
+
+// CheckMongoDB checks if MongoDB is running (local process or container)
+func CheckMongoDB() string {
+	// ローカルプロセスを優先
+	if IsServiceRunning("mongod") {
+		// ポート番号取得
+		port := getPortByProcess("mongod")
+		portInfo := ""
+		if port != "" {
+			portInfo = " [:" + port + "]"
+		}
+
+		// CPU・メモリ使用量取得
+		stats := getMultiProcessStats("mongod")
+
+		result := fmt.Sprintf("✓ MongoDB: 実行中%s", portInfo)
+
+		// CPU・メモリ情報追加
+		statsStr := formatStatsString(stats)
+		if statsStr != "" {
+			result += fmt.Sprintf(" | %s", statsStr)
+		}
+
+		return result
+	}
+
+	// コンテナで動作しているか確認
+	container := findMongoContainer()
+	if container == nil {
+		return "✗ MongoDB: 停止中"
+	}
+
+	portInfo := ""
+	if port := getContainerPort(container.ID); port != "" {
+		portInfo = " [:" + port + "]"
+	}
+
+	result := fmt.Sprintf("✓ MongoDB: 実行中 (コンテナ: %s)%s", container.Name, portInfo)
+
+	stats := GetDockerContainerStats(container.ID)
+	if statsStr := formatDockerStatsString(stats); statsStr != "" {
+		result += fmt.Sprintf(" | %s", statsStr)
+	}
+
+	return result
+}
+
+// IsMongoDBRunning reports whether mongod is running locally or in a container
+func IsMongoDBRunning() bool {
+	return IsServiceRunning("mongod") || findMongoContainer() != nil
+}
+
+// findMongoContainer returns the first running MongoDB container
+func findMongoContainer() *ServiceContainer {
+	return FindContainerByImage("mongo")
+}
+
+// mongoURIEnv は接続文字列を mongosh に渡す環境変数
+// 接続文字列にはユーザー名とパスワードを含む場合があり、コマンドライン引数は ps で他のユーザーからも見えるため
+const mongoURIEnv = "DEVMON_MONGOSH_URI"
+
+// mongoshCommand builds a mongosh command that connects with the given URI and evaluates the script
+// ローカルに mongosh がない場合はMongoDBコンテナ内の mongosh を同じ接続設定で利用します
+func mongoshCommand(ctx context.Context, cfg config.MongoConfig, script string) (*exec.Cmd, error) {
+	// 接続文字列は環境変数から読み、接続してからスクリプトを評価する
+	script = fmt.Sprintf("db = connect(process.env.%s);\n%s", mongoURIEnv, script)
+
+	var cmd *exec.Cmd
+	if _, err := exec.LookPath("mongosh"); err == nil {
+		cmd = exec.CommandContext(ctx, "mongosh", "--nodb", "--quiet", "--eval", script)
+	} else if container := findMongoContainer(); container != nil {
+		// -e に値を付けない場合は、exec を実行するCLIの環境変数の値をコンテナに渡す
+		cmd = exec.CommandContext(ctx, ContainerCLI(), "exec", "-e", mongoURIEnv, container.ID, "mongosh", "--nodb", "--quiet", "--eval", script)
+	} else {
+		return nil, fmt.Errorf("mongosh が見つかりません")
+	}
+
+	cmd.Env = append(os.Environ(), mongoURIEnv+"="+cfg.URI)
+	return cmd, nil
+}
+
+// runMongoEval runs a script with mongosh and decodes its JSON output into v
+func runMongoEval(cfg config.MongoConfig, script string, v interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancel()
+
+	cmd, err := mongoshCommand(ctx, cfg, script)
+	if err != nil {
+		return err
+	}
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		message := strings.TrimSpace(string(output))
+		if message == "" {
+			message = err.Error()
+		}
+		return fmt.Errorf("%s", message)
+	}
+
+	// 出力の最終行がJSON（警告などが前に出力される場合があるため）
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	return json.Unmarshal([]byte(lines[len(lines)-1]), v)
+}
+
+// GetMongoDatabases returns list of MongoDB databases
+func GetMongoDatabases() []MongoDatabase {
+	script := `JSON.stringify(db.adminCommand({listDatabases: 1}).databases.map(d => ({
+		name: d.name,
+		sizeOnDisk: Number(d.sizeOnDisk),
+		empty: Boolean(d.empty),
+		collections: db.getSiblingDB(d.name).getCollectionNames().length
+	})))`
+
+	var result []struct {
+		Name        string `json:"name"`
+		SizeOnDisk  int64  `json:"sizeOnDisk"`
+		Empty       bool   `json:"empty"`
+		Collections int    `json:"collections"`
+	}
+	if err := runMongoEval(config.Load().Mongo, script, &result); err != nil {
+		return []MongoDatabase{}
+	}
+
+	var databases []MongoDatabase
+	for _, d := range result {
+		// システムデータベースはスキップ
+		if IsMongoSystemDatabase(d.Name) {
+			continue
+		}
+
+		databases = append(databases, MongoDatabase{
+			Name:        d.Name,
+			Size:        formatBytes(d.SizeOnDisk),
+			SizeBytes:   d.SizeOnDisk,
+			Collections: d.Collections,
+			Empty:       d.Empty,
+		})
+	}
+
+	return databases
+}
+
+// IsMongoSystemDatabase reports whether the database is a MongoDB system database
+func IsMongoSystemDatabase(name string) bool {
+	for _, sysDB := range mongoSystemDatabases {
+		if name == sysDB {
+			return true
+		}
+	}
+	return false
+}
+
+// GetMongoServerStatus returns server metrics from serverStatus
+func GetMongoServerStatus() MongoServerStatus {
+	script := `(() => {
+		const s = db.adminCommand({serverStatus: 1});
+		return JSON.stringify({
+			version: s.version,
+			uptime: Number(s.uptime),
+			connCurrent: Number(s.connections.current),
+			connAvailable: Number(s.connections.available),
+			resident: Number(s.mem.resident),
+			insert: Number(s.opcounters.insert),
+			query: Number(s.opcounters.query),
+			update: Number(s.opcounters.update),
+			delete: Number(s.opcounters.delete)
+		});
+	})()`
+
+	var result struct {
+		Version       string `json:"version"`
+		Uptime        int64  `json:"uptime"`
+		ConnCurrent   int64  `json:"connCurrent"`
+		ConnAvailable int64  `json:"connAvailable"`
+		Resident      int64  `json:"resident"` // MB
+		Insert        int64  `json:"insert"`
+		Query         int64  `json:"query"`
+		Update        int64  `json:"update"`
+		Delete        int64  `json:"delete"`
+	}
+	if err := runMongoEval(config.Load().Mongo, script, &result); err != nil {
+		return MongoServerStatus{IsReachable: false, Error: err.Error()}
+	}
+
+	status := MongoServerStatus{
+		IsReachable:    true,
+		Version:        result.Version,
+		Uptime:         formatSeconds(result.Uptime),
+		Connections:    fmt.Sprintf("%d / %d", result.ConnCurrent, result.ConnCurrent+result.ConnAvailable),
+		ResidentMemory: fmt.Sprintf("%d MB", result.Resident),
+		OpCounters: fmt.Sprintf("insert %d / query %d / update %d / delete %d",
+			result.Insert, result.Query, result.Update, result.Delete),
+	}
+
+	// mongosh がない環境ではコンテナ経由で取得している
+	if _, err := exec.LookPath("mongosh"); err != nil {
+		if container := findMongoContainer(); container != nil {
+			status.Container = container.Name
+		}
+	}
+
+	return status
+}
+
+// GetMongoCurrentOps returns in-progress operations from currentOp
+func GetMongoCurrentOps() []MongoOperation {
+	// 自分自身のcurrentOpとアイドル接続は除外
+	script := `JSON.stringify(db.adminCommand({currentOp: 1, active: true}).inprog
+		.filter(o => o.op !== "none" && !(o.command && o.command.currentOp))
+		.map(o => ({
+			opid: String(o.opid),
+			op: o.op || "",
+			ns: o.ns || "",
+			client: o.client || "",
+			secs: Number(o.secs_running || 0),
+			desc: o.desc || ""
+		})))`
+
+	var result []struct {
+		OpID   string `json:"opid"`
+		Op     string `json:"op"`
+		NS     string `json:"ns"`
+		Client string `json:"client"`
+		Secs   int64  `json:"secs"`
+		Desc   string `json:"desc"`
+	}
+	if err := runMongoEval(config.Load().Mongo, script, &result); err != nil {
+		return []MongoOperation{}
+	}
+
+	var ops []MongoOperation
+	for _, o := range result {
+		ops = append(ops, MongoOperation{
+			OpID:        o.OpID,
+			Op:          o.Op,
+			Namespace:   o.NS,
+			Client:      o.Client,
+			SecsRunning: o.Secs,
+			Description: o.Desc,
+		})
+	}
+
+	return ops
+}
+
+// GetMongoCollections returns collections of a database ordered by size
+func GetMongoCollections(databaseName string) []MongoCollection {
+	// セキュリティバリデーション
+	if !IsValidIdentifier(databaseName) {
+		return []MongoCollection{}
+	}
+
+	script := fmt.Sprintf(`(() => {
+		const d = db.getSiblingDB(%q);
+		return JSON.stringify(d.getCollectionNames().map(name => {
+			const s = d.getCollection(name).stats();
+			return {name: name, count: Number(s.count || 0), size: Number(s.size || 0), indexSize: Number(s.totalIndexSize || 0)};
+		}).sort((a, b) => b.size - a.size));
+	})()`, databaseName)
+
+	var result []struct {
+		Name      string `json:"name"`
+		Count     int64  `json:"count"`
+		Size      int64  `json:"size"`
+		IndexSize int64  `json:"indexSize"`
+	}
+	if err := runMongoEval(config.Load().Mongo, script, &result); err != nil {
+		return []MongoCollection{}
+	}
+
+	var collections []MongoCollection
+	for _, c := range result {
+		collections = append(collections, MongoCollection{
+			Name:      c.Name,
+			Count:     c.Count,
+			Size:      formatBytes(c.Size),
+			IndexSize: formatBytes(c.IndexSize),
+		})
+	}
+
+	return collections
+}
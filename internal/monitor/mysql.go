@@ -1,9 +1,14 @@
 package monitor
 
 import (
+	"context"
 	"fmt"
+	"os"
 	"os/exec"
+	"strconv"
 	"strings"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/config"
 )
 
 // MySQLDatabase represents a MySQL database
@@ -12,6 +17,46 @@ type MySQLDatabase struct {
 	Size string
 }
 
+// MySQLServerStatus represents MySQL server metrics from SHOW GLOBAL STATUS
+type MySQLServerStatus struct {
+	IsReachable       bool
+	Version           string
+	Uptime            string
+	ThreadsConnected  string
+	ThreadsRunning    string
+	SlowQueries       string
+	Questions         string
+	BufferPoolHitRate string // InnoDBバッファプールのヒット率
+	BufferPoolUsage   string // InnoDBバッファプールの使用率
+	RowLockWaits      string
+	Error             string // 接続できない場合のエラー内容
+}
+
+// MySQLProcess represents a row of SHOW FULL PROCESSLIST
+type MySQLProcess struct {
+	ID      string
+	User    string
+	Host    string
+	DB      string
+	Command string
+	Time    string
+	State   string
+	Info    string
+}
+
+// MySQLTable represents a table in information_schema.TABLES
+type MySQLTable struct {
+	Name      string
+	Engine    string
+	Rows      string
+	DataSize  string
+	IndexSize string
+	TotalSize string
+}
+
+// mysqlSystemDatabases はシステムデータベースの一覧
+var mysqlSystemDatabases = []string{"information_schema", "performance_schema", "mysql", "sys"}
+
 // CheckMySQL checks if MySQL is running
 func CheckMySQL() string {
 	cmd := exec.Command("pgrep", "mysqld")
@@ -42,14 +87,52 @@ func CheckMySQL() string {
 	return result
 }
 
+// mysqlCommand builds a mysql client command using the configured connection settings
+func mysqlCommand(ctx context.Context, args ...string) *exec.Cmd {
+	cfg := config.Load().MySQL
+
+	var baseArgs []string
+	// --login-path は最初の引数である必要がある
+	if cfg.LoginPath != "" {
+		baseArgs = append(baseArgs, "--login-path="+cfg.LoginPath)
+	}
+	if cfg.Host != "" {
+		baseArgs = append(baseArgs, "-h", cfg.Host)
+	}
+	if cfg.Port != "" {
+		baseArgs = append(baseArgs, "-P", cfg.Port)
+	}
+	if cfg.User != "" {
+		baseArgs = append(baseArgs, "-u", cfg.User)
+	}
+	if cfg.Socket != "" {
+		baseArgs = append(baseArgs, "-S", cfg.Socket)
+	}
+
+	cmd := exec.CommandContext(ctx, "mysql", append(baseArgs, args...)...)
+
+	// パスワードはコマンドライン引数ではなく環境変数で渡す（psに表示させない）
+	if cfg.Password != "" {
+		cmd.Env = append(os.Environ(), "MYSQL_PWD="+cfg.Password)
+	}
+
+	return cmd
+}
+
+// runMySQLQuery runs a query in batch mode and returns tab-separated rows without headers
+func runMySQLQuery(query string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancel()
+
+	return mysqlCommand(ctx, "-N", "-B", "-e", query).Output()
+}
+
 // GetMySQLDatabases returns list of MySQL databases
 func GetMySQLDatabases() []MySQLDatabase {
 	// データベース一覧を取得
 	query := "SELECT table_schema as 'Database', ROUND(SUM(data_length + index_length) / 1024 / 1024, 2) as 'Size (MB)' FROM information_schema.TABLES GROUP BY table_schema;"
 
-	cmd := exec.Command("mysql", "-N", "-e", query)
-	output, err := cmd.Output()
-
+	output, err := runMySQLQuery(query)
 	if err != nil {
 		return []MySQLDatabase{}
 	}
@@ -67,7 +150,7 @@ func GetMySQLDatabases() []MySQLDatabase {
 		if len(parts) >= 2 {
 			dbName := parts[0]
 			// システムデータベースはスキップ
-			if dbName == "information_schema" || dbName == "performance_schema" || dbName == "mysql" || dbName == "sys" {
+			if IsMySQLSystemDatabase(dbName) {
 				continue
 			}
 
@@ -81,3 +164,185 @@ func GetMySQLDatabases() []MySQLDatabase {
 
 	return databases
 }
+
+// IsMySQLSystemDatabase reports whether the database is a MySQL system database
+func IsMySQLSystemDatabase(name string) bool {
+	for _, sysDB := range mysqlSystemDatabases {
+		if name == sysDB {
+			return true
+		}
+	}
+	return false
+}
+
+// GetMySQLServerStatus returns server metrics from SHOW GLOBAL STATUS
+func GetMySQLServerStatus() MySQLServerStatus {
+	query := `SHOW GLOBAL STATUS WHERE Variable_name IN (
+		'Uptime', 'Threads_connected', 'Threads_running', 'Slow_queries', 'Questions',
+		'Innodb_buffer_pool_reads', 'Innodb_buffer_pool_read_requests',
+		'Innodb_buffer_pool_pages_total', 'Innodb_buffer_pool_pages_free',
+		'Innodb_row_lock_waits');`
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancel()
+
+	output, err := mysqlCommand(ctx, "-N", "-B", "-e", query).CombinedOutput()
+	if err != nil {
+		return MySQLServerStatus{
+			IsReachable: false,
+			Error:       strings.TrimSpace(string(output)),
+		}
+	}
+
+	// Variable_name\tValue 形式をマップに格納
+	values := make(map[string]string)
+	for _, line := range strings.Split(string(output), "\n") {
+		parts := strings.SplitN(strings.TrimSpace(line), "\t", 2)
+		if len(parts) == 2 {
+			values[parts[0]] = parts[1]
+		}
+	}
+
+	status := MySQLServerStatus{
+		IsReachable:      true,
+		ThreadsConnected: values["Threads_connected"],
+		ThreadsRunning:   values["Threads_running"],
+		SlowQueries:      values["Slow_queries"],
+		Questions:        values["Questions"],
+		RowLockWaits:     values["Innodb_row_lock_waits"],
+	}
+
+	// 稼働時間（秒）を整形
+	if secs, err := strconv.ParseInt(values["Uptime"], 10, 64); err == nil {
+		status.Uptime = formatSeconds(secs)
+	}
+
+	// バッファプールヒット率 = 1 - (ディスク読み込み / 読み込み要求)
+	reads, _ := strconv.ParseFloat(values["Innodb_buffer_pool_reads"], 64)
+	requests, _ := strconv.ParseFloat(values["Innodb_buffer_pool_read_requests"], 64)
+	if requests > 0 {
+		status.BufferPoolHitRate = fmt.Sprintf("%.2f%%", (1-reads/requests)*100)
+	}
+
+	// バッファプール使用率
+	total, _ := strconv.ParseFloat(values["Innodb_buffer_pool_pages_total"], 64)
+	free, _ := strconv.ParseFloat(values["Innodb_buffer_pool_pages_free"], 64)
+	if total > 0 {
+		status.BufferPoolUsage = fmt.Sprintf("%.1f%%", (total-free)/total*100)
+	}
+
+	// バージョン取得
+	if versionOutput, err := runMySQLQuery("SELECT VERSION();"); err == nil {
+		status.Version = strings.TrimSpace(string(versionOutput))
+	}
+
+	return status
+}
+
+// formatSeconds formats seconds like "2d 3h 4m"
+func formatSeconds(secs int64) string {
+	days := secs / 86400
+	hours := (secs % 86400) / 3600
+	minutes := (secs % 3600) / 60
+
+	if days > 0 {
+		return fmt.Sprintf("%dd %dh %dm", days, hours, minutes)
+	}
+	if hours > 0 {
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	}
+	return fmt.Sprintf("%dm", minutes)
+}
+
+// GetMySQLProcessList returns the current connections from SHOW FULL PROCESSLIST
+func GetMySQLProcessList() []MySQLProcess {
+	output, err := runMySQLQuery("SHOW FULL PROCESSLIST;")
+	if err != nil {
+		return []MySQLProcess{}
+	}
+
+	var processes []MySQLProcess
+	for _, line := range strings.Split(string(output), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		// Id, User, Host, db, Command, Time, State, Info
+		parts := strings.Split(line, "\t")
+		if len(parts) < 8 {
+			continue
+		}
+
+		info := nullToEmpty(parts[7])
+
+		// 自分自身の問い合わせは除外
+		if strings.HasPrefix(info, "SHOW FULL PROCESSLIST") {
+			continue
+		}
+
+		processes = append(processes, MySQLProcess{
+			ID:      parts[0],
+			User:    parts[1],
+			Host:    parts[2],
+			DB:      nullToEmpty(parts[3]),
+			Command: parts[4],
+			Time:    parts[5],
+			State:   nullToEmpty(parts[6]),
+			Info:    info,
+		})
+	}
+
+	return processes
+}
+
+// GetMySQLTables returns tables of a database ordered by size
+func GetMySQLTables(databaseName string) []MySQLTable {
+	// セキュリティバリデーション
+	if !IsValidIdentifier(databaseName) {
+		return []MySQLTable{}
+	}
+
+	query := fmt.Sprintf(`SELECT table_name, IFNULL(engine, ''), IFNULL(table_rows, 0),
+		ROUND(IFNULL(data_length, 0) / 1024 / 1024, 2),
+		ROUND(IFNULL(index_length, 0) / 1024 / 1024, 2),
+		ROUND((IFNULL(data_length, 0) + IFNULL(index_length, 0)) / 1024 / 1024, 2)
+		FROM information_schema.TABLES
+		WHERE table_schema = '%s'
+		ORDER BY (data_length + index_length) DESC;`, databaseName)
+
+	output, err := runMySQLQuery(query)
+	if err != nil {
+		return []MySQLTable{}
+	}
+
+	var tables []MySQLTable
+	for _, line := range strings.Split(string(output), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		parts := strings.Split(line, "\t")
+		if len(parts) < 6 {
+			continue
+		}
+
+		tables = append(tables, MySQLTable{
+			Name:      parts[0],
+			Engine:    parts[1],
+			Rows:      parts[2],
+			DataSize:  parts[3] + " MB",
+			IndexSize: parts[4] + " MB",
+			TotalSize: parts[5] + " MB",
+		})
+	}
+
+	return tables
+}
+
+// nullToEmpty converts mysql batch mode NULL to empty string
+func nullToEmpty(s string) string {
+	if s == "NULL" {
+		return ""
+	}
+	return s
+}
@@ -18,6 +18,86 @@ type NodeProcess struct {
 	CPUPerc     string
 	MemUsage    string
 	Port        string
+	Framework   string // Next.js / Vite / Jest など
+	Role        string // NodeRoleServer / NodeRoleTool / NodeRoleEditor（判定できない場合は空）
+	ParentPID   string // ワーカーの場合、まとめ先となる親のNode.jsプロセスのPID
+	Command     string
+}
+
+// Node.jsプロセスの役割
+const (
+	NodeRoleServer = "server" // 開発サーバー・アプリケーション
+	NodeRoleTool   = "tool"   // ウォッチャー・テストランナー・スクリプトランナー
+	NodeRoleEditor = "editor" // エディタ内部のプロセス（拡張機能ホスト、言語サーバー等）
+)
+
+// nodeFrameworkRule はコマンドラインからフレームワークを判定するルール
+type nodeFrameworkRule struct {
+	// いずれかに一致すれば該当（小文字で比較）
+	// 単語は引数のファイル名（拡張子なし）か node_modules 直下のパッケージ名と比較し、ディレクトリ名には一致させない
+	// 空白・"/"・"." を含むものはコマンドライン中の語句として検索する
+	Keywords  []string
+	Framework string
+	Role      string
+}
+
+// nodeCommandRules は上から順に評価する（"vitest" を "vite" より先に判定するなど順序に意味がある）
+var nodeCommandRules = []nodeFrameworkRule{
+	// エディタ内部
+	{[]string{"visual studio code", "code helper", ".vscode-server", ".vscode/extensions", "extensionhost"}, "VS Code", NodeRoleEditor},
+	{[]string{"cursor.app", "cursor helper", ".cursor-server", ".cursor/extensions"}, "Cursor", NodeRoleEditor},
+	{[]string{"windsurf", "windsurf.app", "windsurf helper"}, "Windsurf", NodeRoleEditor},
+	{[]string{"/jetbrains/", "webstorm.app", "intellij idea", "webstorm", "intellij"}, "JetBrains IDE", NodeRoleEditor},
+	{[]string{"tsserver", "typescript-language-server", "eslintserver", "languageserver"}, "言語サーバー", NodeRoleEditor},
+	{[]string{"electron"}, "Electron", NodeRoleEditor},
+
+	// テスト・静的解析・ビルドのウォッチャー
+	{[]string{"vitest"}, "Vitest", NodeRoleTool},
+	{[]string{"jest"}, "Jest", NodeRoleTool},
+	{[]string{"playwright"}, "Playwright", NodeRoleTool},
+	{[]string{"cypress"}, "Cypress", NodeRoleTool},
+	{[]string{"tsc --watch", "tsc -w", "tsc -b --watch", "tsc --build --watch"}, "tsc --watch", NodeRoleTool},
+	{[]string{"eslint"}, "ESLint", NodeRoleTool},
+	{[]string{"prettier"}, "Prettier", NodeRoleTool},
+	{[]string{"webpack --watch", "webpack -w"}, "webpack --watch", NodeRoleTool},
+	{[]string{"nodemon"}, "nodemon", NodeRoleTool},
+	{[]string{"ts-node-dev", "tsx watch"}, "ウォッチャー", NodeRoleTool},
+
+	// 開発サーバー
+	{[]string{"next dev", "next start", "next-server", "next/dist"}, "Next.js", NodeRoleServer},
+	{[]string{"nuxt"}, "Nuxt", NodeRoleServer},
+	{[]string{"astro"}, "Astro", NodeRoleServer},
+	{[]string{"remix", "@remix-run/dev"}, "Remix", NodeRoleServer},
+	{[]string{"storybook"}, "Storybook", NodeRoleServer},
+	{[]string{"gatsby"}, "Gatsby", NodeRoleServer},
+	{[]string{"svelte-kit", "sveltekit"}, "SvelteKit", NodeRoleServer},
+	{[]string{"ng serve", "@angular/cli"}, "Angular", NodeRoleServer},
+	{[]string{"react-scripts"}, "Create React App", NodeRoleServer},
+	{[]string{"webpack serve", "webpack-dev-server"}, "webpack dev server", NodeRoleServer},
+	{[]string{"vite"}, "Vite", NodeRoleServer},
+	{[]string{"nest start", "@nestjs"}, "NestJS", NodeRoleServer},
+
+	// スクリプトランナー・モノレポツール
+	{[]string{"turbo"}, "Turborepo", NodeRoleTool},
+	{[]string{"npm run", "npm exec", "npm start", "npm-cli.js", "npx "}, "npm", NodeRoleTool},
+	{[]string{"yarn.js", "yarn run", "/yarn "}, "yarn", NodeRoleTool},
+	{[]string{"pnpm"}, "pnpm", NodeRoleTool},
+}
+
+// nodeDependencyRules はpackage.jsonの依存関係からフレームワークを判定するルール（上から順に評価）
+var nodeDependencyRules = []struct {
+	Dependency string
+	Framework  string
+}{
+	{"next", "Next.js"},
+	{"@nestjs/core", "NestJS"},
+	{"nuxt", "Nuxt"},
+	{"@remix-run/node", "Remix"},
+	{"astro", "Astro"},
+	{"fastify", "Fastify"},
+	{"koa", "Koa"},
+	{"@hapi/hapi", "hapi"},
+	{"express", "Express"},
 }
 
 // CheckNodejs checks if Node.js process is running
@@ -149,24 +229,36 @@ func getProcessUptime(pid string) string {
 
 // PackageJson represents package.json structure
 type PackageJson struct {
-	Name string `json:"name"`
+	Name            string            `json:"name"`
+	Dependencies    map[string]string `json:"dependencies"`
+	DevDependencies map[string]string `json:"devDependencies"`
 }
 
 // getProjectNameFromPackageJson reads project name from package.json
 func getProjectNameFromPackageJson(dir string) string {
+	pkg := readPackageJson(dir)
+	if pkg == nil {
+		return ""
+	}
+
+	return pkg.Name
+}
+
+// readPackageJson reads package.json in dir
+func readPackageJson(dir string) *PackageJson {
 	packageJsonPath := filepath.Join(dir, "package.json")
 
 	data, err := os.ReadFile(packageJsonPath)
 	if err != nil {
-		return ""
+		return nil
 	}
 
 	var pkg PackageJson
 	if err := json.Unmarshal(data, &pkg); err != nil {
-		return ""
+		return nil
 	}
 
-	return pkg.Name
+	return &pkg
 }
 
 // GetNodeProcesses returns list of Node.js processes
@@ -183,6 +275,14 @@ func GetNodeProcesses() []NodeProcess {
 		return []NodeProcess{}
 	}
 
+	// コマンドラインと親PIDはプロセス一覧からまとめて取得
+	commands := make(map[string]string)
+	parents := make(map[string]string)
+	for _, entry := range getProcessTable() {
+		commands[entry.PID] = entry.Command
+		parents[entry.PID] = entry.PPID
+	}
+
 	var processes []NodeProcess
 
 	for _, pid := range pids {
@@ -229,6 +329,9 @@ func GetNodeProcesses() []NodeProcess {
 			projectName = filepath.Base(projectDir)
 		}
 
+		// フレームワーク・役割判定（Next.js/Vite/Jest/VS Code等）
+		framework, role := detectNodeProcessType(commands[pid], projectDir, port)
+
 		processes = append(processes, NodeProcess{
 			PID:         pid,
 			ProjectDir:  projectDir,
@@ -237,15 +340,121 @@ func GetNodeProcesses() []NodeProcess {
 			CPUPerc:     fmt.Sprintf("%.1f%%", stats.CPU),
 			MemUsage:    fmt.Sprintf("%.1fMB", float64(stats.Memory)/1024.0),
 			Port:        port,
+			Framework:   framework,
+			Role:        role,
+			Command:     commands[pid],
 		})
 	}
 
-	return processes
+	return groupNodeWorkers(processes, parents)
+}
+
+// nodeCommandNames returns the file names (with and without extension) of the arguments and the packages under node_modules
+// "/srv/invite-api/index.js" のようにディレクトリ名に含まれる語でフレームワークを誤判定しないため
+func nodeCommandNames(lower string) map[string]bool {
+	names := make(map[string]bool)
+	for _, field := range strings.Fields(lower) {
+		// --type=extensionhost のような値も1つの引数として扱う
+		for _, arg := range strings.Split(field, "=") {
+			parts := strings.Split(arg, "/")
+			base := parts[len(parts)-1]
+			names[base] = true
+			names[strings.TrimSuffix(base, filepath.Ext(base))] = true
+
+			for i := 0; i+1 < len(parts); i++ {
+				if parts[i] == "node_modules" {
+					names[parts[i+1]] = true
+				}
+			}
+		}
+	}
+	delete(names, "")
+	return names
+}
+
+// detectNodeProcessType detects framework and role of a Node.js process
+// コマンドラインで判定できない場合はpackage.jsonの依存関係から判定します
+func detectNodeProcessType(cmdLine, projectDir, port string) (string, string) {
+	lower := strings.ToLower(cmdLine)
+	names := nodeCommandNames(lower)
+
+	for _, rule := range nodeCommandRules {
+		for _, keyword := range rule.Keywords {
+			if strings.ContainsAny(keyword, " /.") {
+				if strings.Contains(lower, keyword) {
+					return rule.Framework, rule.Role
+				}
+			} else if names[keyword] {
+				return rule.Framework, rule.Role
+			}
+		}
+	}
+
+	if projectDir != "" {
+		if pkg := readPackageJson(projectDir); pkg != nil {
+			for _, rule := range nodeDependencyRules {
+				_, inDeps := pkg.Dependencies[rule.Dependency]
+				_, inDevDeps := pkg.DevDependencies[rule.Dependency]
+				if inDeps || inDevDeps {
+					return rule.Framework, NodeRoleServer
+				}
+			}
+		}
+	}
+
+	// ポートを開いていればサーバーとみなす
+	if port != "" {
+		return "Node.js", NodeRoleServer
+	}
+	return "Node.js", ""
+}
+
+// groupNodeWorkers links worker processes to their root Node.js process
+// 親をたどって最上位のNode.jsプロセスをParentPIDに設定し、ルートの直後に並べます
+func groupNodeWorkers(processes []NodeProcess, parents map[string]string) []NodeProcess {
+	index := make(map[string]int)
+	for i, proc := range processes {
+		index[proc.PID] = i
+	}
+
+	for i := range processes {
+		root := ""
+		// 循環を避けるため深さを制限
+		for current, depth := parents[processes[i].PID], 0; depth < 32; current, depth = parents[current], depth+1 {
+			if _, ok := index[current]; !ok {
+				break
+			}
+			root = current
+		}
+		processes[i].ParentPID = root
+	}
+
+	var grouped []NodeProcess
+	for _, proc := range processes {
+		if proc.ParentPID != "" {
+			continue
+		}
+		grouped = append(grouped, proc)
+
+		for _, worker := range processes {
+			if worker.ParentPID != proc.PID {
+				continue
+			}
+			// エディタ配下のプロセスはエディタ内部として扱う
+			if proc.Role == NodeRoleEditor {
+				worker.Role = NodeRoleEditor
+			}
+			grouped = append(grouped, worker)
+		}
+	}
+
+	return grouped
 }
 
 // getProcessPort returns port number for a process
 func getProcessPort(pid string) string {
-	cmd := exec.Command("lsof", "-i", "-P", "-n", "-p", pid)
+	// -a: -i と -p をAND条件にする（指定しないと全プロセスのソケットが対象になる）
+	cmd := exec.Command("lsof", "-a", "-i", "-P", "-n", "-p", pid)
 	output, err := cmd.Output()
 
 	if err != nil {
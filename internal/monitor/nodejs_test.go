@@ -0,0 +1,41 @@
+package monitor
+
+import "testing"
+
+func TestDetectNodeProcessType(t *testing.T) {
+	tests := []struct {
+		cmdLine   string
+		framework string
+		role      string
+	}{
+		{"node /app/node_modules/.bin/vite --port 5173", "Vite", NodeRoleServer},
+		{"node /app/node_modules/vite/bin/vite.js", "Vite", NodeRoleServer},
+		{"node /app/node_modules/.bin/vitest --watch", "Vitest", NodeRoleTool},
+		{"node /app/node_modules/jest/bin/jest.js --watch", "Jest", NodeRoleTool},
+		{"next-server (v14.2.3)", "Next.js", NodeRoleServer},
+		{"node /app/node_modules/next/dist/bin/next dev", "Next.js", NodeRoleServer},
+		{"node /app/node_modules/react-scripts/scripts/start.js", "Create React App", NodeRoleServer},
+		{"/Applications/Visual Studio Code.app/Contents/Frameworks/Code Helper (Plugin).app/Contents/MacOS/Code Helper (Plugin) --type=extensionHost", "VS Code", NodeRoleEditor},
+		{"/home/u/.vscode-server/bin/abc/node /home/u/.vscode-server/bin/abc/out/server-main.js", "VS Code", NodeRoleEditor},
+		{"/app/node_modules/electron/dist/electron .", "Electron", NodeRoleEditor},
+		{"node /home/u/.nvm/versions/node/v20/lib/node_modules/npm/bin/npm-cli.js run dev", "npm", NodeRoleTool},
+
+		// ディレクトリ名に含まれる語では判定しない
+		{"node /srv/invite-api/index.js", "Node.js", ""},
+		{"node /home/u/majestic/server.js", "Node.js", ""},
+		{"node /home/u/electron-shop/api/server.js", "Node.js", ""},
+		{"node /home/u/my-astro-blog/dist/server/entry.mjs", "Node.js", ""},
+		{"node /home/u/jetbrains-plugin-site/app.js", "Node.js", ""},
+	}
+	for _, tt := range tests {
+		framework, role := detectNodeProcessType(tt.cmdLine, "", "")
+		if framework != tt.framework || role != tt.role {
+			t.Errorf("detectNodeProcessType(%q) = %q, %q, want %q, %q", tt.cmdLine, framework, role, tt.framework, tt.role)
+		}
+	}
+
+	// ポートを開いていればサーバー
+	if framework, role := detectNodeProcessType("node /srv/invite-api/index.js", "", "3000"); framework != "Node.js" || role != NodeRoleServer {
+		t.Errorf("with a port: %q, %q", framework, role)
+	}
+}
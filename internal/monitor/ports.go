@@ -26,6 +26,7 @@ func GetListeningPorts() []PortInfo {
 	}
 	
 	var ports []PortInfo
+	var containerNames map[string]string // 公開ポート -> コンテナ名（必要になったときだけ取得）
 	lines := strings.Split(string(output), "\n")
 	
 	for _, line := range lines {
@@ -52,11 +53,17 @@ func GetListeningPorts() []PortInfo {
 			processName := fields[0]
 			pid := fields[1]
 
-			// プロジェクト名を取得（Dockerの場合）
+			// プロジェクト名を取得（コンテナの場合）
 			projectName := processName
-			if strings.HasPrefix(processName, "com.docke") {
-				// Dockerコンテナの場合、コンテナ名またはプロジェクト名を取得
-				projectName = getDockerProjectNameByPID(pid)
+			if isContainerPortProcess(processName) {
+				// コンテナランタイムのプロセスの場合、公開ポートからコンテナ名またはプロジェクト名を取得
+				if containerNames == nil {
+					containerNames = getContainerNamesByPort()
+				}
+				projectName = containerNames[port]
+				if projectName == "" {
+					projectName = CurrentContainerRuntime().Name()
+				}
 			}
 
 			// URLを生成
@@ -114,37 +121,56 @@ func ListAllPorts() string {
 	return result
 }
 
-// getDockerProjectNameByPID returns the Docker project/container name by PID
-func getDockerProjectNameByPID(pid string) string {
-	// PIDのバリデーション
-	if !IsValidPID(pid) {
-		return "Docker"
-	}
+// getContainerNamesByPort maps published host ports to the compose project or container name
+func getContainerNamesByPort() map[string]string {
+	names := make(map[string]string)
 
-	// タイムアウト付きでpsコマンドを実行
-	output, err := RunCommandWithTimeout("ps", "-p", pid, "-o", "command=")
+	output, err := RunCommandWithTimeout(ContainerCLI(), "ps", "--format", "{{.ID}}|{{.Names}}|{{.Ports}}")
 	if err != nil {
-		return "Docker"
+		return names
 	}
 
-	cmdLine := string(output)
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		parts := strings.Split(line, "|")
+		if len(parts) < 3 {
+			continue
+		}
 
-	// Dockerコンテナのプロセスの場合、docker psでコンテナ情報を取得
-	containers := GetDockerContainers()
-	for _, container := range containers {
-		// コンテナIDやプロセス情報から一致するものを探す
-		// 簡易的な実装として、実行中のコンテナのポート情報と照合
-		if container.ComposeProject != "" {
-			return container.ComposeProject
+		hostPorts := parsePublishedHostPorts(parts[2])
+		if len(hostPorts) == 0 {
+			continue
 		}
-	}
 
-	// コンテナ名が取得できない場合はDocker
-	if strings.Contains(cmdLine, "docker") {
-		return "Docker"
+		// Composeプロジェクトの場合はプロジェクト名、単体の場合はコンテナ名
+		name := parts[1]
+		if project, _ := getComposeInfo(parts[0]); project != "" {
+			name = project
+		}
+		for _, port := range hostPorts {
+			names[port] = name
+		}
 	}
+	return names
+}
 
-	return "Docker"
+// parsePublishedHostPorts extracts host ports from "0.0.0.0:5432->5432/tcp, :::5432->5432/tcp"
+func parsePublishedHostPorts(ports string) []string {
+	var hostPorts []string
+	for _, mapping := range strings.Split(ports, ",") {
+		host, _, found := strings.Cut(strings.TrimSpace(mapping), "->")
+		if !found {
+			continue
+		}
+		if idx := strings.LastIndex(host, ":"); idx != -1 {
+			host = host[idx+1:]
+		}
+		// 範囲指定（8000-8001）は先頭のポートのみ
+		host, _, _ = strings.Cut(host, "-")
+		if host != "" {
+			hostPorts = append(hostPorts, host)
+		}
+	}
+	return hostPorts
 }
 
 // generateURL generates an accessible URL based on bind address and port
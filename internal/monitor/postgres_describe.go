@@ -0,0 +1,100 @@
+package monitor
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// postgresDescribeMaxTables は説明に含めるテーブルの最大数（大きい順）
+const postgresDescribeMaxTables = 30
+
+// PostgresTable represents a table in a PostgreSQL database
+type PostgresTable struct {
+	Schema      string
+	Name        string
+	Size        string // インデックスを含む合計サイズ
+	RowEstimate int64  // 統計情報による推定行数
+	DeadRows    int64
+	LastVacuum  string // 自動VACUUMを含む最終VACUUM
+	LastAnalyze string
+}
+
+// PostgresDatabaseDescription describes the tables and connections of a PostgreSQL database
+type PostgresDatabaseDescription struct {
+	Name        string
+	Size        string
+	Connections map[string]int // 状態（active / idle など）ごとの接続数
+	Tables      []PostgresTable
+}
+
+// DescribePostgresDatabase returns the largest tables and the connections of a database
+func DescribePostgresDatabase(databaseName string) (PostgresDatabaseDescription, error) {
+	// セキュリティバリデーション: データベース名が安全な文字のみであることを確認
+	if !IsValidIdentifier(databaseName) {
+		return PostgresDatabaseDescription{}, fmt.Errorf("不正なデータベース名です")
+	}
+
+	desc := PostgresDatabaseDescription{Name: databaseName, Connections: make(map[string]int)}
+
+	tableQuery := fmt.Sprintf(`
+		SELECT schemaname, relname,
+			pg_size_pretty(pg_total_relation_size(relid)),
+			n_live_tup, n_dead_tup,
+			COALESCE(GREATEST(last_vacuum, last_autovacuum)::text, ''),
+			COALESCE(GREATEST(last_analyze, last_autoanalyze)::text, '')
+		FROM pg_stat_user_tables
+		ORDER BY pg_total_relation_size(relid) DESC
+		LIMIT %d;
+	`, postgresDescribeMaxTables)
+
+	// タイムアウト付きでpsqlを実行
+	output, err := RunCommandWithTimeoutCombined("psql", "-d", databaseName, "-c", tableQuery, "-t", "-A", "-F", "|")
+	if err != nil {
+		return desc, fmt.Errorf("データベース %s に接続できません: %s", databaseName, strings.TrimSpace(string(output)))
+	}
+
+	for _, line := range strings.Split(string(output), "\n") {
+		parts := strings.Split(strings.TrimSpace(line), "|")
+		if len(parts) < 7 {
+			continue
+		}
+		table := PostgresTable{
+			Schema:      parts[0],
+			Name:        parts[1],
+			Size:        parts[2],
+			LastVacuum:  "なし",
+			LastAnalyze: "なし",
+		}
+		if parts[5] != "" {
+			table.LastVacuum = formatTimeAgo(parts[5])
+		}
+		if parts[6] != "" {
+			table.LastAnalyze = formatTimeAgo(parts[6])
+		}
+		table.RowEstimate, _ = strconv.ParseInt(parts[3], 10, 64)
+		table.DeadRows, _ = strconv.ParseInt(parts[4], 10, 64)
+		desc.Tables = append(desc.Tables, table)
+	}
+
+	// データベースのサイズと接続の状態
+	infoQuery := fmt.Sprintf(`
+		SELECT 'size', pg_size_pretty(pg_database_size('%s'))
+		UNION ALL
+		SELECT COALESCE(state, 'unknown'), count(*)::text FROM pg_stat_activity WHERE datname = '%s' GROUP BY state;
+	`, databaseName, databaseName)
+	if output, err := RunCommandWithTimeout("psql", "-d", databaseName, "-c", infoQuery, "-t", "-A", "-F", "|"); err == nil {
+		for _, line := range strings.Split(string(output), "\n") {
+			key, value, ok := strings.Cut(strings.TrimSpace(line), "|")
+			if !ok {
+				continue
+			}
+			if key == "size" {
+				desc.Size = value
+				continue
+			}
+			desc.Connections[key], _ = strconv.Atoi(value)
+		}
+	}
+	return desc, nil
+}
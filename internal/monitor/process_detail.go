@@ -0,0 +1,69 @@
+package monitor
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ProcessDetail holds details of a single process
+type ProcessDetail struct {
+	PID      string
+	PPID     string
+	User     string
+	State    string
+	CPU      float64
+	MemPerc  float64
+	RSSKB    int64
+	Elapsed  string // 起動からの経過時間（ps の etime 形式）
+	Threads  int
+	Command  string
+	Cwd      string
+	Ports    []PortInfo
+	Children []string // 子プロセスのPID
+}
+
+// GetProcessDetail returns details of the process with pid
+func GetProcessDetail(pid string) (ProcessDetail, error) {
+	if !IsValidPID(pid) {
+		return ProcessDetail{}, fmt.Errorf("不正なPIDです: %s", pid)
+	}
+
+	// args は空白を含むため最後に置く（スレッド数は macOS の ps にないため別に取得する）
+	output, err := RunCommandWithTimeout("ps", "-o", "pid=,ppid=,user=,stat=,%cpu=,%mem=,rss=,etime=,args=", "-p", pid)
+	if err != nil {
+		return ProcessDetail{}, fmt.Errorf("プロセス %s が見つかりません", pid)
+	}
+
+	fields := strings.Fields(strings.TrimSpace(string(output)))
+	if len(fields) < 9 {
+		return ProcessDetail{}, fmt.Errorf("プロセス %s の情報を解析できません", pid)
+	}
+
+	detail := ProcessDetail{
+		PID:     fields[0],
+		PPID:    fields[1],
+		User:    fields[2],
+		State:   fields[3],
+		Elapsed: fields[7],
+		Command: strings.Join(fields[8:], " "),
+	}
+	detail.CPU, _ = strconv.ParseFloat(fields[4], 64)
+	detail.MemPerc, _ = strconv.ParseFloat(fields[5], 64)
+	detail.RSSKB, _ = strconv.ParseInt(fields[6], 10, 64)
+
+	if output, err := RunCommandWithTimeout("ps", "-o", "nlwp=", "-p", pid); err == nil {
+		detail.Threads, _ = strconv.Atoi(strings.TrimSpace(string(output)))
+	}
+	if output, err := RunCommandWithTimeout("pgrep", "-P", pid); err == nil {
+		detail.Children = strings.Fields(string(output))
+	}
+	detail.Cwd = getProcessCwd(pid)
+
+	for _, port := range GetListeningPorts() {
+		if port.PID == pid {
+			detail.Ports = append(detail.Ports, port)
+		}
+	}
+	return detail, nil
+}
@@ -126,8 +126,11 @@ func getShortProcessName(fullName string) string {
 func isDevProcess(name string) bool {
 	devKeywords := []string{
 		"docker", "Docker",
+		"podman", "nerdctl",
 		"node", "Node",
 		"python", "Python",
+		"java", "gradle",
+		"ruby", "php", "deno",
 		"postgres", "PostgreSQL",
 		"mysql", "MySQL",
 		"redis", "Redis",
@@ -172,3 +175,40 @@ func FormatDevProcesses(processes []ProcessInfo) string {
 
 	return result.String()
 }
+
+// processEntry is a row of the process table (PID, parent PID and full command line)
+type processEntry struct {
+	PID     string
+	PPID    string
+	Command string
+}
+
+// getProcessTable returns all processes with parent PID and full command line
+func getProcessTable() []processEntry {
+	output, err := RunCommandWithTimeout("ps", "axww", "-o", "pid=,ppid=,command=")
+	if err != nil {
+		return nil
+	}
+
+	var entries []processEntry
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+
+		// コマンドラインは空白を含むため、PID・PPIDの後ろをそのまま使う
+		rest := strings.TrimSpace(line)
+		for _, f := range fields[:2] {
+			rest = strings.TrimSpace(strings.TrimPrefix(rest, f))
+		}
+
+		entries = append(entries, processEntry{
+			PID:     fields[0],
+			PPID:    fields[1],
+			Command: rest,
+		})
+	}
+
+	return entries
+}
@@ -16,6 +16,7 @@ type PythonProcess struct {
 	CPUPerc     string
 	MemUsage    string
 	Port        string
+	Env         PythonEnvironment // インタプリタ・仮想環境・依存関係の状態
 }
 
 // CheckPython checks if Python process is running
@@ -178,6 +179,12 @@ func GetPythonProcesses() []PythonProcess {
 		return []PythonProcess{}
 	}
 
+	// コマンドラインはプロセス一覧からまとめて取得
+	commands := make(map[string]string)
+	for _, entry := range getProcessTable() {
+		commands[entry.PID] = entry.Command
+	}
+
 	var processes []PythonProcess
 
 	for _, pid := range pids {
@@ -233,6 +240,7 @@ func GetPythonProcesses() []PythonProcess {
 			CPUPerc:     fmt.Sprintf("%.1f%%", stats.CPU),
 			MemUsage:    fmt.Sprintf("%.1fMB", float64(stats.Memory)/1024.0),
 			Port:        port,
+			Env:         detectPythonProcessEnvironment(pid, commands[pid], projectDir),
 		})
 	}
 
@@ -0,0 +1,536 @@
+package monitor
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// PythonEnvironment represents interpreter, virtualenv and dependency status of a Python project
+type PythonEnvironment struct {
+	Interpreter     string   // 実行ファイルのパス
+	Version         string   // 例: "3.12.1"
+	EnvType         string   // "venv" / "poetry" / "uv" / "conda" / "pipenv" / "system"
+	EnvPath         string   // 仮想環境のルート（systemの場合は空）
+	DependencyFiles []string // 検出した依存定義ファイル（requirements.txt, pyproject.toml, poetry.lock, uv.lock）
+	Dependencies    []string // 直接の依存パッケージ名
+	Missing         []string // 宣言されているが未インストールのパッケージ
+	Mismatched      []string // バージョンが一致しないパッケージ（例: "django (要求 4.2.7 / 実際 5.0.1)"）
+	Checked         bool     // インストール済みパッケージと照合できたか
+}
+
+// InSync reports whether installed packages match the declared dependencies
+func (e PythonEnvironment) InSync() bool {
+	return e.Checked && len(e.Missing) == 0 && len(e.Mismatched) == 0
+}
+
+// pythonRequirement は依存定義の1件（Pinは == で固定されたバージョン）
+type pythonRequirement struct {
+	Name string
+	Pin  string
+}
+
+// pythonRequirementPattern は "django[argon2]==4.2.7 ; python_version>'3.8'" のような行から名前と固定バージョンを取り出す
+var pythonRequirementPattern = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(\[[^\]]*\])?\s*(==\s*([^\s,;]+))?`)
+
+// pythonNameNormalizer はPEP 503のパッケージ名正規化に使う
+var pythonNameNormalizer = regexp.MustCompile(`[-_.]+`)
+
+// pyproject.toml の簡易パース用
+var (
+	tomlQuotedPattern   = regexp.MustCompile(`"([^"]+)"|'([^']+)'`)
+	exactVersionPattern = regexp.MustCompile(`^[0-9][0-9A-Za-z.]*$`)
+)
+
+// pythonManifests はPythonプロジェクトのルートの目印となるファイル
+var pythonManifests = []string{"pyproject.toml", "requirements.txt", "poetry.lock", "uv.lock"}
+
+// venvDirNames はプロジェクト直下で仮想環境として探すディレクトリ名
+var venvDirNames = []string{".venv", "venv", "env", ".env"}
+
+// InspectPythonProject inspects virtualenv and dependency status of a project directory
+// プロセスを伴わない場合（AI分析など）はプロジェクト直下の仮想環境を対象にします
+func InspectPythonProject(projectDir string) PythonEnvironment {
+	envPath := ""
+	for _, name := range venvDirNames {
+		candidate := filepath.Join(projectDir, name)
+		if _, err := os.Stat(filepath.Join(candidate, "pyvenv.cfg")); err == nil {
+			envPath = candidate
+			break
+		}
+	}
+
+	interpreter := ""
+	if envPath != "" {
+		interpreter = filepath.Join(envPath, "bin", "python")
+	}
+
+	return inspectPythonEnvironment(projectDir, interpreter, envPath)
+}
+
+// detectPythonProcessEnvironment detects interpreter and virtualenv of a running Python process
+func detectPythonProcessEnvironment(pid, cmdLine, projectDir string) PythonEnvironment {
+	interpreter := ""
+	if fields := strings.Fields(cmdLine); len(fields) > 0 && filepath.IsAbs(fields[0]) {
+		interpreter = fields[0]
+	}
+
+	// 実行ファイルのパスから仮想環境を判定（<env>/bin/python）
+	envPath := ""
+	if interpreter != "" {
+		root := filepath.Dir(filepath.Dir(interpreter))
+		if isPythonEnvRoot(root) {
+			envPath = root
+		}
+	}
+
+	// 有効化された仮想環境（環境変数）から判定
+	if envPath == "" {
+		env := getProcessEnv(pid)
+		for _, key := range []string{"VIRTUAL_ENV", "CONDA_PREFIX"} {
+			if value := env[key]; value != "" && isPythonEnvRoot(value) {
+				envPath = value
+				break
+			}
+		}
+	}
+
+	if interpreter == "" {
+		if envPath != "" {
+			interpreter = filepath.Join(envPath, "bin", "python")
+		} else {
+			interpreter = getProcessExecutable(pid)
+		}
+	}
+
+	// カレントディレクトリがサブディレクトリの場合に備えて依存定義のある場所まで遡る
+	return inspectPythonEnvironment(findProjectRoot(projectDir, pythonManifests), interpreter, envPath)
+}
+
+// inspectPythonEnvironment collects version, env type and dependency status
+func inspectPythonEnvironment(projectDir, interpreter, envPath string) PythonEnvironment {
+	env := PythonEnvironment{
+		Interpreter: interpreter,
+		EnvType:     "system",
+		EnvPath:     envPath,
+	}
+
+	if envPath != "" {
+		env.EnvType = classifyPythonEnv(projectDir, envPath)
+		env.Version = readPyvenvVersion(envPath)
+	}
+	if env.Version == "" && interpreter != "" {
+		env.Version = getPythonVersion(interpreter)
+	}
+
+	if projectDir == "" {
+		return env
+	}
+
+	// 依存定義を読み込む
+	requirements, pins, files := readPythonDependencies(projectDir)
+	env.DependencyFiles = files
+	for _, req := range requirements {
+		env.Dependencies = append(env.Dependencies, req.Name)
+	}
+
+	// システムのPythonはプロジェクトと無関係なパッケージが多いため照合しない
+	if envPath == "" || len(files) == 0 {
+		return env
+	}
+
+	installed := listInstalledPythonPackages(envPath)
+	if installed == nil {
+		return env
+	}
+	env.Checked = true
+
+	// 直接の依存が未インストールか
+	for _, req := range requirements {
+		version, ok := installed[normalizePythonName(req.Name)]
+		if !ok {
+			env.Missing = append(env.Missing, req.Name)
+			continue
+		}
+		if req.Pin != "" && req.Pin != version {
+			env.Mismatched = append(env.Mismatched, fmt.Sprintf("%s (要求 %s / 実際 %s)", req.Name, req.Pin, version))
+		}
+	}
+
+	// ロックファイルのバージョンとインストール済みのバージョンを照合
+	// （ロックファイルには別プラットフォーム用のパッケージも含まれるため、未インストールは対象外）
+	var lockedNames []string
+	for name := range pins {
+		lockedNames = append(lockedNames, name)
+	}
+	sort.Strings(lockedNames)
+	for _, name := range lockedNames {
+		version, ok := installed[name]
+		if ok && version != pins[name] {
+			env.Mismatched = append(env.Mismatched, fmt.Sprintf("%s (ロック %s / 実際 %s)", name, pins[name], version))
+		}
+	}
+
+	return env
+}
+
+// isPythonEnvRoot reports whether dir is a virtualenv or conda environment
+func isPythonEnvRoot(dir string) bool {
+	if _, err := os.Stat(filepath.Join(dir, "pyvenv.cfg")); err == nil {
+		return true
+	}
+	if _, err := os.Stat(filepath.Join(dir, "conda-meta")); err == nil {
+		return true
+	}
+	return false
+}
+
+// classifyPythonEnv returns which tool manages the environment
+func classifyPythonEnv(projectDir, envPath string) string {
+	if _, err := os.Stat(filepath.Join(envPath, "conda-meta")); err == nil {
+		return "conda"
+	}
+	if strings.Contains(envPath, "pypoetry/virtualenvs") {
+		return "poetry"
+	}
+	if strings.Contains(envPath, ".local/share/virtualenvs") {
+		return "pipenv"
+	}
+
+	// uvが作成したvenvはpyvenv.cfgに "uv = <version>" が書かれる
+	if cfg := readPyvenvConfig(envPath); cfg["uv"] != "" {
+		return "uv"
+	}
+
+	// プロジェクト内のvenvはロックファイルで判定
+	if projectDir != "" {
+		if _, err := os.Stat(filepath.Join(projectDir, "poetry.lock")); err == nil {
+			return "poetry"
+		}
+		if _, err := os.Stat(filepath.Join(projectDir, "uv.lock")); err == nil {
+			return "uv"
+		}
+	}
+
+	return "venv"
+}
+
+// readPyvenvConfig reads key = value pairs of pyvenv.cfg
+func readPyvenvConfig(envPath string) map[string]string {
+	config := make(map[string]string)
+
+	data, err := os.ReadFile(filepath.Join(envPath, "pyvenv.cfg"))
+	if err != nil {
+		return config
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) == 2 {
+			config[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+		}
+	}
+
+	return config
+}
+
+// readPyvenvVersion returns Python version recorded in pyvenv.cfg
+func readPyvenvVersion(envPath string) string {
+	cfg := readPyvenvConfig(envPath)
+	// venvは "version"、uvは "version_info" に記録する
+	for _, key := range []string{"version", "version_info"} {
+		if v := cfg[key]; v != "" {
+			// "3.12.1.final.0" のような形式は先頭3要素に揃える
+			parts := strings.Split(v, ".")
+			if len(parts) > 3 {
+				parts = parts[:3]
+			}
+			return strings.Join(parts, ".")
+		}
+	}
+	return ""
+}
+
+// getPythonVersion runs "<interpreter> --version"
+func getPythonVersion(interpreter string) string {
+	// プロセスのコマンドライン由来のパスのため、Python以外の実行ファイルは起動しない
+	if !strings.HasPrefix(filepath.Base(interpreter), "python") {
+		return ""
+	}
+
+	output, err := RunCommandWithTimeout(interpreter, "--version")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(string(output)), "Python"))
+}
+
+// getProcessEnv returns environment variables of a process (own processes only)
+func getProcessEnv(pid string) map[string]string {
+	env := make(map[string]string)
+
+	// "ps eww" はコマンドラインの後ろに環境変数を KEY=VALUE 形式で出力する
+	output, err := RunCommandWithTimeout("ps", "eww", "-o", "command=", "-p", pid)
+	if err != nil {
+		return env
+	}
+
+	for _, field := range strings.Fields(string(output)) {
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) == 2 && kv[0] != "" && strings.ToUpper(kv[0]) == kv[0] {
+			env[kv[0]] = kv[1]
+		}
+	}
+
+	return env
+}
+
+// getProcessExecutable returns the executable path of a process
+func getProcessExecutable(pid string) string {
+	// Linux
+	if path, err := os.Readlink(filepath.Join("/proc", pid, "exe")); err == nil {
+		return path
+	}
+
+	// macOS: lsofの "txt" のうち最初のもの
+	output, err := RunCommandWithTimeout("lsof", "-a", "-d", "txt", "-p", pid)
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(output), "\n") {
+		if strings.Contains(line, " txt ") {
+			fields := strings.Fields(line)
+			return fields[len(fields)-1]
+		}
+	}
+
+	return ""
+}
+
+// readPythonDependencies reads declared dependencies and locked versions of a project
+// 戻り値: 直接の依存、ロックファイルのバージョン（正規化名 -> バージョン）、検出したファイル
+func readPythonDependencies(projectDir string) ([]pythonRequirement, map[string]string, []string) {
+	var requirements []pythonRequirement
+	pins := make(map[string]string)
+	var files []string
+
+	if reqs, ok := readRequirementsTxt(filepath.Join(projectDir, "requirements.txt")); ok {
+		requirements = append(requirements, reqs...)
+		files = append(files, "requirements.txt")
+	}
+
+	if reqs, ok := readPyprojectDependencies(filepath.Join(projectDir, "pyproject.toml")); ok {
+		// requirements.txt と重複する場合は requirements.txt を優先
+		seen := make(map[string]bool)
+		for _, req := range requirements {
+			seen[normalizePythonName(req.Name)] = true
+		}
+		for _, req := range reqs {
+			if !seen[normalizePythonName(req.Name)] {
+				requirements = append(requirements, req)
+			}
+		}
+		files = append(files, "pyproject.toml")
+	}
+
+	for _, lockFile := range []string{"poetry.lock", "uv.lock"} {
+		if locked, ok := readPythonLockFile(filepath.Join(projectDir, lockFile)); ok {
+			for name, version := range locked {
+				pins[name] = version
+			}
+			files = append(files, lockFile)
+		}
+	}
+
+	return requirements, pins, files
+}
+
+// readRequirementsTxt parses requirements.txt
+func readRequirementsTxt(path string) ([]pythonRequirement, bool) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, false
+	}
+	defer file.Close()
+
+	var requirements []pythonRequirement
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		// コメント、オプション（-r, -e, --index-url 等）、URL指定は対象外
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") || strings.Contains(line, "://") {
+			continue
+		}
+
+		if req, ok := parsePythonRequirement(line); ok {
+			requirements = append(requirements, req)
+		}
+	}
+
+	return requirements, true
+}
+
+// readPyprojectDependencies parses [project].dependencies and [tool.poetry.dependencies] of pyproject.toml
+// TOMLパーサーを使わず、依存の宣言部分だけを簡易的に読み取ります
+func readPyprojectDependencies(path string) ([]pythonRequirement, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	var requirements []pythonRequirement
+	section := ""
+	inArray := false
+
+	for _, rawLine := range strings.Split(string(data), "\n") {
+		line := strings.TrimSpace(rawLine)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && !inArray {
+			section = strings.Trim(line, "[] ")
+			continue
+		}
+
+		switch section {
+		case "project":
+			// dependencies = [ "django>=4.2", "requests" ]
+			if strings.HasPrefix(line, "dependencies") && strings.Contains(line, "[") {
+				inArray = true
+				line = line[strings.Index(line, "[")+1:]
+			}
+			if !inArray {
+				continue
+			}
+
+			// extras（"django[argon2]"）の括弧と区別するため、文字列を除いて閉じ括弧を探す
+			closing := strings.Contains(tomlQuotedPattern.ReplaceAllString(line, ""), "]")
+			for _, quoted := range tomlQuotedPattern.FindAllStringSubmatch(line, -1) {
+				value := quoted[1] + quoted[2]
+				if req, ok := parsePythonRequirement(value); ok {
+					requirements = append(requirements, req)
+				}
+			}
+			if closing {
+				inArray = false
+			}
+
+		case "tool.poetry.dependencies":
+			// django = "^4.2" / django = { version = "4.2.7", extras = [...] }
+			kv := strings.SplitN(line, "=", 2)
+			if len(kv) != 2 {
+				continue
+			}
+			name := strings.Trim(strings.TrimSpace(kv[0]), `"'`)
+			if name == "" || name == "python" {
+				continue
+			}
+
+			pin := ""
+			value := strings.Trim(strings.TrimSpace(kv[1]), `"'`)
+			if exactVersionPattern.MatchString(value) {
+				pin = value
+			}
+			requirements = append(requirements, pythonRequirement{Name: name, Pin: pin})
+		}
+	}
+
+	return requirements, true
+}
+
+// readPythonLockFile reads [[package]] name/version pairs of poetry.lock or uv.lock
+func readPythonLockFile(path string) (map[string]string, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	locked := make(map[string]string)
+	inPackage := false
+	name := ""
+
+	for _, rawLine := range strings.Split(string(data), "\n") {
+		line := strings.TrimSpace(rawLine)
+
+		if strings.HasPrefix(line, "[") {
+			inPackage = line == "[[package]]"
+			name = ""
+			continue
+		}
+		if !inPackage {
+			continue
+		}
+
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		key := strings.TrimSpace(kv[0])
+		value := strings.Trim(strings.TrimSpace(kv[1]), `"`)
+
+		switch key {
+		case "name":
+			name = normalizePythonName(value)
+		case "version":
+			if name != "" {
+				locked[name] = value
+			}
+		}
+	}
+
+	return locked, true
+}
+
+// parsePythonRequirement parses a PEP 508 requirement string
+func parsePythonRequirement(value string) (pythonRequirement, bool) {
+	m := pythonRequirementPattern.FindStringSubmatch(strings.TrimSpace(value))
+	if m == nil {
+		return pythonRequirement{}, false
+	}
+	return pythonRequirement{Name: m[1], Pin: m[4]}, true
+}
+
+// listInstalledPythonPackages returns installed packages (normalized name -> version) of an environment
+// pipを起動せず、site-packages の *.dist-info を直接読み取ります
+func listInstalledPythonPackages(envPath string) map[string]string {
+	patterns := []string{
+		filepath.Join(envPath, "lib", "python*", "site-packages", "*.dist-info"),
+		filepath.Join(envPath, "Lib", "site-packages", "*.dist-info"),
+	}
+
+	var matches []string
+	for _, pattern := range patterns {
+		found, _ := filepath.Glob(pattern)
+		matches = append(matches, found...)
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+
+	installed := make(map[string]string)
+	for _, match := range matches {
+		// "Django-5.0.1.dist-info" -> Django, 5.0.1
+		base := strings.TrimSuffix(filepath.Base(match), ".dist-info")
+		i := strings.LastIndex(base, "-")
+		if i <= 0 {
+			continue
+		}
+		installed[normalizePythonName(base[:i])] = base[i+1:]
+	}
+
+	return installed
+}
+
+// normalizePythonName normalizes a package name (PEP 503)
+func normalizePythonName(name string) string {
+	return strings.ToLower(pythonNameNormalizer.ReplaceAllString(name, "-"))
+}
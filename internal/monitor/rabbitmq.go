@@ -0,0 +1,219 @@
+package monitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/config"
+)
+
+// RabbitMQOverview represents cluster-wide metrics from /api/overview
+type RabbitMQOverview struct {
+	IsReachable     bool
+	Version         string
+	ClusterName     string
+	MessagesReady   int64
+	MessagesUnacked int64
+	PublishRate     float64 // msg/s
+	DeliverRate     float64 // msg/s
+	Connections     int64
+	Consumers       int64
+	Container       string // コンテナで動作している場合のコンテナ名
+	Error           string // 接続できない場合のエラー内容
+}
+
+// RabbitMQQueue represents a queue from /api/queues
+type RabbitMQQueue struct {
+	Name            string
+	VHost           string
+	State           string
+	Messages        int64
+	MessagesReady   int64
+	MessagesUnacked int64
+	Consumers       int64
+	PublishRate     float64
+	DeliverRate     float64
+}
+
+// RabbitMQQueueBacklogThreshold は滞留とみなすメッセージ数
+const RabbitMQQueueBacklogThreshold = 1000
+
+// CheckRabbitMQ checks if RabbitMQ is running (local process or container)
+func CheckRabbitMQ() string {
+	// ローカルプロセス（Erlang VM上で動作するため -f で検索）
+	if _, err := RunCommandWithTimeout("pgrep", "-f", "rabbitmq"); err == nil {
+		stats := getMultiProcessStats("beam.smp")
+
+		result := "✓ RabbitMQ: 実行中"
+		if statsStr := formatStatsString(stats); statsStr != "" {
+			result += fmt.Sprintf(" | %s", statsStr)
+		}
+		return result
+	}
+
+	// コンテナで動作しているか確認
+	container := FindContainerByImage("rabbitmq")
+	if container == nil {
+		return "✗ RabbitMQ: 停止中"
+	}
+
+	portInfo := ""
+	if port := getContainerPort(container.ID); port != "" {
+		portInfo = " [:" + port + "]"
+	}
+
+	result := fmt.Sprintf("✓ RabbitMQ: 実行中 (コンテナ: %s)%s", container.Name, portInfo)
+
+	stats := GetDockerContainerStats(container.ID)
+	if statsStr := formatDockerStatsString(stats); statsStr != "" {
+		result += fmt.Sprintf(" | %s", statsStr)
+	}
+
+	return result
+}
+
+// IsRabbitMQRunning reports whether RabbitMQ is running locally or in a container
+func IsRabbitMQRunning() bool {
+	if _, err := RunCommandWithTimeout("pgrep", "-f", "rabbitmq"); err == nil {
+		return true
+	}
+	return FindContainerByImage("rabbitmq") != nil
+}
+
+// rabbitMQRequest sends a request to the management HTTP API
+func rabbitMQRequest(cfg config.RabbitMQConfig, method, path string) ([]byte, error) {
+	return doHTTPRequest(method, strings.TrimRight(cfg.URL, "/")+path, cfg.User, cfg.Password)
+}
+
+// GetRabbitMQOverview returns cluster-wide metrics
+func GetRabbitMQOverview() RabbitMQOverview {
+	overview := getRabbitMQOverview(config.Load().RabbitMQ)
+	if !overview.IsReachable {
+		return overview
+	}
+
+	if container := FindContainerByImage("rabbitmq"); container != nil {
+		overview.Container = container.Name
+	}
+
+	return overview
+}
+
+// getRabbitMQOverview reads cluster-wide metrics from the management API of cfg
+func getRabbitMQOverview(cfg config.RabbitMQConfig) RabbitMQOverview {
+	body, err := rabbitMQRequest(cfg, http.MethodGet, "/api/overview")
+	if err != nil {
+		return RabbitMQOverview{IsReachable: false, Error: err.Error()}
+	}
+
+	var result struct {
+		RabbitMQVersion string `json:"rabbitmq_version"`
+		ClusterName     string `json:"cluster_name"`
+		QueueTotals     struct {
+			MessagesReady   int64 `json:"messages_ready"`
+			MessagesUnacked int64 `json:"messages_unacknowledged"`
+		} `json:"queue_totals"`
+		MessageStats struct {
+			PublishDetails struct {
+				Rate float64 `json:"rate"`
+			} `json:"publish_details"`
+			DeliverGetDetails struct {
+				Rate float64 `json:"rate"`
+			} `json:"deliver_get_details"`
+		} `json:"message_stats"`
+		ObjectTotals struct {
+			Connections int64 `json:"connections"`
+			Consumers   int64 `json:"consumers"`
+		} `json:"object_totals"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return RabbitMQOverview{IsReachable: false, Error: err.Error()}
+	}
+
+	return RabbitMQOverview{
+		IsReachable:     true,
+		Version:         result.RabbitMQVersion,
+		ClusterName:     result.ClusterName,
+		MessagesReady:   result.QueueTotals.MessagesReady,
+		MessagesUnacked: result.QueueTotals.MessagesUnacked,
+		PublishRate:     result.MessageStats.PublishDetails.Rate,
+		DeliverRate:     result.MessageStats.DeliverGetDetails.Rate,
+		Connections:     result.ObjectTotals.Connections,
+		Consumers:       result.ObjectTotals.Consumers,
+	}
+}
+
+// GetRabbitMQQueues returns queues ordered as returned by the management API
+func GetRabbitMQQueues() []RabbitMQQueue {
+	queues, err := getRabbitMQQueues(config.Load().RabbitMQ)
+	if err != nil {
+		return []RabbitMQQueue{}
+	}
+	return queues
+}
+
+// getRabbitMQQueues reads the queues from the management API of cfg
+func getRabbitMQQueues(cfg config.RabbitMQConfig) ([]RabbitMQQueue, error) {
+	body, err := rabbitMQRequest(cfg, http.MethodGet, "/api/queues")
+	if err != nil {
+		return nil, err
+	}
+
+	var result []struct {
+		Name            string `json:"name"`
+		VHost           string `json:"vhost"`
+		State           string `json:"state"`
+		Messages        int64  `json:"messages"`
+		MessagesReady   int64  `json:"messages_ready"`
+		MessagesUnacked int64  `json:"messages_unacknowledged"`
+		Consumers       int64  `json:"consumers"`
+		MessageStats    struct {
+			PublishDetails struct {
+				Rate float64 `json:"rate"`
+			} `json:"publish_details"`
+			DeliverGetDetails struct {
+				Rate float64 `json:"rate"`
+			} `json:"deliver_get_details"`
+		} `json:"message_stats"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	var queues []RabbitMQQueue
+	for _, q := range result {
+		queues = append(queues, RabbitMQQueue{
+			Name:            q.Name,
+			VHost:           q.VHost,
+			State:           q.State,
+			Messages:        q.Messages,
+			MessagesReady:   q.MessagesReady,
+			MessagesUnacked: q.MessagesUnacked,
+			Consumers:       q.Consumers,
+			PublishRate:     q.MessageStats.PublishDetails.Rate,
+			DeliverRate:     q.MessageStats.DeliverGetDetails.Rate,
+		})
+	}
+
+	return queues, nil
+}
+
+// rabbitMQQueueKeySeparator はキュー識別子のvhostとキュー名の区切り
+// vhost（デフォルトは "/"）とキュー名のどちらにも "/" を含められるため改行を使う
+const rabbitMQQueueKeySeparator = "\n"
+
+// RabbitMQQueueKey returns an identifier of a queue built from vhost and name
+func RabbitMQQueueKey(vhost, name string) string {
+	return vhost + rabbitMQQueueKeySeparator + name
+}
+
+// splitRabbitMQQueueKey splits an identifier built by RabbitMQQueueKey
+func splitRabbitMQQueueKey(key string) (vhost, name string, ok bool) {
+	parts := strings.SplitN(key, rabbitMQQueueKeySeparator, 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
@@ -0,0 +1,110 @@
+package monitor
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/config"
+)
+
+// newRabbitMQServer returns a management API that accepts only guest/guest
+func newRabbitMQServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, ok := r.BasicAuth(); !ok || user != "guest" || password != "guest" {
+			http.Error(w, `{"error":"not_authorised"}`, http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/overview":
+			fmt.Fprint(w, `{
+				"rabbitmq_version": "3.13.1",
+				"cluster_name": "rabbit@dev",
+				"queue_totals": {"messages_ready": 1500, "messages_unacknowledged": 3},
+				"message_stats": {"publish_details": {"rate": 12.5}, "deliver_get_details": {"rate": 10.0}},
+				"object_totals": {"connections": 2, "consumers": 4}
+			}`)
+		case "/api/queues":
+			fmt.Fprint(w, `[
+				{"name": "orders", "vhost": "/", "state": "running", "messages": 1503, "messages_ready": 1500,
+				 "messages_unacknowledged": 3, "consumers": 1,
+				 "message_stats": {"publish_details": {"rate": 12.5}, "deliver_get_details": {"rate": 10.0}}},
+				{"name": "idle", "vhost": "staging", "state": "idle"}
+			]`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestGetRabbitMQOverview(t *testing.T) {
+	srv := newRabbitMQServer(t)
+
+	got := getRabbitMQOverview(config.RabbitMQConfig{URL: srv.URL + "/", User: "guest", Password: "guest"})
+	want := RabbitMQOverview{
+		IsReachable:     true,
+		Version:         "3.13.1",
+		ClusterName:     "rabbit@dev",
+		MessagesReady:   1500,
+		MessagesUnacked: 3,
+		PublishRate:     12.5,
+		DeliverRate:     10.0,
+		Connections:     2,
+		Consumers:       4,
+	}
+	if got != want {
+		t.Errorf("overview = %+v, want %+v", got, want)
+	}
+}
+
+func TestGetRabbitMQQueues(t *testing.T) {
+	srv := newRabbitMQServer(t)
+
+	queues, err := getRabbitMQQueues(config.RabbitMQConfig{URL: srv.URL, User: "guest", Password: "guest"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []RabbitMQQueue{
+		{Name: "orders", VHost: "/", State: "running", Messages: 1503, MessagesReady: 1500, MessagesUnacked: 3,
+			Consumers: 1, PublishRate: 12.5, DeliverRate: 10.0},
+		// メッセージの統計がないキュー
+		{Name: "idle", VHost: "staging", State: "idle"},
+	}
+	if len(queues) != len(want) {
+		t.Fatalf("got %d queues, want %d", len(queues), len(want))
+	}
+	for i := range want {
+		if queues[i] != want[i] {
+			t.Errorf("queue %d = %+v, want %+v", i, queues[i], want[i])
+		}
+	}
+}
+
+func TestRabbitMQErrors(t *testing.T) {
+	srv := newRabbitMQServer(t)
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	tests := []struct {
+		name    string
+		cfg     config.RabbitMQConfig
+		wantErr string
+	}{
+		{"bad credentials", config.RabbitMQConfig{URL: srv.URL, User: "guest", Password: "wrong"}, "401"},
+		{"unreachable", config.RabbitMQConfig{URL: closed.URL, User: "guest", Password: "guest"}, "接続できません"},
+	}
+	for _, tt := range tests {
+		overview := getRabbitMQOverview(tt.cfg)
+		if overview.IsReachable || !strings.Contains(overview.Error, tt.wantErr) {
+			t.Errorf("%s: overview = %+v, want an error containing %q", tt.name, overview, tt.wantErr)
+		}
+		if queues, err := getRabbitMQQueues(tt.cfg); err == nil || !strings.Contains(err.Error(), tt.wantErr) || len(queues) != 0 {
+			t.Errorf("%s: queues = %+v, %v, want an error containing %q", tt.name, queues, err, tt.wantErr)
+		}
+	}
+}
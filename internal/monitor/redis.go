@@ -3,7 +3,10 @@ package monitor
 import (
 	"fmt"
 	"os/exec"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // RedisDatabase represents a Redis database
@@ -12,6 +15,44 @@ type RedisDatabase struct {
 	KeysNum string
 }
 
+// RedisServerInfo represents metrics from INFO server/memory/clients/stats
+type RedisServerInfo struct {
+	IsReachable      bool
+	Version          string
+	Uptime           string
+	UsedMemory       string
+	UsedMemoryPeak   string
+	MaxMemory        string
+	MaxMemoryPolicy  string
+	Fragmentation    string // mem_fragmentation_ratio
+	ConnectedClients string
+	BlockedClients   string
+	OpsPerSec        string
+	EvictedKeys      string
+	ExpiredKeys      string
+	HitRate          string // keyspace_hits / (hits + misses)
+	Error            string // 接続できない場合のエラー内容
+}
+
+// RedisSlowLogEntry represents an entry of SLOWLOG GET
+type RedisSlowLogEntry struct {
+	ID        string
+	Timestamp time.Time
+	Duration  time.Duration
+	Command   string
+}
+
+// RedisKey represents a key found by SCAN
+type RedisKey struct {
+	Name string
+	Type string
+	TTL  string
+	Size string // MEMORY USAGE
+}
+
+// RedisKeyScanLimit はキーブラウザで一度に取得するキーの上限
+const RedisKeyScanLimit = 100
+
 // CheckRedis checks if Redis is running
 func CheckRedis() string {
 	cmd := exec.Command("pgrep", "redis-server")
@@ -45,34 +86,22 @@ func CheckRedis() string {
 // GetRedisDatabases returns list of Redis databases
 func GetRedisDatabases() []RedisDatabase {
 	// Redis INFOコマンドでデータベース情報を取得
-	cmd := exec.Command("redis-cli", "INFO", "keyspace")
-	output, err := cmd.Output()
-
+	info, err := redisInfo("keyspace")
 	if err != nil {
 		return []RedisDatabase{}
 	}
 
 	var databases []RedisDatabase
-	lines := strings.Split(string(output), "\n")
 
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if !strings.HasPrefix(line, "db") {
+	for key, value := range info {
+		if !strings.HasPrefix(key, "db") {
 			continue
 		}
 
 		// db0:keys=100,expires=0,avg_ttl=0 のような形式
-		parts := strings.Split(line, ":")
-		if len(parts) != 2 {
-			continue
-		}
-
-		dbIndex := parts[0]
-		info := parts[1]
-
 		// keys=100 を抽出
 		keysNum := "0"
-		infoParts := strings.Split(info, ",")
+		infoParts := strings.Split(value, ",")
 		for _, part := range infoParts {
 			if strings.HasPrefix(part, "keys=") {
 				keysNum = strings.TrimPrefix(part, "keys=")
@@ -81,10 +110,225 @@ func GetRedisDatabases() []RedisDatabase {
 		}
 
 		databases = append(databases, RedisDatabase{
-			Index:   dbIndex,
+			Index:   key,
 			KeysNum: keysNum + " keys",
 		})
 	}
 
+	// db番号順にソート
+	sort.Slice(databases, func(i, j int) bool {
+		return redisDBNumber(databases[i].Index) < redisDBNumber(databases[j].Index)
+	})
+
 	return databases
 }
+
+// redisDBNumber converts "db3" to 3
+func redisDBNumber(index string) int {
+	n, err := strconv.Atoi(strings.TrimPrefix(index, "db"))
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+// redisInfo runs INFO for the given sections and returns key/value pairs
+func redisInfo(sections ...string) (map[string]string, error) {
+	conn, err := dialRedis(0)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	reply, err := conn.Do(append([]string{"INFO"}, sections...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string)
+	for _, line := range strings.Split(redisString(reply), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) == 2 {
+			values[parts[0]] = parts[1]
+		}
+	}
+
+	return values, nil
+}
+
+// GetRedisServerInfo returns memory, clients and stats metrics
+func GetRedisServerInfo() RedisServerInfo {
+	// Redis 7以降は複数セクション指定に対応しているが、互換性のため引数なし（default）で取得
+	values, err := redisInfo()
+	if err != nil {
+		return RedisServerInfo{IsReachable: false, Error: err.Error()}
+	}
+
+	info := RedisServerInfo{
+		IsReachable:      true,
+		Version:          values["redis_version"],
+		UsedMemory:       values["used_memory_human"],
+		UsedMemoryPeak:   values["used_memory_peak_human"],
+		MaxMemory:        values["maxmemory_human"],
+		MaxMemoryPolicy:  values["maxmemory_policy"],
+		Fragmentation:    values["mem_fragmentation_ratio"],
+		ConnectedClients: values["connected_clients"],
+		BlockedClients:   values["blocked_clients"],
+		OpsPerSec:        values["instantaneous_ops_per_sec"],
+		EvictedKeys:      values["evicted_keys"],
+		ExpiredKeys:      values["expired_keys"],
+	}
+
+	// maxmemory=0 は無制限
+	if values["maxmemory"] == "0" {
+		info.MaxMemory = "無制限"
+	}
+
+	// 稼働時間（秒）を整形
+	if secs, err := strconv.ParseInt(values["uptime_in_seconds"], 10, 64); err == nil {
+		info.Uptime = formatSeconds(secs)
+	}
+
+	// キャッシュヒット率
+	hits, _ := strconv.ParseFloat(values["keyspace_hits"], 64)
+	misses, _ := strconv.ParseFloat(values["keyspace_misses"], 64)
+	if hits+misses > 0 {
+		info.HitRate = fmt.Sprintf("%.2f%%", hits/(hits+misses)*100)
+	}
+
+	return info
+}
+
+// GetRedisSlowLog returns the latest slow log entries
+func GetRedisSlowLog(count int) []RedisSlowLogEntry {
+	conn, err := dialRedis(0)
+	if err != nil {
+		return []RedisSlowLogEntry{}
+	}
+	defer conn.Close()
+
+	reply, err := conn.Do("SLOWLOG", "GET", strconv.Itoa(count))
+	if err != nil {
+		return []RedisSlowLogEntry{}
+	}
+
+	items, ok := reply.([]interface{})
+	if !ok {
+		return []RedisSlowLogEntry{}
+	}
+
+	var entries []RedisSlowLogEntry
+	for _, item := range items {
+		// [id, timestamp, duration(μs), [args...], client addr, client name]
+		fields, ok := item.([]interface{})
+		if !ok || len(fields) < 4 {
+			continue
+		}
+
+		var args []string
+		if argList, ok := fields[3].([]interface{}); ok {
+			for _, a := range argList {
+				args = append(args, redisString(a))
+			}
+		}
+
+		entries = append(entries, RedisSlowLogEntry{
+			ID:        redisString(fields[0]),
+			Timestamp: time.Unix(redisInt(fields[1]), 0),
+			Duration:  time.Duration(redisInt(fields[2])) * time.Microsecond,
+			Command:   strings.Join(args, " "),
+		})
+	}
+
+	return entries
+}
+
+// ScanRedisKeys scans keys matching the pattern and returns their type, TTL and size
+func ScanRedisKeys(dbIndex, pattern string) ([]RedisKey, error) {
+	dbNum := redisDBNumber(dbIndex)
+	if dbNum < 0 {
+		return nil, fmt.Errorf("不正なデータベースインデックスです")
+	}
+	if pattern == "" {
+		pattern = "*"
+	}
+
+	conn, err := dialRedis(dbNum)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	// SCANでカーソルを進めながらキーを収集（KEYSはブロッキングするため使用しない）
+	var names []string
+	cursor := "0"
+	for {
+		reply, err := conn.Do("SCAN", cursor, "MATCH", pattern, "COUNT", "100")
+		if err != nil {
+			return nil, err
+		}
+
+		parts, ok := reply.([]interface{})
+		if !ok || len(parts) != 2 {
+			return nil, fmt.Errorf("SCANの応答形式が不正です")
+		}
+
+		cursor = redisString(parts[0])
+		if keys, ok := parts[1].([]interface{}); ok {
+			for _, k := range keys {
+				names = append(names, redisString(k))
+			}
+		}
+
+		if cursor == "0" || len(names) >= RedisKeyScanLimit {
+			break
+		}
+	}
+
+	if len(names) > RedisKeyScanLimit {
+		names = names[:RedisKeyScanLimit]
+	}
+
+	// 各キーの型・TTL・メモリ使用量を取得
+	var keys []RedisKey
+	for _, name := range names {
+		key := RedisKey{Name: name}
+
+		if reply, err := conn.Do("TYPE", name); err == nil {
+			key.Type = redisString(reply)
+		}
+
+		if reply, err := conn.Do("TTL", name); err == nil {
+			key.TTL = formatRedisTTL(redisInt(reply))
+		}
+
+		if reply, err := conn.Do("MEMORY", "USAGE", name); err == nil && reply != nil {
+			key.Size = formatBytes(redisInt(reply))
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// formatRedisTTL formats TTL reply (-1: no expire, -2: not found)
+func formatRedisTTL(ttl int64) string {
+	switch {
+	case ttl == -1:
+		return "なし"
+	case ttl == -2:
+		return "削除済み"
+	case ttl < 60:
+		// 期限の近いキーは秒まで表示する
+		return fmt.Sprintf("%ds", ttl)
+	case ttl < 3600:
+		return fmt.Sprintf("%dm %ds", ttl/60, ttl%60)
+	default:
+		return formatSeconds(ttl)
+	}
+}
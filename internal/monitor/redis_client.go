@@ -0,0 +1,204 @@
+package monitor
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/config"
+)
+
+// redisConn はRESPプロトコルでRedisと通信する最小限のクライアントです
+// redis-cli に依存せず、設定されたホスト・ポート・パスワードで接続します
+type redisConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// サーバーが返すサイズの上限（壊れた応答や想定外のサーバーで大きなメモリを確保しないため）
+const (
+	redisMaxBulkSize   = 64 << 20 // バルク文字列の最大バイト数
+	redisMaxArrayCount = 1 << 20  // 配列の最大要素数
+)
+
+// redisError はRedisが返したエラー応答（-ERR ...）です
+type redisError string
+
+func (e redisError) Error() string {
+	return string(e)
+}
+
+// dialRedis connects to Redis, authenticates and selects the database
+func dialRedis(db int) (*redisConn, error) {
+	cfg := config.Load().Redis
+
+	address := net.JoinHostPort(cfg.Host, cfg.Port)
+	conn, err := net.DialTimeout("tcp", address, DefaultTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("Redis接続エラー: %w", err)
+	}
+
+	c := &redisConn{
+		conn:   conn,
+		reader: bufio.NewReader(conn),
+	}
+
+	// 認証
+	if cfg.Password != "" {
+		args := []string{"AUTH", cfg.Password}
+		if cfg.Username != "" {
+			args = []string{"AUTH", cfg.Username, cfg.Password}
+		}
+		if _, err := c.Do(args...); err != nil {
+			c.Close()
+			return nil, fmt.Errorf("Redis認証エラー: %w", err)
+		}
+	}
+
+	// データベース選択
+	if db > 0 {
+		if _, err := c.Do("SELECT", strconv.Itoa(db)); err != nil {
+			c.Close()
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// Close closes the connection
+func (c *redisConn) Close() error {
+	return c.conn.Close()
+}
+
+// Do sends a command and reads its reply
+// 返り値は string / int64 / []interface{} / nil（Null応答）のいずれか
+func (c *redisConn) Do(args ...string) (interface{}, error) {
+	c.conn.SetDeadline(time.Now().Add(DefaultTimeout))
+
+	// コマンドをRESP配列としてエンコード
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("*%d\r\n", len(args)))
+	for _, arg := range args {
+		sb.WriteString(fmt.Sprintf("$%d\r\n%s\r\n", len(arg), arg))
+	}
+
+	if _, err := c.conn.Write([]byte(sb.String())); err != nil {
+		return nil, err
+	}
+
+	return c.readReply()
+}
+
+// runRedisCommand connects to the database, runs a single command and closes the connection
+func runRedisCommand(db int, args ...string) (interface{}, error) {
+	conn, err := dialRedis(db)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	return conn.Do(args...)
+}
+
+// readReply parses a single RESP reply
+func (c *redisConn) readReply() (interface{}, error) {
+	line, err := c.readLine()
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 {
+		return nil, fmt.Errorf("空の応答です")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, redisError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if size < 0 {
+			return nil, nil
+		}
+		if size > redisMaxBulkSize {
+			return nil, fmt.Errorf("応答が大きすぎます: %d bytes", size)
+		}
+		buf := make([]byte, size+2) // 末尾の\r\nを含む
+		if _, err := io.ReadFull(c.reader, buf); err != nil {
+			return nil, err
+		}
+		return string(buf[:size]), nil
+	case '*':
+		count, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if count < 0 {
+			return nil, nil
+		}
+		if count > redisMaxArrayCount {
+			return nil, fmt.Errorf("応答の要素が多すぎます: %d", count)
+		}
+		// 要素数は受信した分だけ増やす（宣言された数をそのまま確保しない）
+		items := make([]interface{}, 0, min(count, 1024))
+		for i := 0; i < count; i++ {
+			item, err := c.readReply()
+			if err != nil {
+				// 配列内のエラー応答は値として保持する
+				if rerr, ok := err.(redisError); ok {
+					items = append(items, rerr)
+					continue
+				}
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("不明な応答形式: %q", line)
+	}
+}
+
+// readLine reads a line terminated by \r\n
+func (c *redisConn) readLine() (string, error) {
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r"), nil
+}
+
+// redisString converts a reply to string
+func redisString(reply interface{}) string {
+	switch v := reply.(type) {
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	default:
+		return ""
+	}
+}
+
+// redisInt converts a reply to int64
+func redisInt(reply interface{}) int64 {
+	switch v := reply.(type) {
+	case int64:
+		return v
+	case string:
+		n, _ := strconv.ParseInt(v, 10, 64)
+		return n
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,80 @@
+package monitor
+
+import (
+	"bufio"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// replyConn returns a connection that reads the given raw reply
+func replyConn(raw string) *redisConn {
+	return &redisConn{reader: bufio.NewReader(strings.NewReader(raw))}
+}
+
+func TestRedisReadReply(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    interface{}
+		wantErr string
+	}{
+		{"simple string", "+OK\r\n", "OK", ""},
+		{"integer", ":42\r\n", int64(42), ""},
+		{"bulk", "$5\r\nhello\r\n", "hello", ""},
+		{"bulk with CRLF", "$7\r\nab\r\ncde\r\n", "ab\r\ncde", ""},
+		{"empty bulk", "$0\r\n\r\n", "", ""},
+		{"nil bulk", "$-1\r\n", nil, ""},
+		{"nil array", "*-1\r\n", nil, ""},
+		{"array", "*3\r\n$3\r\nkey\r\n:7\r\n$-1\r\n", []interface{}{"key", int64(7), nil}, ""},
+		{"nested array", "*2\r\n$1\r\n0\r\n*2\r\n$1\r\na\r\n$1\r\nb\r\n", []interface{}{"0", []interface{}{"a", "b"}}, ""},
+		// 配列内のエラー応答は値として保持する
+		{"error in array", "*2\r\n+OK\r\n-ERR no such key\r\n", []interface{}{"OK", redisError("ERR no such key")}, ""},
+		{"error", "-WRONGPASS invalid username-password pair\r\n", nil, "WRONGPASS"},
+		{"unknown type", "?what\r\n", nil, "不明な応答形式"},
+		{"empty line", "\r\n", nil, "空の応答"},
+		{"bad bulk size", "$abc\r\n", nil, "invalid syntax"},
+		// 途中で切れた応答
+		{"truncated bulk", "$10\r\nhello", nil, "EOF"},
+		{"truncated array", "*3\r\n:1\r\n", nil, "EOF"},
+		{"truncated line", "+OK", nil, "EOF"},
+		// サーバーが返すサイズの上限
+		{"bulk too large", "$999999999999\r\n", nil, "大きすぎます"},
+		{"array too long", "*999999999\r\n", nil, "多すぎます"},
+	}
+	for _, tt := range tests {
+		got, err := replyConn(tt.raw).readReply()
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%s: err = %v, want an error containing %q", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %#v, want %#v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFormatRedisTTL(t *testing.T) {
+	tests := []struct {
+		ttl  int64
+		want string
+	}{
+		{-1, "なし"},
+		{-2, "削除済み"},
+		{0, "0s"},
+		{45, "45s"},
+		{90, "1m 30s"},
+		{7200, "2h 0m"},
+	}
+	for _, tt := range tests {
+		if got := formatRedisTTL(tt.ttl); got != tt.want {
+			t.Errorf("formatRedisTTL(%d) = %q, want %q", tt.ttl, got, tt.want)
+		}
+	}
+}
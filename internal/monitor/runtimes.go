@@ -0,0 +1,459 @@
+package monitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RuntimeProcess represents a process of a language runtime (Go, Ruby, JVM, Deno/Bun, PHP)
+type RuntimeProcess struct {
+	PID         string
+	Runtime     string // メニュー名（"Go", "JVM" など）
+	ProjectDir  string
+	ProjectName string
+	Framework   string
+	Command     string
+	Uptime      string
+	CPUPerc     string
+	MemUsage    string
+	Port        string
+}
+
+// runtimeSpec はランタイムごとのプロセス検出ルール
+type runtimeSpec struct {
+	Name      string
+	Pattern   *regexp.Regexp              // 実行ファイル（argv[0]）に対する一次フィルタ
+	Detect    func(cmdLine string) string // フレームワーク判定（空文字なら対象外）
+	Manifests []string                    // プロジェクトルートの目印となるファイル
+}
+
+// runtimeSpecs はパネルとして表示するランタイムの一覧（表示順）
+var runtimeSpecs = []runtimeSpec{
+	{
+		Name:      "Go",
+		Pattern:   regexp.MustCompile(`^(\S*/go-build\S*/exe/\S+|(\S*/)?(air|dlv|__debug_bin\S*))( |$)|^(\S*/)?go run `),
+		Detect:    detectGoProcessType,
+		Manifests: []string{"go.mod"},
+	},
+	{
+		Name:      "Ruby",
+		Pattern:   regexp.MustCompile(`^(\S*/)?(ruby[0-9.]*|puma|sidekiq|unicorn|rails)( |$)`),
+		Detect:    detectRubyProcessType,
+		Manifests: []string{"Gemfile"},
+	},
+	{
+		Name:      "JVM",
+		Pattern:   regexp.MustCompile(`^(\S*/)?java( |$)`),
+		Detect:    detectJVMProcessType,
+		Manifests: []string{"pom.xml", "build.gradle", "build.gradle.kts", "settings.gradle", "settings.gradle.kts"},
+	},
+	{
+		Name:      "Deno/Bun",
+		Pattern:   regexp.MustCompile(`^(\S*/)?(deno|bun)( |$)`),
+		Detect:    detectDenoBunProcessType,
+		Manifests: []string{"deno.json", "deno.jsonc", "package.json"},
+	},
+	{
+		Name:      "PHP",
+		Pattern:   regexp.MustCompile(`^(\S*/)?php[0-9.]*( |$)|^php-fpm`),
+		Detect:    detectPHPProcessType,
+		Manifests: []string{"composer.json", "artisan"},
+	},
+}
+
+// Goツールのコマンドライン判定
+var (
+	goDelvePattern = regexp.MustCompile(`^(\S*/)?(dlv|__debug_bin\S*)( |$)`)
+	goAirPattern   = regexp.MustCompile(`^(\S*/)?air( |$)`)
+	goRunPattern   = regexp.MustCompile(`^(\S*/)?go run `)
+)
+
+// jcmd GC.heap_info の使用量行
+var (
+	jvmHeapTotalUsedPattern = regexp.MustCompile(`total (\d+)K, used (\d+)K`)
+	jvmHeapZGCPattern       = regexp.MustCompile(`used (\d+)M, capacity (\d+)M`)
+)
+
+// jcmdTimeout はjcmd（JVMへのアタッチを含む）のタイムアウト
+const jcmdTimeout = 5 * time.Second
+
+// RuntimeNames returns menu names of supported runtimes
+func RuntimeNames() []string {
+	var names []string
+	for _, spec := range runtimeSpecs {
+		names = append(names, spec.Name)
+	}
+	return names
+}
+
+// IsKnownRuntime reports whether name is a supported runtime
+func IsKnownRuntime(name string) bool {
+	return findRuntimeSpec(name) != nil
+}
+
+// findRuntimeSpec returns the spec for a runtime name
+func findRuntimeSpec(name string) *runtimeSpec {
+	for i := range runtimeSpecs {
+		if runtimeSpecs[i].Name == name {
+			return &runtimeSpecs[i]
+		}
+	}
+	return nil
+}
+
+// listRuntimeCommands returns PID → command line of processes matching the runtime
+func listRuntimeCommands(spec *runtimeSpec) ([]string, map[string]string) {
+	// pgrep -f の正規表現はOSで差があるため、psの結果をGo側で絞り込む
+	// （シェル経由の起動コマンドなどを拾わないよう、実行ファイル名で判定する）
+	var pids []string
+	commands := make(map[string]string)
+	for _, entry := range getProcessTable() {
+		if !spec.Pattern.MatchString(entry.Command) || spec.Detect(entry.Command) == "" {
+			continue
+		}
+
+		pids = append(pids, entry.PID)
+		commands[entry.PID] = entry.Command
+	}
+
+	return pids, commands
+}
+
+// IsRuntimeRunning reports whether any process of the runtime is running
+func IsRuntimeRunning(name string) bool {
+	spec := findRuntimeSpec(name)
+	if spec == nil {
+		return false
+	}
+
+	pids, _ := listRuntimeCommands(spec)
+	return len(pids) > 0
+}
+
+// CheckRuntime checks if processes of the runtime are running
+func CheckRuntime(name string) string {
+	processes := GetRuntimeProcesses(name)
+	if len(processes) == 0 {
+		return fmt.Sprintf("✗ %s: 検出なし", name)
+	}
+
+	result := fmt.Sprintf("✓ %s: 実行中 (%d個)\n", name, len(processes))
+
+	// 各プロセスの情報（最大3つまで）
+	for i, proc := range processes {
+		if i >= 3 {
+			break
+		}
+
+		dir := proc.ProjectDir
+		if dir == "" {
+			dir = proc.ProjectName
+		}
+		result += fmt.Sprintf("  └─ %s\n", dir)
+
+		infoLine := fmt.Sprintf("     (%s)", proc.Framework)
+		if proc.Port != "" {
+			infoLine += " [:" + proc.Port + "]"
+		}
+		if proc.Uptime != "" {
+			infoLine += fmt.Sprintf(" | 稼働: %s", proc.Uptime)
+		}
+		infoLine += fmt.Sprintf(" | CPU: %s | メモリ: %s", proc.CPUPerc, proc.MemUsage)
+
+		result += infoLine + "\n"
+	}
+
+	return result
+}
+
+// GetRuntimeProcesses returns list of processes of the runtime
+func GetRuntimeProcesses(name string) []RuntimeProcess {
+	spec := findRuntimeSpec(name)
+	if spec == nil {
+		return []RuntimeProcess{}
+	}
+
+	pids, commands := listRuntimeCommands(spec)
+	if len(pids) == 0 {
+		return []RuntimeProcess{}
+	}
+
+	var processes []RuntimeProcess
+
+	for _, pid := range pids {
+		cmdLine := commands[pid]
+
+		// カレントディレクトリからプロジェクトルートを探す
+		projectDir := findProjectRoot(getProcessCwd(pid), spec.Manifests)
+
+		// フレームワーク判定
+		framework := spec.Detect(cmdLine)
+
+		// プロジェクト名を取得（マニフェスト → ディレクトリ名 → フレームワーク名の順）
+		projectName := ""
+		if projectDir != "" {
+			projectName = getProjectNameFromManifest(projectDir)
+			if projectName == "" {
+				projectName = filepath.Base(projectDir)
+			}
+		}
+		if projectName == "" {
+			projectName = framework
+		}
+
+		// CPU・メモリ使用量取得
+		stats := getProcessStats(pid)
+
+		processes = append(processes, RuntimeProcess{
+			PID:         pid,
+			Runtime:     spec.Name,
+			ProjectDir:  projectDir,
+			ProjectName: projectName,
+			Framework:   framework,
+			Command:     cmdLine,
+			Uptime:      getProcessUptime(pid),
+			CPUPerc:     fmt.Sprintf("%.1f%%", stats.CPU),
+			MemUsage:    fmt.Sprintf("%.1fMB", float64(stats.Memory)/1024.0),
+			Port:        getProcessPort(pid),
+		})
+	}
+
+	return processes
+}
+
+// getProcessCwd returns current working directory of a process
+func getProcessCwd(pid string) string {
+	output, err := RunCommandWithTimeout("lsof", "-a", "-d", "cwd", "-p", pid)
+	if err != nil {
+		return ""
+	}
+
+	for _, line := range strings.Split(string(output), "\n") {
+		if strings.Contains(line, " cwd ") {
+			fields := strings.Fields(line)
+			if len(fields) > 0 {
+				return fields[len(fields)-1]
+			}
+		}
+	}
+
+	return ""
+}
+
+// findProjectRoot walks up from dir to find a directory containing one of manifests
+// 見つからない場合はdirをそのまま返します（ルート直下やホームは除く）
+func findProjectRoot(dir string, manifests []string) string {
+	if dir == "" || dir == "/" {
+		return ""
+	}
+
+	home, _ := os.UserHomeDir()
+	for current := dir; current != "/" && current != home && current != "."; current = filepath.Dir(current) {
+		for _, manifest := range manifests {
+			if _, err := os.Stat(filepath.Join(current, manifest)); err == nil {
+				return current
+			}
+		}
+	}
+
+	if dir == home {
+		return ""
+	}
+	return dir
+}
+
+// getProjectNameFromManifest reads project name from package.json, composer.json or go.mod
+func getProjectNameFromManifest(dir string) string {
+	if name := getProjectNameFromPackageJson(dir); name != "" {
+		return name
+	}
+
+	// composer.json の "name"（vendor/package 形式）
+	if data, err := os.ReadFile(filepath.Join(dir, "composer.json")); err == nil {
+		var composer struct {
+			Name string `json:"name"`
+		}
+		if json.Unmarshal(data, &composer) == nil && composer.Name != "" {
+			return composer.Name
+		}
+	}
+
+	// go.mod の module パスの末尾
+	if data, err := os.ReadFile(filepath.Join(dir, "go.mod")); err == nil {
+		for _, line := range strings.Split(string(data), "\n") {
+			if strings.HasPrefix(line, "module ") {
+				return filepath.Base(strings.TrimSpace(strings.TrimPrefix(line, "module ")))
+			}
+		}
+	}
+
+	return ""
+}
+
+// detectGoProcessType detects what type of Go process is running
+func detectGoProcessType(cmdLine string) string {
+	switch {
+	case goDelvePattern.MatchString(cmdLine):
+		return "Delve (デバッグ)"
+	case goAirPattern.MatchString(cmdLine):
+		return "air (ホットリロード)"
+	case strings.Contains(cmdLine, "go-build"):
+		// go run がビルドした一時バイナリ（実際にポートを開くのはこちら）
+		return "go run (バイナリ)"
+	case goRunPattern.MatchString(cmdLine):
+		return "go run"
+	}
+	return ""
+}
+
+// detectRubyProcessType detects what type of Ruby process is running
+func detectRubyProcessType(cmdLine string) string {
+	lower := strings.ToLower(cmdLine)
+
+	switch {
+	case strings.HasPrefix(lower, "sidekiq") || strings.Contains(lower, "bin/sidekiq"):
+		return "Sidekiq"
+	case strings.Contains(lower, "rails server") || strings.Contains(lower, "rails s ") || strings.HasSuffix(lower, "rails s"):
+		return "Rails"
+	case strings.HasPrefix(lower, "puma") || strings.Contains(lower, "bin/puma"):
+		// pumaはプロセス名を "puma 6.4.0 (tcp://0.0.0.0:3000) [app]" に書き換える
+		return "Puma"
+	case strings.Contains(lower, "unicorn"):
+		return "Unicorn"
+	case strings.Contains(lower, "rails console") || strings.HasSuffix(lower, "rails c"):
+		return "Rails Console"
+	case strings.Contains(lower, "rake"):
+		return "Rake"
+	case strings.Contains(lower, "ruby"):
+		return "Ruby"
+	}
+	return ""
+}
+
+// detectJVMProcessType detects what type of JVM process is running
+func detectJVMProcessType(cmdLine string) string {
+	switch {
+	case strings.Contains(cmdLine, "GradleDaemon"):
+		return "Gradle Daemon"
+	case strings.Contains(cmdLine, "GradleWrapperMain") || strings.Contains(cmdLine, "org.gradle.launcher"):
+		return "Gradle"
+	case strings.Contains(cmdLine, "KotlinCompileDaemon") || strings.Contains(cmdLine, "kotlin-daemon"):
+		return "Kotlin Daemon"
+	case strings.Contains(cmdLine, "org.codehaus.plexus.classworlds"):
+		return "Maven"
+	case strings.Contains(cmdLine, "org.springframework.boot") || strings.Contains(cmdLine, "spring-boot"):
+		return "Spring Boot"
+	case strings.Contains(cmdLine, "org.elasticsearch") || strings.Contains(cmdLine, "org.opensearch"):
+		return "Elasticsearch"
+	case strings.Contains(cmdLine, "kafka.Kafka"):
+		return "Kafka"
+	case strings.Contains(cmdLine, "-jar "):
+		return "Java (jar)"
+	}
+	return "Java"
+}
+
+// detectDenoBunProcessType detects what type of Deno/Bun process is running
+func detectDenoBunProcessType(cmdLine string) string {
+	fields := strings.Fields(cmdLine)
+	if len(fields) == 0 {
+		return ""
+	}
+
+	runtime := ""
+	switch filepath.Base(fields[0]) {
+	case "deno":
+		runtime = "Deno"
+	case "bun":
+		runtime = "Bun"
+	default:
+		return ""
+	}
+
+	// サブコマンドとウォッチモードを表示
+	if len(fields) > 1 && !strings.HasPrefix(fields[1], "-") {
+		runtime += " " + fields[1]
+	}
+	if strings.Contains(cmdLine, "--watch") || strings.Contains(cmdLine, "--hot") {
+		runtime += " (ウォッチ)"
+	}
+
+	return runtime
+}
+
+// detectPHPProcessType detects what type of PHP process is running
+func detectPHPProcessType(cmdLine string) string {
+	switch {
+	case strings.Contains(cmdLine, "php-fpm: pool"):
+		// ワーカーはmasterが管理するため一覧には出さない
+		return ""
+	case strings.Contains(cmdLine, "php-fpm"):
+		return "PHP-FPM"
+	case strings.Contains(cmdLine, "artisan serve"):
+		return "Laravel (artisan serve)"
+	case strings.Contains(cmdLine, "artisan queue:work") || strings.Contains(cmdLine, "artisan horizon"):
+		return "Laravel (キューワーカー)"
+	case strings.Contains(cmdLine, " -S "):
+		return "PHP ビルトインサーバー"
+	}
+	return "PHP"
+}
+
+// GetJVMHeap returns heap usage of a JVM process via jcmd
+// jcmdがない場合や別ユーザーのプロセスの場合は空文字を返します
+func GetJVMHeap(pid string) string {
+	if !IsValidPID(pid) {
+		return ""
+	}
+	if _, err := exec.LookPath("jcmd"); err != nil {
+		return ""
+	}
+
+	output, err := RunCommandWithCustomTimeout(jcmdTimeout, "jcmd", pid, "GC.heap_info")
+	if err != nil {
+		return ""
+	}
+
+	return parseJVMHeapInfo(string(output))
+}
+
+// parseJVMHeapInfo parses output of jcmd GC.heap_info
+// G1/Parallel/Serial: "total 262144K, used 21504K"、ZGC: "used 30M, capacity 512M"
+func parseJVMHeapInfo(output string) string {
+	var totalKB, usedKB int64
+	for _, line := range strings.Split(output, "\n") {
+		// Metaspace等はヒープではないため除外
+		if strings.Contains(line, "Metaspace") || strings.Contains(line, "class space") {
+			continue
+		}
+
+		if m := jvmHeapTotalUsedPattern.FindStringSubmatch(line); m != nil {
+			total, _ := strconv.ParseInt(m[1], 10, 64)
+			used, _ := strconv.ParseInt(m[2], 10, 64)
+			totalKB += total
+			usedKB += used
+			// G1は先頭行がヒープ全体のため以降のリージョン行は不要
+			if strings.Contains(line, "garbage-first heap") {
+				break
+			}
+		} else if m := jvmHeapZGCPattern.FindStringSubmatch(line); m != nil {
+			used, _ := strconv.ParseInt(m[1], 10, 64)
+			capacity, _ := strconv.ParseInt(m[2], 10, 64)
+			totalKB, usedKB = capacity*1024, used*1024
+			break
+		}
+	}
+
+	if totalKB == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("%s / %s (%.0f%%)", formatBytes(usedKB*1024), formatBytes(totalKB*1024), float64(usedKB)/float64(totalKB)*100)
+}
@@ -0,0 +1,61 @@
+package monitor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// containerShellCandidates はコンテナ内で探すシェル（優先順）
+var containerShellCandidates = []string{"bash", "ash", "sh"}
+
+// DetectContainerShell returns the best interactive shell available in a running container
+func DetectContainerShell(containerID string) (string, error) {
+	// セキュリティバリデーション: コンテナIDが16進数のみであることを確認
+	if !IsValidContainerID(containerID) {
+		return "", fmt.Errorf("不正なコンテナIDです")
+	}
+
+	// command -v で最初に見つかったシェルのパスを使う（distrolessなどでshもない場合はエラー）
+	probe := make([]string, 0, len(containerShellCandidates))
+	for _, shell := range containerShellCandidates {
+		probe = append(probe, "command -v "+shell)
+	}
+	output, err := RunCommandWithTimeout(ContainerCLI(), "exec", containerID, "sh", "-c", strings.Join(probe, " || "))
+	if err != nil {
+		return "", fmt.Errorf("コンテナ内でシェルが見つかりません（停止中か、シェルを含まないイメージです）")
+	}
+
+	shell := strings.TrimSpace(strings.SplitN(string(output), "\n", 2)[0])
+	if shell == "" {
+		return "sh", nil
+	}
+	return shell, nil
+}
+
+// NewContainerShellCommand builds "docker exec -it <id> <shell>" for an interactive session
+func NewContainerShellCommand(containerID string) (*exec.Cmd, error) {
+	shell, err := DetectContainerShell(containerID)
+	if err != nil {
+		return nil, err
+	}
+	return exec.Command(ContainerCLI(), "exec", "-it", containerID, shell), nil
+}
+
+// NewDirectoryShellCommand builds an interactive $SHELL session started in dir
+func NewDirectoryShellCommand(dir string) (*exec.Cmd, error) {
+	info, err := os.Stat(dir)
+	if err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("ディレクトリが見つかりません: %s", dir)
+	}
+
+	shell := os.Getenv("SHELL")
+	if shell == "" {
+		shell = "/bin/sh"
+	}
+
+	cmd := exec.Command(shell)
+	cmd.Dir = dir
+	return cmd, nil
+}
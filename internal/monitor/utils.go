@@ -2,6 +2,9 @@ package monitor
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"net/http"
 	"os/exec"
 	"regexp"
 	"time"
@@ -72,3 +75,33 @@ func IsServiceRunning(processName string) bool {
 	_, err := RunCommandWithTimeout("pgrep", processName)
 	return err == nil
 }
+
+// doHTTPRequest はタイムアウト付きでHTTPリクエストを送信し、レスポンスボディを返します
+// 管理API（RabbitMQ、Elasticsearchなど）へのアクセスに使用します。userが空の場合は認証なし
+func doHTTPRequest(method, url, user, password string) ([]byte, error) {
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if user != "" {
+		req.SetBasicAuth(user, password)
+	}
+
+	client := &http.Client{Timeout: DefaultTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("接続できません: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("APIエラー: %s", resp.Status)
+	}
+
+	return body, nil
+}
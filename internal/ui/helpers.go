@@ -2,6 +2,9 @@ package ui
 
 import (
 	"strings"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/config"
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
 )
 
 // styleContent applies color based on content
@@ -29,3 +32,30 @@ func styleContent(content string) string {
 
 	return strings.Join(styledLines, "\n")
 }
+
+// valueOrUnknown returns "不明" for empty values
+func valueOrUnknown(value string) string {
+	if value == "" {
+		return "不明"
+	}
+	return value
+}
+
+// configPathText returns the config file path for display
+func configPathText() string {
+	path := config.Path()
+	if path == "" {
+		return "~/.devmon/config.json"
+	}
+	return path
+}
+
+// isRuntimeMenu reports whether the menu item is a runtime process panel (Go, Ruby, JVM, Deno/Bun, PHP)
+func isRuntimeMenu(name string) bool {
+	return monitor.IsKnownRuntime(name)
+}
+
+// isDockerResourceMenu reports whether the menu item is a Docker volumes/networks/images panel
+func isDockerResourceMenu(name string) bool {
+	return name == "Docker Volumes" || name == "Docker Networks" || name == "Docker Images"
+}
@@ -3,7 +3,6 @@ package ui
 import (
 	"context"
 	"fmt"
-	"os/exec"
 	"regexp"
 	"time"
 
@@ -13,6 +12,7 @@ import (
 	"github.com/Masahide-S/bho_hacka_go/internal/llm"
 	"github.com/Masahide-S/bho_hacka_go/internal/logger"
 	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor/logs"
 )
 
 // 画面モードの定義
@@ -44,8 +44,9 @@ type clearCommandResultMsg struct{}
 
 // containerStatsMsg is sent when container stats are fetched
 type containerStatsMsg struct {
-	Containers     map[string]*ContainerStatsCache // コンテナID -> キャッシュ
-	ContainersList []monitor.DockerContainer       // コンテナリスト
+	Containers      map[string]*ContainerStatsCache // コンテナID -> キャッシュ
+	ContainersList  []monitor.DockerContainer       // コンテナリスト
+	ComposeProjects []monitor.ComposeProject        // 記憶しているComposeプロジェクト（停止中を含む）
 }
 
 // portsDataMsg is sent when port data is fetched
@@ -73,7 +74,7 @@ type MenuItem struct {
 
 // RightPanelItem represents an item in the right panel
 type RightPanelItem struct {
-	Type        string // "project", "container", "port", "process_item"
+	Type        string // "project", "container", "service", "port", "process_item"
 	Name        string
 	ProjectName string // プロジェクト名（コンテナの場合）
 	ContainerID string // コンテナの場合のID
@@ -95,6 +96,13 @@ type ContainerStatsCache struct {
 	UpdatedAt time.Time
 }
 
+// ComposeConfigCache holds a resolved compose configuration
+type ComposeConfigCache struct {
+	Config    *monitor.ComposeConfig
+	Err       string
+	UpdatedAt time.Time
+}
+
 // Model holds the TUI state
 type Model struct {
 	lastUpdate time.Time
@@ -109,6 +117,10 @@ type Model struct {
 	// AI Analysis
 	aiIssueCount int
 
+	// ログのエラー検知（バックグラウンドで収集）
+	logWatcher *logWatcher
+	logIssues  []logs.LogIssue // 検知した問題（新しい順）
+
 	// System Resources
 	systemResources monitor.SystemResources
 
@@ -116,11 +128,38 @@ type Model struct {
 	serviceCache            map[string]*ServiceCache
 	containerStatsCache     map[string]*ContainerStatsCache // コンテナID -> 統計キャッシュ
 	cachedContainers        []monitor.DockerContainer       // コンテナリストのキャッシュ
+	cachedComposeProjects   []monitor.ComposeProject        // Composeプロジェクトのキャッシュ（停止中を含む）
+	composeConfigCache      map[string]*ComposeConfigCache  // プロジェクト名 -> compose configのキャッシュ
+	cachedDockerDiskUsage   []monitor.DockerDiskUsage       // docker system df のキャッシュ
+	cachedDockerVolumes     []monitor.DockerVolume          // Dockerボリュームのキャッシュ
+	cachedDockerNetworks    []monitor.DockerNetwork         // Dockerネットワークのキャッシュ
+	cachedDockerImages      []monitor.DockerImage           // Dockerイメージのキャッシュ
+	dockerFetchedAt         map[string]time.Time            // パネル名 -> 最終取得時刻
 	cachedPostgresDatabases []monitor.PostgresDatabase      // PostgreSQLデータベースのキャッシュ
 	cachedMySQLDatabases    []monitor.MySQLDatabase         // MySQLデータベースのキャッシュ
+	cachedMySQLStatus       monitor.MySQLServerStatus       // MySQLサーバー統計のキャッシュ
+	cachedMySQLProcesses    []monitor.MySQLProcess          // MySQLプロセスリストのキャッシュ
+	cachedMySQLTables       map[string][]monitor.MySQLTable // DB名 -> テーブル一覧のキャッシュ
 	cachedRedisDatabases    []monitor.RedisDatabase         // Redisデータベースのキャッシュ
+	cachedRedisInfo         monitor.RedisServerInfo         // Redisサーバー統計のキャッシュ
+	cachedRedisSlowLog      []monitor.RedisSlowLogEntry     // Redisスローログのキャッシュ
+	cachedRedisKeys         []monitor.RedisKey              // キーブラウザのキャッシュ
+	cachedMongoDatabases    []monitor.MongoDatabase         // MongoDBデータベースのキャッシュ
+	cachedMongoStatus       monitor.MongoServerStatus       // MongoDBサーバー統計のキャッシュ
+	cachedMongoOps          []monitor.MongoOperation        // MongoDB実行中オペレーションのキャッシュ
+	cachedMongoCollections  map[string][]monitor.MongoCollection // DB名 -> コレクション一覧のキャッシュ
+	cachedRabbitMQOverview  monitor.RabbitMQOverview        // RabbitMQ概要のキャッシュ
+	cachedRabbitMQQueues    []monitor.RabbitMQQueue         // RabbitMQキュー一覧のキャッシュ
+	cachedKafkaStatus       monitor.KafkaStatus             // Kafkaトピック・コンシューマーグループのキャッシュ
+	kafkaFetching           bool                            // Kafka情報の取得中フラグ
+	cachedESCluster         monitor.ElasticsearchCluster    // Elasticsearchクラスタ情報のキャッシュ
+	cachedESNodes           []monitor.ElasticsearchNode     // Elasticsearchノードのキャッシュ
+	cachedESIndices         []monitor.ElasticsearchIndex    // Elasticsearchインデックスのキャッシュ
+	cachedESPendingTasks    []monitor.ElasticsearchPendingTask // Elasticsearch保留タスクのキャッシュ
 	cachedNodeProcesses     []monitor.NodeProcess           // Node.jsプロセスのキャッシュ
 	cachedPythonProcesses   []monitor.PythonProcess         // Pythonプロセスのキャッシュ
+	cachedRuntimeProcesses  []monitor.RuntimeProcess        // Go/Ruby/JVM/Deno/Bun/PHPプロセスのキャッシュ（選択中のランタイムのみ）
+	cachedJVMHeap           map[string]string               // PID -> JVMヒープ使用量のキャッシュ
 	cachedPorts             []monitor.PortInfo              // ポート一覧のキャッシュ
 	cachedPortsUpdatedAt    time.Time                       // ポート一覧の最終更新時刻
 	cachedTopProcesses         []monitor.ProcessInfo           // Top 10プロセスのキャッシュ
@@ -141,11 +180,57 @@ type Model struct {
 	lastCommandResult string // 最後のコマンド実行結果
 
 	// Log viewing
-	showLogView   bool
-	logContent    string
-	logScroll     int
-	logTargetName string // ログ表示対象の名前
-
+	showLogView     bool
+	logStream       *logs.LogStream  // フォロー中のログ（docker logs -f / ファイルのtail）
+	logBuffer       *logs.RingBuffer // 直近のログ行（上限を超えた古い行は捨てる）
+	logScroll       int
+	logAutoScroll   bool        // 新着行に合わせて最下部へスクロールする
+	logPaused       bool        // 一時停止中（表示を固定）
+	logPausedLines  []logs.Line // 一時停止した時点の表示内容
+	logPendingLines int         // 一時停止中に届いた行数
+	logStreamErr    string      // フォローが終了した理由
+	logTargetName   string      // ログ表示対象の名前
+
+	// ログビューの検索
+	logSearchEditing bool           // 検索パターン入力中
+	logSearchInput   string         // 入力中のパターン
+	logSearch        string         // 確定したパターン
+	logSearchRegex   *regexp.Regexp // 検索パターン（nilなら検索なし）
+	logSearchErr     string
+	logFilter        bool // 一致しない行を隠す
+	logPrettyJSON    bool // JSONの構造化ログを整形して表示
+	logMatchLine     int  // 現在の一致行（表示行のインデックス、-1ならなし）
+
+	// 統合ログ（複数のコンテナ・プロセスのログを時刻順に表示）
+	logSources       []string            // 表示中の統合ログのソース名（単一のログでは空）
+	logHiddenSources map[string]bool     // 非表示にしたソース
+	logMarks         []logs.MergedSource // m キーで選択した統合ログの対象
+	logView          *logViewCache       // 表示する行の計算結果（モデルのコピー間で共有）
+
+	// Redisキーブラウザ
+	redisBrowseDB       string // 閲覧中のデータベース（例: "db0"、空なら非表示）
+	redisKeyPattern     string // SCANのMATCHパターン
+	redisPatternInput   string // 入力中のパターン
+	redisPatternEditing bool   // パターン入力モード
+	redisKeysLoading    bool
+	redisKeysError      string
+
+	// Node.jsパネル
+	showEditorNodeProcesses bool // エディタ内部のNode.jsプロセスを表示するか（デフォルト非表示）
+
+	// プロセスの起動（devmon run で出力をキャプチャする）
+	launchEditing      bool     // コマンド入力モード
+	launchInput        string   // 入力中のコマンド
+	launchDir          string   // 起動ディレクトリ
+	launchHistory      []string // このディレクトリで以前に実行したコマンド（Tabで切替）
+	launchHistoryIndex int
+
+	// コンテナ設定タブ
+	showContainerConfig     bool                     // コンテナ詳細で設定タブ（環境変数・制限など）を表示するか
+	cachedContainerConfig   *monitor.ContainerConfig // 設定タブのコンテナ設定のキャッシュ
+	containerConfigID       string                   // cachedContainerConfig のコンテナID
+	containerConfigErr      string
+	containerConfigFetching string // 取得中のコンテナID
 
 	// AI関連フィールド
 	aiService    *ai.Service
@@ -154,13 +239,31 @@ type Model struct {
 	aiPendingCmd string // 実行待ちのコマンド
 	aiCmdResult  string // コマンド実行結果
 
+	aiCmdAssessment   ai.CommandAssessment // 実行待ちのコマンドのリスク判定
+	aiCmdConfirmInput string               // 危険なコマンドの確認の入力
+
+	// 会話（先頭はシステムプロンプトとシステム状況レポート）
+	aiHistory     []llm.Message
+	aiChatEditing bool   // 質問の入力中
+	aiChatInput   string // 入力中の質問
+
+	// 送信内容のプレビュー
+	aiShowPreview bool       // LLMに送る内容を表示中
+	aiPreview     *aiPreview // 会話がない場合にプレビューのために集めた会話（準備中は nil）
+
+	// ツールを使った調査（エージェント）
+	aiAgentMode   bool                 // 回答の前にツールで情報を取得させる
+	aiAgentEvents <-chan ai.AgentEvent // 実行中のエージェントのイベント
+	aiToolTrace   []ai.ToolTrace       // 最新の回答までに呼び出したツール
+
 	// ストリーミング用フィールド
 	currentStream <-chan llm.GenerateResponseStream
 
-	// Ollama接続状態
-	ollamaAvailable bool
-	availableModels []string
-	selectedModel   int // モデル選択インデックス
+	// LLMサーバーの接続状態とモデル選択
+	aiAvailable      bool                // 選択中のプロバイダーに接続できる
+	aiProviderModels []ai.ProviderModels // プロバイダーごとのモデル一覧
+	availableModels  []aiModelChoice     // Tab で切り替えるモデル（全プロバイダー）
+	selectedModel    int                 // モデル選択インデックス
 
 	// --- DB関連フィールド ---
 	dbStore    *db.Store
@@ -193,11 +296,15 @@ type aiAnalysisMsg struct {
 // cmdExecMsg はコマンド実行結果を運ぶメッセージ
 type cmdExecMsg struct {
 	Result string
+	DryRun bool
 
 }
 
-// ストリーミング開始を通知するメッセージ
-type aiStreamStartMsg <-chan llm.GenerateResponseStream
+// ストリーミング開始を通知するメッセージ（送信した会話を含む）
+type aiStreamStartMsg struct {
+	History []llm.Message
+	Stream  <-chan llm.GenerateResponseStream
+}
 
 // ストリーミングの各パケットを運ぶメッセージ
 type aiStreamMsg struct {
@@ -206,15 +313,14 @@ type aiStreamMsg struct {
 	Err      error
 }
 
-// Ollamaヘルスチェック結果を運ぶメッセージ
+// LLMサーバーのヘルスチェック結果を運ぶメッセージ
 type aiHealthMsg struct {
 	Err error
 }
 
 // モデル一覧取得結果を運ぶメッセージ
 type aiModelsMsg struct {
-	Models []string
-	Err    error
+	Providers []ai.ProviderModels
 }
 
 // serviceStatusResultMsg はサービス状態チェックの結果を運ぶメッセージ
@@ -232,35 +338,72 @@ func InitialModel() Model {
 	return InitialModelWithStore(nil)
 }
 
+// initialMenuItems returns the menu items; runtime panels come from monitor.RuntimeNames
+func initialMenuItems() []MenuItem {
+	items := []MenuItem{
+		{Name: "AI分析", Type: "ai", Status: ""},
+		{Name: "────────────", Type: "separator", Status: ""},
+		{Name: "PostgreSQL", Type: "service", Status: "✗"},
+		{Name: "MySQL", Type: "service", Status: "✗"},
+		{Name: "Redis", Type: "service", Status: "✗"},
+		{Name: "MongoDB", Type: "service", Status: "✗"},
+		{Name: "RabbitMQ", Type: "service", Status: "✗"},
+		{Name: "Kafka", Type: "service", Status: "✗"},
+		{Name: "Elasticsearch", Type: "service", Status: "✗"},
+		{Name: "Docker", Type: "service", Status: "✗"},
+		{Name: "Docker Volumes", Type: "service", Status: "✗"},
+		{Name: "Docker Networks", Type: "service", Status: "✗"},
+		{Name: "Docker Images", Type: "service", Status: "✗"},
+		{Name: "Node.js", Type: "service", Status: "✗"},
+		{Name: "Python", Type: "service", Status: "✗"},
+	}
+	for _, name := range monitor.RuntimeNames() {
+		items = append(items, MenuItem{Name: name, Type: "service", Status: "✗"})
+	}
+	return append(items,
+		MenuItem{Name: "────────────", Type: "separator", Status: ""},
+		MenuItem{Name: "ポート一覧", Type: "info", Status: ""},
+		MenuItem{Name: "Top 10 プロセス", Type: "info", Status: ""},
+		MenuItem{Name: "システムリソース", Type: "info", Status: ""},
+	)
+}
+
 // InitialModelWithStore returns the initial model with database store
 func InitialModelWithStore(store *db.Store) Model {
 	m := Model{
 		lastUpdate:   time.Now(),
 		selectedItem: 0,
-		menuItems: []MenuItem{
-			{Name: "AI分析", Type: "ai", Status: ""},
-			{Name: "────────────", Type: "separator", Status: ""},
-			{Name: "PostgreSQL", Type: "service", Status: "✗"},
-			{Name: "MySQL", Type: "service", Status: "✗"},
-			{Name: "Redis", Type: "service", Status: "✗"},
-			{Name: "Docker", Type: "service", Status: "✗"},
-			{Name: "Node.js", Type: "service", Status: "✗"},
-			{Name: "Python", Type: "service", Status: "✗"},
-			{Name: "────────────", Type: "separator", Status: ""},
-			{Name: "ポート一覧", Type: "info", Status: ""},
-			{Name: "Top 10 プロセス", Type: "info", Status: ""},
-			{Name: "システムリソース", Type: "info", Status: ""},
-		},
+		menuItems:    initialMenuItems(),
 		aiIssueCount:           0,
 		systemResources:        monitor.GetSystemResources(),
 		serviceCache:           make(map[string]*ServiceCache),
 		containerStatsCache:    make(map[string]*ContainerStatsCache),
 		cachedContainers:       []monitor.DockerContainer{},
+		cachedComposeProjects:  []monitor.ComposeProject{},
+		composeConfigCache:     make(map[string]*ComposeConfigCache),
+		cachedDockerDiskUsage:  []monitor.DockerDiskUsage{},
+		cachedDockerVolumes:    []monitor.DockerVolume{},
+		cachedDockerNetworks:   []monitor.DockerNetwork{},
+		cachedDockerImages:     []monitor.DockerImage{},
+		dockerFetchedAt:        make(map[string]time.Time),
 		cachedPostgresDatabases: []monitor.PostgresDatabase{},
 		cachedMySQLDatabases:   []monitor.MySQLDatabase{},
+		cachedMySQLProcesses:   []monitor.MySQLProcess{},
+		cachedMySQLTables:      make(map[string][]monitor.MySQLTable),
 		cachedRedisDatabases:   []monitor.RedisDatabase{},
+		cachedRedisSlowLog:     []monitor.RedisSlowLogEntry{},
+		cachedRedisKeys:        []monitor.RedisKey{},
+		cachedMongoDatabases:   []monitor.MongoDatabase{},
+		cachedMongoOps:         []monitor.MongoOperation{},
+		cachedMongoCollections: make(map[string][]monitor.MongoCollection),
+		cachedRabbitMQQueues:   []monitor.RabbitMQQueue{},
+		cachedESNodes:          []monitor.ElasticsearchNode{},
+		cachedESIndices:        []monitor.ElasticsearchIndex{},
+		cachedESPendingTasks:   []monitor.ElasticsearchPendingTask{},
 		cachedNodeProcesses:    []monitor.NodeProcess{},
 		cachedPythonProcesses:  []monitor.PythonProcess{},
+		cachedRuntimeProcesses: []monitor.RuntimeProcess{},
+		cachedJVMHeap:          make(map[string]string),
 		cachedTopProcesses:     []monitor.ProcessInfo{},
 		tickCount:              0,
 		focusedPanel:           "left",
@@ -273,19 +416,47 @@ func InitialModelWithStore(store *db.Store) Model {
 		confirmType:            "",
 		lastCommandResult:      "",
 		showLogView:            false,
-		logContent:             "",
 		logScroll:              0,
+		logMatchLine:           -1,
 		logTargetName:          "",
 		aiService:              ai.NewService(),
 		aiState:                aiStateIdle,
 		aiPendingCmd:           "",
 		aiCmdResult:            "",
-		ollamaAvailable:        false,
-		availableModels:        []string{},
+		aiAvailable:            false,
+		availableModels:        []aiModelChoice{},
 		selectedModel:          0,
 		dbStore:                store,
 		dbChan:                 make(chan monitor.FullSnapshot, 50), // バッファを持たせる
 		currentView:            viewMonitor,
+		logView:                &logViewCache{},
+	}
+
+	// 記憶しているComposeプロジェクトを読み込む（コンテナがすべて削除されていても起動できるように）
+	if store != nil {
+		projects, err := store.GetComposeProjects()
+		if err != nil {
+			logger.LogIssue("DB_READ_ERROR", err.Error())
+		} else {
+			monitor.RememberComposeProjects(projects)
+			m.cachedComposeProjects = monitor.KnownComposeProjects()
+		}
+	}
+
+	// devmon run で起動したプロセスを読み込む（ログの表示・再起動に使う）
+	if store != nil {
+		processes, err := store.GetManagedProcesses()
+		if err != nil {
+			logger.LogIssue("DB_READ_ERROR", err.Error())
+		} else {
+			monitor.RememberManagedProcesses(processes)
+		}
+	}
+
+	// ログの保存とエラー検知はDBがある場合のみ
+	if store != nil {
+		m.logWatcher = newLogWatcher()
+		m.aiService.SetStore(store)
 	}
 
 	// 裏方（DBワーカー）を始動
@@ -323,7 +494,7 @@ func (m Model) Init() tea.Cmd {
 	)
 }
 
-// checkHealthCmd はOllamaサーバーの接続確認を行うコマンド
+// checkHealthCmd は選択中のLLMサーバーの接続確認を行うコマンド
 func (m Model) checkHealthCmd() tea.Cmd {
 	return func() tea.Msg {
 		err := m.aiService.CheckHealth(context.Background())
@@ -334,8 +505,7 @@ func (m Model) checkHealthCmd() tea.Cmd {
 // fetchModelsCmd は利用可能なモデル一覧を取得するコマンド
 func (m Model) fetchModelsCmd() tea.Cmd {
 	return func() tea.Msg {
-		models, err := m.aiService.ListModels(context.Background())
-		return aiModelsMsg{Models: models, Err: err}
+		return aiModelsMsg{Providers: m.aiService.ListProviderModels(context.Background())}
 	}
 }
 
@@ -370,6 +540,37 @@ func updateServiceStatusCmd(menuItems []MenuItem) []tea.Cmd {
 		serviceName := item.Name
 
 		cmds = append(cmds, func() tea.Msg {
+			// MongoDB・メッセージブローカーはコンテナで動作している場合も稼働中とみなす
+			var checkFunc func() bool
+			switch serviceName {
+			case "MongoDB":
+				checkFunc = monitor.IsMongoDBRunning
+			case "RabbitMQ":
+				checkFunc = monitor.IsRabbitMQRunning
+			case "Kafka":
+				checkFunc = monitor.IsKafkaRunning
+			case "Elasticsearch":
+				checkFunc = monitor.IsElasticsearchRunning
+			case "Docker", "Docker Volumes", "Docker Networks", "Docker Images":
+				// Podmanはデーモンがないため、pgrepではなくCLIで接続を確認
+				checkFunc = monitor.IsContainerRuntimeRunning
+			default:
+				if isRuntimeMenu(serviceName) {
+					// pgrepのプロセス名では判定できないため、コマンドラインで判定
+					checkFunc = func() bool { return monitor.IsRuntimeRunning(serviceName) }
+				}
+			}
+			if checkFunc != nil {
+				status := "✗"
+				if checkFunc() {
+					status = "✓"
+				}
+				return serviceStatusResultMsg{
+					Index:  index,
+					Status: status,
+				}
+			}
+
 			var processName string
 			switch serviceName {
 			case "PostgreSQL":
@@ -378,8 +579,6 @@ func updateServiceStatusCmd(menuItems []MenuItem) []tea.Cmd {
 				processName = "mysqld"
 			case "Redis":
 				processName = "redis-server"
-			case "Docker":
-				processName = "docker"
 			case "Node.js":
 				processName = "node"
 			case "Python":
@@ -419,30 +618,34 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		// AIのコマンド実行待ち状態の時のキー操作
 		if m.aiPendingCmd != "" {
-			switch msg.String() {
-			case "enter":
-				// コマンド実行
-				cmdStr := m.aiPendingCmd
-				m.aiPendingCmd = ""
-				m.aiCmdResult = fmt.Sprintf("実行中: %s...", cmdStr)
-				return m, executePendingCmd(cmdStr)
-
-			case "esc", "n":
-				// キャンセル
-				m.aiPendingCmd = ""
-				m.aiCmdResult = "コマンド実行をキャンセルしました。"
-				return m, nil
+			return m.handleAIPendingCmdKey(msg)
+		}
+
+		// Redisキーのパターン入力中は文字入力として扱う
+		if m.redisPatternEditing {
+			return m.handleRedisPatternInput(msg)
+		}
+
+		// 起動するコマンドの入力中は文字入力として扱う
+		if m.launchEditing {
+			return m.handleLaunchInput(msg)
+		}
 
-			case "q", "ctrl+c":
-				m.quitting = true
-				return m, tea.Quit
+		// AIへの質問の入力中は文字入力として扱う
+		if m.aiChatEditing {
+			return m.handleAIChatInput(msg)
+		}
+
+		// ログビュー表示中のスクロール・一時停止
+		if m.showLogView {
+			if next, cmd, handled := m.handleLogViewKey(msg); handled {
+				return next, cmd
 			}
-			// コマンド待ちの時は他の操作をブロック
-			return m, nil
 		}
 
 		switch msg.String() {
 		case "q", "ctrl+c":
+			m = m.stopLogStream()
 			m.quitting = true
 			return m, tea.Quit
 
@@ -461,10 +664,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 			if m.showLogView {
-				m.showLogView = false
-				m.logContent = ""
-				m.logScroll = 0
-				m.logTargetName = ""
+				m = m.closeLogView()
+				return m, nil
+			}
+			// Redisキーブラウザを閉じる
+			if m.redisBrowseDB != "" && m.menuItems[m.selectedItem].Name == "Redis" {
+				m.redisBrowseDB = ""
+				m.cachedRedisKeys = []monitor.RedisKey{}
+				m.redisKeysError = ""
+				m = m.updateRightPanelItems()
+				if m.rightPanelCursor >= len(m.rightPanelItems) {
+					m.rightPanelCursor = 0
+				}
 				return m, nil
 			}
 			return m, nil
@@ -584,6 +795,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 			if m.focusedPanel == "right" && len(m.rightPanelItems) > 0 {
+				selectedItem := m.menuItems[m.selectedItem]
+				if selectedItem.Name == "Node.js" || selectedItem.Name == "Python" || isRuntimeMenu(selectedItem.Name) {
+					return m.handleManagedProcessRestart()
+				}
 				return m.handleContainerRestart()
 			}
 
@@ -592,12 +807,38 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 			if m.focusedPanel == "right" && len(m.rightPanelItems) > 0 {
+				// Imagesパネルではビルドキャッシュの削除
+				if m.menuItems[m.selectedItem].Name == "Docker Images" {
+					return m.handleBuildCachePrune()
+				}
 				// Composeコンテナの場合のみ
 				if m.isSelectedContainerCompose() {
 					return m.handleContainerRebuild()
 				}
 			}
 
+		// Composeサービス単位の操作（右パネルでComposeのコンテナ/サービス選択時のみ）
+		case "u", "R", "B", "S", "+", "-":
+			if m.showConfirmDialog {
+				return m, nil
+			}
+			if m.focusedPanel == "right" && len(m.rightPanelItems) > 0 && m.menuItems[m.selectedItem].Name == "Docker" {
+				switch msg.String() {
+				case "u":
+					return m.handleComposeServiceAction("up_service")
+				case "R":
+					return m.handleComposeServiceAction("restart_service")
+				case "B":
+					return m.handleComposeServiceAction("build_service")
+				case "S":
+					return m.handleViewComposeServiceLogs()
+				case "+":
+					return m.handleComposeServiceScale(1)
+				case "-":
+					return m.handleComposeServiceScale(-1)
+				}
+			}
+
 		case "d":
 			if m.showConfirmDialog {
 				return m, nil
@@ -610,6 +851,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return m.handleDatabaseDrop()
 				} else if selectedItem.Name == "MySQL" {
 					return m.handleMySQLDatabaseDrop()
+				} else if selectedItem.Name == "Redis" {
+					return m.handleRedisKeyDelete()
+				} else if selectedItem.Name == "MongoDB" {
+					return m.handleMongoDatabaseDrop()
+				} else if selectedItem.Name == "Elasticsearch" {
+					return m.handleElasticsearchIndexDelete()
+				} else if isDockerResourceMenu(selectedItem.Name) {
+					return m.handleDockerResourceRemove()
 				}
 			}
 
@@ -623,6 +872,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return m.handleProcessKill()
 				} else if selectedItem.Name == "Python" {
 					return m.handlePythonProcessKill()
+				} else if isRuntimeMenu(selectedItem.Name) {
+					return m.handleRuntimeProcessKill()
+				} else if selectedItem.Name == "MySQL" {
+					return m.handleMySQLKillQuery()
 				} else if selectedItem.Name == "ポート一覧" {
 					return m.handlePortKill()
 				} else if selectedItem.Name == "Top 10 プロセス" {
@@ -640,6 +893,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return m.handleProcessForceKill()
 				} else if selectedItem.Name == "Python" {
 					return m.handlePythonProcessForceKill()
+				} else if isRuntimeMenu(selectedItem.Name) {
+					return m.handleRuntimeProcessForceKill()
 				} else if selectedItem.Name == "ポート一覧" {
 					return m.handlePortForceKill()
 				} else if selectedItem.Name == "Top 10 プロセス" {
@@ -655,11 +910,23 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				selectedItem := m.menuItems[m.selectedItem]
 				if selectedItem.Name == "MySQL" {
 					return m.handleMySQLDatabaseOptimize()
-				} else if selectedItem.Name == "Docker" || selectedItem.Name == "Node.js" || selectedItem.Name == "Python" {
+				} else if selectedItem.Name == "Docker" || selectedItem.Name == "Node.js" || selectedItem.Name == "Python" || isRuntimeMenu(selectedItem.Name) {
 					return m.handleOpenInVSCode()
 				}
 			}
 
+		// t: コンテナ内またはプロジェクトディレクトリでシェルを開く
+		case "t":
+			if m.showConfirmDialog {
+				return m, nil
+			}
+			if m.focusedPanel == "right" && len(m.rightPanelItems) > 0 {
+				selectedItem := m.menuItems[m.selectedItem]
+				if selectedItem.Name == "Docker" || selectedItem.Name == "Node.js" || selectedItem.Name == "Python" || isRuntimeMenu(selectedItem.Name) {
+					return m.handleOpenShell()
+				}
+			}
+
 		case "f":
 			if m.showConfirmDialog {
 				return m, nil
@@ -671,14 +938,88 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 
+		// Enter: Redisデータベースのキーブラウザを開く
+		case "enter":
+			if m.showConfirmDialog {
+				return m, nil
+			}
+			if m.focusedPanel == "right" && len(m.rightPanelItems) > 0 {
+				selectedItem := m.menuItems[m.selectedItem]
+				if selectedItem.Name == "Redis" {
+					return m.handleRedisBrowseKeys()
+				}
+			}
+
+		// /: Redisキーの検索パターン入力
+		case "/":
+			if m.showConfirmDialog {
+				return m, nil
+			}
+			selectedItem := m.menuItems[m.selectedItem]
+			if selectedItem.Name == "Redis" && m.redisBrowseDB != "" {
+				m.redisPatternEditing = true
+				m.redisPatternInput = m.redisKeyPattern
+				return m, nil
+			}
+
 		case "c":
 			if m.showConfirmDialog {
 				return m, nil
 			}
 			selectedItem := m.menuItems[m.selectedItem]
-			if selectedItem.Name == "Docker" {
+			if selectedItem.Name == "Docker" || selectedItem.Name == "Docker Images" {
 				return m.handleCleanDanglingImages()
+			} else if selectedItem.Name == "Elasticsearch" && m.focusedPanel == "right" && len(m.rightPanelItems) > 0 {
+				return m.handleElasticsearchClearCache()
+			} else if selectedItem.Type == "ai" {
+				// AIに続けて質問する
+				return m.handleAIChatPrompt()
+			}
+
+		// i: コンテナ詳細の概要/設定タブ切替
+		case "i":
+			if m.showConfirmDialog {
+				return m, nil
+			}
+			if m.focusedPanel == "right" && len(m.rightPanelItems) > 0 && m.menuItems[m.selectedItem].Name == "Docker" {
+				return m.handleToggleContainerConfig()
+			}
+
+		// D: 選択中のコンテナを再現する docker run コマンドをコピー（E: シークレットの値を含める）
+		case "D", "E":
+			if m.showConfirmDialog {
+				return m, nil
+			}
+			if m.focusedPanel == "right" && len(m.rightPanelItems) > 0 && m.menuItems[m.selectedItem].Name == "Docker" {
+				return m.handleExportDockerRun(msg.String() == "E")
+			}
+
+		// e: エディタ内部のNode.jsプロセスの表示切替
+		case "e":
+			if m.showConfirmDialog {
+				return m, nil
+			}
+			selectedItem := m.menuItems[m.selectedItem]
+			if selectedItem.Name == "Node.js" {
+				m.showEditorNodeProcesses = !m.showEditorNodeProcesses
+				m = m.updateRightPanelItems()
+				return m, nil
+			}
+
+		// m: 統合ログの対象に追加/削除、M: 統合ログを表示
+		case "m":
+			if m.showConfirmDialog {
+				return m, nil
+			}
+			if m.focusedPanel == "right" && len(m.rightPanelItems) > 0 {
+				return m.handleToggleLogMark()
+			}
+
+		case "M":
+			if m.showConfirmDialog {
+				return m, nil
 			}
+			return m.handleViewMarkedLogs()
 
 		case "L":
 			if m.showConfirmDialog {
@@ -687,11 +1028,34 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.focusedPanel == "right" && len(m.rightPanelItems) > 0 {
 				selectedItem := m.menuItems[m.selectedItem]
 				if selectedItem.Name == "Docker" {
+					// コンテナ未作成のサービスはcompose logsで表示
+					if m.rightPanelItems[m.rightPanelCursor].Type == "service" {
+						return m.handleViewComposeServiceLogs()
+					}
+					// プロジェクトは全コンテナのログを時刻順にまとめて表示
+					if m.rightPanelItems[m.rightPanelCursor].Type == "project" {
+						return m.handleViewComposeProjectLogs()
+					}
 					return m.handleViewContainerLogs()
 				} else if selectedItem.Name == "Node.js" {
 					return m.handleViewNodeProcessLogs()
 				} else if selectedItem.Name == "Python" {
 					return m.handleViewPythonProcessLogs()
+				} else if isRuntimeMenu(selectedItem.Name) {
+					return m.handleViewRuntimeProcessLogs()
+				}
+			}
+
+		case "p":
+			if m.showConfirmDialog {
+				return m, nil
+			}
+			if m.focusedPanel == "right" && len(m.rightPanelItems) > 0 {
+				selectedItem := m.menuItems[m.selectedItem]
+				if selectedItem.Name == "RabbitMQ" {
+					return m.handleRabbitMQPurge()
+				} else if isDockerResourceMenu(selectedItem.Name) {
+					return m.handleDockerResourcePrune()
 				}
 			}
 
@@ -708,23 +1072,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// スクロール（右パネルで詳細表示時のみ）
 		case "ctrl+d":
-			if m.showLogView {
-				m.logScroll += 5
-				return m, nil
-			}
 			if m.focusedPanel == "right" {
 				m.detailScroll += 5
 				return m, nil
 			}
 
 		case "ctrl+u":
-			if m.showLogView {
-				m.logScroll -= 5
-				if m.logScroll < 0 {
-					m.logScroll = 0
-				}
-				return m, nil
-			}
 			if m.focusedPanel == "right" {
 				m.detailScroll -= 5
 				if m.detailScroll < 0 {
@@ -747,65 +1100,87 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 			if m.showLogView {
-				m.showLogView = false
-				m.logContent = ""
-				m.logScroll = 0
-				m.logTargetName = ""
+				m = m.closeLogView()
 				return m, nil
 			}
+			// n: プロセスを起動（出力をキャプチャする）
+			if msg.String() == "n" {
+				selectedItem := m.menuItems[m.selectedItem]
+				if selectedItem.Name == "Node.js" || selectedItem.Name == "Python" || isRuntimeMenu(selectedItem.Name) {
+					return m.handleLaunchPrompt()
+				}
+			}
 
 		// [a] キーでAI分析開始（AI分析メニュー選択時のみ）
 		case "a":
 			selectedItem := m.menuItems[m.selectedItem]
 			if selectedItem.Type == "ai" && m.aiState != aiStateLoading {
-				if !m.ollamaAvailable {
+				if !m.aiAvailable {
 					m.aiState = aiStateError
-					m.aiResponse = "Ollamaサーバーに接続できません。\nOllamaが起動しているか確認してください。"
+					m.aiResponse = m.aiUnavailableMessage()
 					return m, nil
 				}
 				m.aiState = aiStateLoading
 				m.aiResponse = ""
 				m.aiPendingCmd = "" // リセット
 				m.aiCmdResult = ""  // リセット
-				return m, m.runAIAnalysisCmd()
+				m.aiHistory = nil   // 新しい会話として分析する
+				m.aiToolTrace = nil
+				// プレビューを表示中なら確認した内容をそのまま送る
+				var previewed []llm.Message
+				m, previewed = m.takeAIPreview()
+				return m, m.runAIAnalysisCmd(previewed)
+			}
+
+		// [P] キーで送信内容のプレビュー（AI分析メニュー選択時のみ）
+		case "P":
+			if m.menuItems[m.selectedItem].Type == "ai" {
+				return m.handleAIPreviewToggle()
+			}
+
+		// [T] キーでツールを使った調査の切り替え（AI分析メニュー選択時のみ）
+		case "T":
+			if m.menuItems[m.selectedItem].Type == "ai" {
+				return m.handleAIAgentModeToggle()
 			}
 
 		// [tab] キーでモデル切り替え（AI分析メニュー選択時のみ）
 		case "tab":
-			selectedItem := m.menuItems[m.selectedItem]
-			if selectedItem.Type == "ai" && len(m.availableModels) > 0 {
-				m.selectedModel = (m.selectedModel + 1) % len(m.availableModels)
-				m.aiService.SetModel(m.availableModels[m.selectedModel])
+			if m.menuItems[m.selectedItem].Type == "ai" {
+				return m.handleAIModelNext()
 			}
 		} // switch msg.String() をここで閉じる
 
-	case containerLogsMsg:
-		// コンテナログの取得結果を処理
-		if msg.err != nil {
-			m.lastCommandResult = fmt.Sprintf("ログ取得失敗: %v", msg.err)
-			return m, nil
-		} // ← ここに if の閉じ括弧が抜けていました
+	case logStreamStartMsg:
+		return m.handleLogStreamStart(msg)
 
-		m.showLogView = true
-		m.logContent = msg.content
-		m.logScroll = 999999 // 一番下から表示（view_logs.goで自動調整される）
-		m.logTargetName = msg.targetName
+	case logLinesMsg:
+		return m.handleLogLines(msg)
 
+	case managedProcessesMsg:
+		monitor.RememberManagedProcesses(msg.processes)
 		return m, nil
 
-	case processLogsMsg:
-		// プロセスログの取得結果を処理
-		if msg.err != nil {
-			m.lastCommandResult = fmt.Sprintf("ログ取得失敗: %v", msg.err)
-			return m, nil
-		}
+	case managedLaunchMsg:
+		return m.handleManagedLaunch(msg)
 
-		m.showLogView = true
-		m.logContent = msg.content
-		m.logScroll = 999999 // 一番下から表示（view_logs.goで自動調整される）
-		m.logTargetName = msg.targetName
+	case logIssuesMsg:
+		return m.handleLogIssues(msg)
 
-		return m, nil
+	case aiSnapshotMsg:
+		return m.handleAISnapshot(msg)
+
+	case aiPreviewMsg:
+		return m.handleAIPreview(msg)
+
+	case aiAgentStartMsg:
+		return m.handleAIAgentStart(msg)
+
+	case aiAgentEventMsg:
+		return m.handleAIAgentEvent(msg)
+
+	case aiConversationSavedMsg:
+		return m.handleAIConversationSaved(msg)
 
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
@@ -868,6 +1243,49 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if selectedItem.Name == "PostgreSQL" {
 					cmds = append(cmds, fetchPostgresConnectionCmd())
 				}
+				// MySQLが選択されている場合、サーバー統計・プロセスリスト・テーブル一覧も非同期で取得
+				if selectedItem.Name == "MySQL" {
+					cmds = append(cmds, fetchMySQLStatusCmd())
+					if database := m.getSelectedMySQLDatabase(); database != nil {
+						cmds = append(cmds, fetchMySQLTablesCmd(database.Name))
+					}
+				}
+				// Redisが選択されている場合、サーバー統計とスローログも非同期で取得
+				if selectedItem.Name == "Redis" {
+					cmds = append(cmds, fetchRedisInfoCmd())
+				}
+				// MongoDBが選択されている場合、サーバー統計・実行中オペレーション・コレクション一覧も非同期で取得
+				if selectedItem.Name == "MongoDB" {
+					cmds = append(cmds, fetchMongoStatusCmd())
+					if database := m.getSelectedMongoDatabase(); database != nil {
+						cmds = append(cmds, fetchMongoCollectionsCmd(database.Name))
+					}
+				}
+				// メッセージブローカーが選択されている場合、キュー・トピック情報も非同期で取得
+				if selectedItem.Name == "RabbitMQ" {
+					cmds = append(cmds, fetchRabbitMQDataCmd())
+				}
+				if selectedItem.Name == "Kafka" {
+					if cmd := m.fetchKafkaStatusCmd(); cmd != nil {
+						cmds = append(cmds, cmd)
+					}
+				}
+				// Elasticsearchが選択されている場合、クラスタヘルス・ノード・保留タスクも非同期で取得
+				if selectedItem.Name == "Elasticsearch" {
+					cmds = append(cmds, fetchElasticsearchStatusCmd())
+				}
+				// Dockerのボリューム・ネットワーク・イメージは取得が重いため間隔を空けて取得
+				if isDockerResourceMenu(selectedItem.Name) {
+					if cmd := m.fetchDockerResourcesCmd(selectedItem.Name); cmd != nil {
+						cmds = append(cmds, cmd)
+					}
+				}
+				// JVMプロセスを選択している場合、jcmdでヒープ使用量も非同期で取得
+				if selectedItem.Name == "JVM" {
+					if process := m.getSelectedRuntimeProcess(); process != nil {
+						cmds = append(cmds, fetchJVMHeapCmd(process.PID))
+					}
+				}
 			}
 		} else if selectedItem.Type == "info" {
 			// ポート一覧: 3秒ごと（選択中、高速更新）
@@ -890,11 +1308,37 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		// 設定タブ表示中: カーソルが別のコンテナに移ったら設定を取得
+		if m.showContainerConfig && selectedItem.Name == "Docker" && m.focusedPanel == "right" {
+			if cmd := m.fetchContainerConfigCmd(); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+		}
+
+		// 3秒ごと: 選択中のComposeプロジェクトの構成（依存関係グラフ用）
+		if m.tickCount%3 == 0 && selectedItem.Name == "Docker" && m.focusedPanel == "right" {
+			if cmd := m.fetchComposeConfigCmd(); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+		}
+
 		// 10秒ごと: 選択されていないサービスをバックグラウンド更新
 		if m.tickCount%10 == 0 {
 			cmds = append(cmds, m.fetchNonSelectedServicesCmd())
 		}
 
+		// 10秒ごと: devmon run で起動したプロセスの一覧を読み直す（別の端末から起動したものを含む）
+		if m.tickCount%10 == 0 && m.dbStore != nil {
+			cmds = append(cmds, loadManagedProcessesCmd(m.dbStore))
+		}
+
+		// 30秒ごと: 動いているコンテナ・プロセスのログを保存し、エラーの急増・新しいエラーを検知
+		if m.tickCount%logWatchInterval == 0 {
+			if cmd := collectLogIssuesCmd(m.logWatcher, m.dbStore, m.cachedContainers); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+		}
+
 		if m.tickCount%10 == 0 {
 			logger.LogSystemResources(
 				m.systemResources.CPUUsage,
@@ -937,12 +1381,27 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if selectedItem.Name == "Docker" {
 			// Dockerの場合: コンテナ統計とリストを更新
 			updateCmds = append(updateCmds, m.fetchContainerStatsCmd())
-		} else if selectedItem.Name == "PostgreSQL" || selectedItem.Name == "MySQL" || selectedItem.Name == "Redis" {
+		} else if selectedItem.Name == "PostgreSQL" || selectedItem.Name == "MySQL" || selectedItem.Name == "Redis" || selectedItem.Name == "MongoDB" || selectedItem.Name == "Elasticsearch" {
 			// データベースの場合: 右パネルを更新
 			m = m.updateRightPanelItems()
-		} else if selectedItem.Name == "Node.js" || selectedItem.Name == "Python" {
+			// Redisキーブラウザを開いている場合はキーも再取得
+			if selectedItem.Name == "Redis" && m.redisBrowseDB != "" {
+				updateCmds = append(updateCmds, fetchRedisKeysCmd(m.redisBrowseDB, m.redisKeyPattern))
+			}
+		} else if selectedItem.Name == "Node.js" || selectedItem.Name == "Python" || isRuntimeMenu(selectedItem.Name) {
 			// プロセスの場合: 右パネルを更新
 			m = m.updateRightPanelItems()
+			// devmon run で再起動した場合は新しいPIDを読み直す
+			if m.dbStore != nil {
+				updateCmds = append(updateCmds, loadManagedProcessesCmd(m.dbStore))
+			}
+		} else if selectedItem.Name == "RabbitMQ" {
+			// RabbitMQの場合: キュー一覧を再取得
+			updateCmds = append(updateCmds, fetchRabbitMQDataCmd())
+		} else if isDockerResourceMenu(selectedItem.Name) {
+			// Dockerリソースの場合: 取得間隔を待たずに再取得
+			delete(m.dockerFetchedAt, selectedItem.Name)
+			updateCmds = append(updateCmds, m.fetchDockerResourcesCmd(selectedItem.Name))
 		}
 
 		updateCmds = append(updateCmds,
@@ -965,6 +1424,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		// コンテナリストのキャッシュも更新
 		m.cachedContainers = msg.ContainersList
+		m.cachedComposeProjects = msg.ComposeProjects
 
 		// Dockerパネルが選択されている場合のみ右パネルを更新
 		selectedItem := m.menuItems[m.selectedItem]
@@ -974,6 +1434,51 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		return m, nil
 
+	case dockerResourcesMsg:
+		// Dockerリソースのキャッシュを更新
+		m.cachedDockerDiskUsage = msg.DiskUsage
+		switch msg.Menu {
+		case "Docker Volumes":
+			m.cachedDockerVolumes = msg.Volumes
+		case "Docker Networks":
+			m.cachedDockerNetworks = msg.Networks
+		case "Docker Images":
+			m.cachedDockerImages = msg.Images
+		}
+		m.dockerFetchedAt[msg.Menu] = time.Now()
+
+		// 対象パネルが選択されている場合のみ右パネルを更新
+		if m.menuItems[m.selectedItem].Name == msg.Menu {
+			m = m.updateRightPanelItems()
+		}
+		return m, nil
+
+	case shellReadyMsg:
+		return m.handleShellReady(msg)
+
+	case containerConfigMsg:
+		return m.handleContainerConfig(msg)
+
+	case dockerRunExportMsg:
+		return m.handleDockerRunExport(msg)
+
+	case shellExitedMsg:
+		return m.handleShellExited(msg)
+
+	case composeConfigMsg:
+		// compose configのキャッシュを更新
+		cache := &ComposeConfigCache{Config: msg.Config, UpdatedAt: time.Now()}
+		if msg.Err != nil {
+			cache.Err = msg.Err.Error()
+		}
+		m.composeConfigCache[msg.Project] = cache
+
+		// 未作成のサービスを右パネルに反映
+		if m.menuItems[m.selectedItem].Name == "Docker" {
+			m = m.updateRightPanelItems()
+		}
+		return m, nil
+
 	case portsDataMsg:
 		// ポート一覧のキャッシュを更新
 		m.cachedPorts = msg.Ports
@@ -1004,6 +1509,103 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.cachedPostgresConnection = monitor.PostgresConnection(msg)
 		return m, nil
 
+	case mysqlStatusMsg:
+		// MySQLサーバー統計とプロセスリストのキャッシュを更新
+		m.cachedMySQLStatus = msg.Status
+		m.cachedMySQLProcesses = msg.Processes
+
+		// MySQLパネルが選択されている場合のみ右パネルを更新
+		selectedItem := m.menuItems[m.selectedItem]
+		if selectedItem.Name == "MySQL" {
+			m = m.updateRightPanelItems()
+		}
+
+		return m, nil
+
+	case mysqlTablesMsg:
+		// テーブル一覧のキャッシュを更新
+		m.cachedMySQLTables[msg.Database] = msg.Tables
+		return m, nil
+
+	case mongoStatusMsg:
+		// MongoDBサーバー統計と実行中オペレーションのキャッシュを更新
+		m.cachedMongoStatus = msg.Status
+		m.cachedMongoOps = msg.Ops
+		return m, nil
+
+	case mongoCollectionsMsg:
+		// コレクション一覧のキャッシュを更新
+		m.cachedMongoCollections[msg.Database] = msg.Collections
+		return m, nil
+
+	case elasticsearchStatusMsg:
+		// Elasticsearchクラスタ情報のキャッシュを更新
+		m.cachedESCluster = msg.Cluster
+		m.cachedESNodes = msg.Nodes
+		m.cachedESPendingTasks = msg.PendingTasks
+		return m, nil
+
+	case jvmHeapMsg:
+		// JVMヒープ使用量のキャッシュを更新
+		if msg.Heap != "" {
+			m.cachedJVMHeap[msg.PID] = msg.Heap
+		}
+		return m, nil
+
+	case rabbitMQDataMsg:
+		// RabbitMQ概要とキュー一覧のキャッシュを更新
+		m.cachedRabbitMQOverview = msg.Overview
+		m.cachedRabbitMQQueues = msg.Queues
+
+		// RabbitMQパネルが選択されている場合のみ右パネルを更新
+		selectedItem := m.menuItems[m.selectedItem]
+		if selectedItem.Name == "RabbitMQ" {
+			m = m.updateRightPanelItems()
+		}
+
+		return m, nil
+
+	case kafkaStatusMsg:
+		// Kafkaトピック・コンシューマーグループのキャッシュを更新
+		m.cachedKafkaStatus = monitor.KafkaStatus(msg)
+		m.kafkaFetching = false
+
+		// Kafkaパネルが選択されている場合のみ右パネルを更新
+		selectedItem := m.menuItems[m.selectedItem]
+		if selectedItem.Name == "Kafka" {
+			m = m.updateRightPanelItems()
+		}
+
+		return m, nil
+
+	case redisInfoMsg:
+		// Redisサーバー統計とスローログのキャッシュを更新
+		m.cachedRedisInfo = msg.Info
+		m.cachedRedisSlowLog = msg.SlowLog
+		return m, nil
+
+	case redisKeysMsg:
+		// 別のDB・パターンに切り替わった後の古い結果は捨てる
+		if msg.Database != m.redisBrowseDB || msg.Pattern != m.redisKeyPattern {
+			return m, nil
+		}
+
+		m.redisKeysLoading = false
+		m.redisKeysError = ""
+		m.cachedRedisKeys = msg.Keys
+		if msg.Err != nil {
+			m.redisKeysError = msg.Err.Error()
+			m.cachedRedisKeys = []monitor.RedisKey{}
+		}
+
+		// Redisパネルが選択されている場合のみ右パネルを更新
+		selectedItem := m.menuItems[m.selectedItem]
+		if selectedItem.Name == "Redis" {
+			m = m.updateRightPanelItems()
+		}
+
+		return m, nil
+
 		// AI分析結果の受信
 	case aiAnalysisMsg:
 		if msg.Err != nil {
@@ -1014,24 +1616,23 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.aiResponse = msg.Result
 
 			// コマンドが含まれているかチェック
-			matches := cmdRegex.FindStringSubmatch(msg.Result)
-			if len(matches) > 1 {
-				m.aiPendingCmd = matches[1] // コマンド部分を抽出して保存
-			} else {
-				m.aiPendingCmd = ""
-			}
+			m = m.proposeAICommand(msg.Result)
 		}
 		return m, nil
 
 	// コマンド実行結果の受信
 	case cmdExecMsg:
 		m.aiCmdResult = msg.Result
+		if msg.DryRun {
+			return m, nil
+		}
 		// 実行後に最新の状態を反映するため、全サービス再取得をトリガー
 		return m, m.fetchAllServicesCmd()
 
 	// ストリーミング開始の受信
 	case aiStreamStartMsg:
-		m.currentStream = msg
+		m.aiHistory = msg.History
+		m.currentStream = msg.Stream
 		return m, waitForStreamResponse(m.currentStream)
 
 	// ストリーミングデータの受信
@@ -1040,6 +1641,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.aiState = aiStateError
 			m.aiResponse += "\n\nエラーが発生しました:\n" + msg.Err.Error()
 			m.currentStream = nil
+			// 回答のない質問は会話から外す（再度質問できるように）
+			if n := len(m.aiHistory); n > 0 && m.aiHistory[n-1].Role == "user" {
+				m.aiHistory = m.aiHistory[:n-1]
+			}
 			return m, nil
 		}
 
@@ -1048,11 +1653,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		if msg.Done {
 			m.aiState = aiStateSuccess
-			// コマンド解析は完了後に実行
-			matches := cmdRegex.FindStringSubmatch(m.aiResponse)
-			if len(matches) > 1 {
-				m.aiPendingCmd = matches[1]
+			// 続けて質問できるように回答を会話に残す
+			if len(m.aiHistory) > 0 {
+				m.aiHistory = append(m.aiHistory, llm.Message{Role: "assistant", Content: m.aiResponse})
 			}
+			// コマンド解析は完了後に実行
+			m = m.proposeAICommand(m.aiResponse)
 			m.currentStream = nil
 			return m, nil
 		}
@@ -1060,68 +1666,46 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// まだ終わっていない場合、次のデータを待つ
 		return m, waitForStreamResponse(m.currentStream)
 
-	// Ollamaヘルスチェック結果の受信
+	// LLMサーバーのヘルスチェック結果の受信
 	case aiHealthMsg:
 		if msg.Err == nil {
-			m.ollamaAvailable = true
+			m.aiAvailable = true
 		} else {
-			m.ollamaAvailable = false
+			m.aiAvailable = false
 		}
 		return m, nil
 
 	// モデル一覧取得結果の受信
 	case aiModelsMsg:
-		if msg.Err == nil && len(msg.Models) > 0 {
-			m.availableModels = msg.Models
-			// デフォルトモデルがリストにあるか確認
-			currentModel := m.aiService.GetModel()
-			for i, model := range m.availableModels {
-				if model == currentModel {
-					m.selectedModel = i
-					break
-				}
-			}
-		}
-
-		return m, nil
+		return m.handleAIModels(msg)
 	}
 
 	return m, nil
 }
 
 // runAIAnalysisCmd は非同期でAI分析を実行（ストリーミングモード）
-func (m Model) runAIAnalysisCmd() tea.Cmd {
+// history がある場合（プレビューで確認した会話）は、システム情報を集め直さずにそのまま送る
+func (m Model) runAIAnalysisCmd(history []llm.Message) tea.Cmd {
 	return func() tea.Msg {
 		// コンテキスト構築（RAG）
-		// BuildSystemContext が system と user の2つを返すようになったため対応
-		sysPrompt, userContext := m.aiService.BuildSystemContext()
+		// 続けて質問できるように、システムプロンプトとレポートを会話の先頭として送る
+		if len(history) == 0 {
+			history = m.aiService.NewConversation()
+		}
+
+		// ツールを使う場合は必要な情報を取得しながら回答させる
+		if m.aiAgentMode {
+			return aiAgentStartMsg{History: history, Events: m.aiService.RunAgent(context.Background(), history)}
+		}
 
 		// ストリーミングモードで推論実行
-		stream, err := m.aiService.AnalyzeStream(context.Background(), sysPrompt, userContext)
+		stream, err := m.aiService.ChatStream(context.Background(), history)
 		if err != nil {
 			return aiAnalysisMsg{Err: err}
 		}
 
 		// ストリームチャネルをメッセージとして返す
-		return aiStreamStartMsg(stream)
-	}
-}
-
-// executePendingCmd はシェル経由でコマンドを実行
-func executePendingCmd(command string) tea.Cmd {
-	return func() tea.Msg {
-		// sh -c を使うことでパイプやリダイレクトを含むコマンドも実行可能
-		cmd := exec.Command("sh", "-c", command)
-		output, err := cmd.CombinedOutput()
-
-		result := ""
-		if err != nil {
-			result = fmt.Sprintf("✗ 実行エラー: %v\n%s", err, string(output))
-		} else {
-			result = fmt.Sprintf("✓ 実行成功:\n%s", string(output))
-		}
-
-		return cmdExecMsg{Result: result}
+		return aiStreamStartMsg{History: history, Stream: stream}
 	}
 }
 
@@ -1197,6 +1781,14 @@ func fetchServiceDataCmd(serviceName string) tea.Cmd {
 			data = monitor.CheckMySQL()
 		case "Redis":
 			data = monitor.CheckRedis()
+		case "MongoDB":
+			data = monitor.CheckMongoDB()
+		case "RabbitMQ":
+			data = monitor.CheckRabbitMQ()
+		case "Kafka":
+			data = monitor.CheckKafka()
+		case "Elasticsearch":
+			data = monitor.CheckElasticsearch()
 		case "Docker":
 			data = monitor.CheckDocker()
 		case "Node.js":
@@ -1229,7 +1821,11 @@ TOP5 リソース使用:
 					monitor.FormatDevProcesses(devProcs),
 				)
 			default:
-				data = serviceName + " のデータ"
+				if isRuntimeMenu(serviceName) {
+					data = monitor.CheckRuntime(serviceName)
+				} else {
+					data = serviceName + " のデータ"
+				}
 			}
 
 		return serviceDataMsg{
@@ -1267,12 +1863,15 @@ func (m Model) updateRightPanelItems() Model {
 	// 現在選択中のコンテナIDを保存
 	var currentSelectedContainerID string
 	var currentSelectedProjectName string
+	var currentSelectedItem *RightPanelItem
 	if m.rightPanelCursor < len(m.rightPanelItems) {
 		currentItem := m.rightPanelItems[m.rightPanelCursor]
 		if currentItem.Type == "container" {
 			currentSelectedContainerID = currentItem.ContainerID
 		} else if currentItem.Type == "project" {
 			currentSelectedProjectName = currentItem.Name
+		} else {
+			currentSelectedItem = &currentItem
 		}
 	}
 
@@ -1328,6 +1927,23 @@ func (m Model) updateRightPanelItems() Model {
 					ContainerID: c.ID,
 				})
 			}
+
+			// コンテナが未作成のサービスを追加
+			m.rightPanelItems = append(m.rightPanelItems, m.composeServiceItems(projectName, containers)...)
+		}
+
+		// コンテナが残っていない記憶済みのプロジェクトを追加（起動できるように）
+		for _, p := range m.cachedComposeProjects {
+			if _, exists := projects[p.Name]; exists {
+				continue
+			}
+			m.rightPanelItems = append(m.rightPanelItems, RightPanelItem{
+				Type:        "project",
+				Name:        p.Name,
+				ProjectName: p.Name,
+				IsExpanded:  expandedState[p.Name],
+			})
+			m.rightPanelItems = append(m.rightPanelItems, m.composeServiceItems(p.Name, nil)...)
 		}
 
 		// 単体コンテナを追加
@@ -1357,8 +1973,11 @@ func (m Model) updateRightPanelItems() Model {
 		processes := monitor.GetNodeProcesses()
 		m.cachedNodeProcesses = processes
 
-		// プロセスを追加
+		// プロセスを追加（エディタ内部のプロセスはトグルで表示）
 		for _, proc := range processes {
+			if proc.Role == monitor.NodeRoleEditor && !m.showEditorNodeProcesses {
+				continue
+			}
 			m.rightPanelItems = append(m.rightPanelItems, RightPanelItem{
 				Type: "process",
 				Name: proc.PID,
@@ -1378,6 +1997,14 @@ func (m Model) updateRightPanelItems() Model {
 			})
 		}
 
+		// プロセスリスト（接続）を追加
+		for _, proc := range m.cachedMySQLProcesses {
+			m.rightPanelItems = append(m.rightPanelItems, RightPanelItem{
+				Type: "mysql_process",
+				Name: proc.ID,
+			})
+		}
+
 	case "Redis":
 		// Redisデータベース一覧を取得
 		databases := monitor.GetRedisDatabases()
@@ -1391,6 +2018,88 @@ func (m Model) updateRightPanelItems() Model {
 			})
 		}
 
+		// キーブラウザのキーを追加
+		if m.redisBrowseDB != "" {
+			for _, key := range m.cachedRedisKeys {
+				m.rightPanelItems = append(m.rightPanelItems, RightPanelItem{
+					Type: "redis_key",
+					Name: key.Name,
+				})
+			}
+		}
+
+	case "MongoDB":
+		// MongoDBデータベース一覧を取得
+		databases := monitor.GetMongoDatabases()
+		m.cachedMongoDatabases = databases
+
+		// データベースを追加
+		for _, db := range databases {
+			m.rightPanelItems = append(m.rightPanelItems, RightPanelItem{
+				Type: "database",
+				Name: db.Name,
+			})
+		}
+
+	case "Elasticsearch":
+		// インデックス一覧を取得
+		indices := monitor.GetElasticsearchIndices()
+		m.cachedESIndices = indices
+
+		// インデックスを追加
+		for _, index := range indices {
+			m.rightPanelItems = append(m.rightPanelItems, RightPanelItem{
+				Type: "es_index",
+				Name: index.Name,
+			})
+		}
+
+	case "Docker Volumes":
+		// ボリューム一覧を追加（キャッシュから）
+		for _, volume := range m.cachedDockerVolumes {
+			m.rightPanelItems = append(m.rightPanelItems, RightPanelItem{
+				Type: "docker_volume",
+				Name: volume.Name,
+			})
+		}
+
+	case "Docker Networks":
+		// ネットワーク一覧を追加（キャッシュから）
+		for _, network := range m.cachedDockerNetworks {
+			m.rightPanelItems = append(m.rightPanelItems, RightPanelItem{
+				Type: "docker_network",
+				Name: network.Name,
+			})
+		}
+
+	case "Docker Images":
+		// イメージ一覧を追加（キャッシュから）
+		for _, image := range m.cachedDockerImages {
+			m.rightPanelItems = append(m.rightPanelItems, RightPanelItem{
+				Type: "docker_image",
+				Name: image.ID,
+			})
+		}
+
+	case "RabbitMQ":
+		// キュー一覧を追加（キャッシュから）
+		for _, queue := range m.cachedRabbitMQQueues {
+			m.rightPanelItems = append(m.rightPanelItems, RightPanelItem{
+				Type:        "rabbitmq_queue",
+				Name:        queue.Name,
+				ProjectName: queue.VHost,
+			})
+		}
+
+	case "Kafka":
+		// トピック一覧を追加（キャッシュから）
+		for _, topic := range m.cachedKafkaStatus.Topics {
+			m.rightPanelItems = append(m.rightPanelItems, RightPanelItem{
+				Type: "kafka_topic",
+				Name: topic.Name,
+			})
+		}
+
 	case "Python":
 		// Pythonプロセス一覧を取得
 		processes := monitor.GetPythonProcesses()
@@ -1432,8 +2141,22 @@ func (m Model) updateRightPanelItems() Model {
 		}
 
 	default:
-		// その他は選択不可
-		m.rightPanelItems = []RightPanelItem{}
+		if isRuntimeMenu(selectedItem.Name) {
+			// ランタイムのプロセス一覧を取得
+			processes := monitor.GetRuntimeProcesses(selectedItem.Name)
+			m.cachedRuntimeProcesses = processes
+
+			// プロセスを追加
+			for _, proc := range processes {
+				m.rightPanelItems = append(m.rightPanelItems, RightPanelItem{
+					Type: "runtime_process",
+					Name: proc.PID,
+				})
+			}
+		} else {
+			// その他は選択不可
+			m.rightPanelItems = []RightPanelItem{}
+		}
 	}
 
 	// カーソル位置を復元
@@ -1447,6 +2170,14 @@ func (m Model) updateRightPanelItems() Model {
 				break
 			}
 		}
+	} else if currentSelectedItem != nil {
+		// その他の項目は種類と名前（vhostなどの所属も含む）で復元
+		for i, item := range m.rightPanelItems {
+			if item.Type == currentSelectedItem.Type && item.Name == currentSelectedItem.Name && item.ProjectName == currentSelectedItem.ProjectName {
+				m.rightPanelCursor = i
+				break
+			}
+		}
 	}
 
 	// カーソル位置が範囲外の場合は調整
@@ -1531,6 +2262,16 @@ func (m Model) getSelectedContainer() *monitor.DockerContainer {
 	return nil
 }
 
+// getComposeProject returns the cached compose project configuration by name
+func (m Model) getComposeProject(projectName string) *monitor.ComposeProject {
+	for i := range m.cachedComposeProjects {
+		if m.cachedComposeProjects[i].Name == projectName {
+			return &m.cachedComposeProjects[i]
+		}
+	}
+	return nil
+}
+
 // getSelectedDatabase returns the currently selected database
 func (m Model) getSelectedDatabase() *monitor.PostgresDatabase {
 	if m.rightPanelCursor >= len(m.rightPanelItems) {
@@ -1577,6 +2318,29 @@ func (m Model) getSelectedNodeProcess() *monitor.NodeProcess {
 	return nil
 }
 
+// getSelectedRuntimeProcess returns the currently selected Go/Ruby/JVM/Deno/Bun/PHP process
+func (m Model) getSelectedRuntimeProcess() *monitor.RuntimeProcess {
+	if m.rightPanelCursor >= len(m.rightPanelItems) {
+		return nil
+	}
+
+	selectedItem := m.rightPanelItems[m.rightPanelCursor]
+
+	// プロセス以外はnil
+	if selectedItem.Type != "runtime_process" {
+		return nil
+	}
+
+	// PIDから検索
+	for i := range m.cachedRuntimeProcesses {
+		if m.cachedRuntimeProcesses[i].PID == selectedItem.Name {
+			return &m.cachedRuntimeProcesses[i]
+		}
+	}
+
+	return nil
+}
+
 // getSelectedMySQLDatabase returns the currently selected MySQL database
 func (m Model) getSelectedMySQLDatabase() *monitor.MySQLDatabase {
 	if m.rightPanelCursor >= len(m.rightPanelItems) {
@@ -1600,6 +2364,29 @@ func (m Model) getSelectedMySQLDatabase() *monitor.MySQLDatabase {
 	return nil
 }
 
+// getSelectedMySQLProcess returns the currently selected MySQL connection
+func (m Model) getSelectedMySQLProcess() *monitor.MySQLProcess {
+	if m.rightPanelCursor >= len(m.rightPanelItems) {
+		return nil
+	}
+
+	selectedItem := m.rightPanelItems[m.rightPanelCursor]
+
+	// 接続以外はnil
+	if selectedItem.Type != "mysql_process" {
+		return nil
+	}
+
+	// 接続IDから検索
+	for i := range m.cachedMySQLProcesses {
+		if m.cachedMySQLProcesses[i].ID == selectedItem.Name {
+			return &m.cachedMySQLProcesses[i]
+		}
+	}
+
+	return nil
+}
+
 // getSelectedRedisDatabase returns the currently selected Redis database
 func (m Model) getSelectedRedisDatabase() *monitor.RedisDatabase {
 	if m.rightPanelCursor >= len(m.rightPanelItems) {
@@ -1623,6 +2410,121 @@ func (m Model) getSelectedRedisDatabase() *monitor.RedisDatabase {
 	return nil
 }
 
+// getSelectedMongoDatabase returns the currently selected MongoDB database
+func (m Model) getSelectedMongoDatabase() *monitor.MongoDatabase {
+	if m.rightPanelCursor >= len(m.rightPanelItems) {
+		return nil
+	}
+
+	selectedItem := m.rightPanelItems[m.rightPanelCursor]
+
+	// データベース以外はnil
+	if selectedItem.Type != "database" {
+		return nil
+	}
+
+	// データベース名から検索
+	for i := range m.cachedMongoDatabases {
+		if m.cachedMongoDatabases[i].Name == selectedItem.Name {
+			return &m.cachedMongoDatabases[i]
+		}
+	}
+
+	return nil
+}
+
+// getSelectedElasticsearchIndex returns the currently selected Elasticsearch index
+func (m Model) getSelectedElasticsearchIndex() *monitor.ElasticsearchIndex {
+	if m.rightPanelCursor >= len(m.rightPanelItems) {
+		return nil
+	}
+
+	selectedItem := m.rightPanelItems[m.rightPanelCursor]
+
+	// インデックス以外はnil
+	if selectedItem.Type != "es_index" {
+		return nil
+	}
+
+	// インデックス名から検索
+	for i := range m.cachedESIndices {
+		if m.cachedESIndices[i].Name == selectedItem.Name {
+			return &m.cachedESIndices[i]
+		}
+	}
+
+	return nil
+}
+
+// getSelectedRabbitMQQueue returns the currently selected RabbitMQ queue
+func (m Model) getSelectedRabbitMQQueue() *monitor.RabbitMQQueue {
+	if m.rightPanelCursor >= len(m.rightPanelItems) {
+		return nil
+	}
+
+	selectedItem := m.rightPanelItems[m.rightPanelCursor]
+
+	// キュー以外はnil
+	if selectedItem.Type != "rabbitmq_queue" {
+		return nil
+	}
+
+	// vhostとキュー名から検索
+	for i := range m.cachedRabbitMQQueues {
+		queue := &m.cachedRabbitMQQueues[i]
+		if queue.VHost == selectedItem.ProjectName && queue.Name == selectedItem.Name {
+			return queue
+		}
+	}
+
+	return nil
+}
+
+// getSelectedKafkaTopic returns the currently selected Kafka topic
+func (m Model) getSelectedKafkaTopic() *monitor.KafkaTopic {
+	if m.rightPanelCursor >= len(m.rightPanelItems) {
+		return nil
+	}
+
+	selectedItem := m.rightPanelItems[m.rightPanelCursor]
+
+	// トピック以外はnil
+	if selectedItem.Type != "kafka_topic" {
+		return nil
+	}
+
+	for i := range m.cachedKafkaStatus.Topics {
+		if m.cachedKafkaStatus.Topics[i].Name == selectedItem.Name {
+			return &m.cachedKafkaStatus.Topics[i]
+		}
+	}
+
+	return nil
+}
+
+// getSelectedRedisKey returns the currently selected Redis key
+func (m Model) getSelectedRedisKey() *monitor.RedisKey {
+	if m.rightPanelCursor >= len(m.rightPanelItems) {
+		return nil
+	}
+
+	selectedItem := m.rightPanelItems[m.rightPanelCursor]
+
+	// キー以外はnil
+	if selectedItem.Type != "redis_key" {
+		return nil
+	}
+
+	// キー名から検索
+	for i := range m.cachedRedisKeys {
+		if m.cachedRedisKeys[i].Name == selectedItem.Name {
+			return &m.cachedRedisKeys[i]
+		}
+	}
+
+	return nil
+}
+
 // getSelectedPythonProcess returns the currently selected Python process
 func (m Model) getSelectedPythonProcess() *monitor.PythonProcess {
 	if m.rightPanelCursor >= len(m.rightPanelItems) {
@@ -1680,6 +2582,11 @@ func (m Model) executeCommand() (Model, tea.Cmd) {
 	m.confirmTarget = ""
 	m.confirmType = ""
 
+	// devmon run で起動したプロセスは、停止してよいかをDBの記録と照合してから再起動する
+	if targetType == "managed_process" {
+		return m, restartManagedProcessCmd(m.dbStore, target)
+	}
+
 	// コマンドを非同期で実行
 	return m, executeCommandCmd(target, action, targetType)
 }
@@ -1699,12 +2606,24 @@ func executeCommandCmd(target, action, targetType string) tea.Cmd {
 			result = monitor.ExecutePostgresCommand(target, action)
 		} else if targetType == "mysql_database" {
 			result = monitor.ExecuteMySQLCommand(target, action)
+		} else if targetType == "mysql_process" {
+			result = monitor.ExecuteMySQLKillQuery(target)
 		} else if targetType == "redis_database" {
 			result = monitor.ExecuteRedisCommand(target, action)
+		} else if targetType == "mongo_database" {
+			result = monitor.ExecuteMongoCommand(target, action)
+		} else if targetType == "es_index" {
+			result = monitor.ExecuteElasticsearchCommand(target, action)
+		} else if targetType == "rabbitmq_queue" {
+			result = monitor.ExecuteRabbitMQPurge(target)
+		} else if targetType == "redis_key" {
+			result = monitor.ExecuteRedisDeleteKey(target)
 		} else if targetType == "process" {
 			result = monitor.ExecuteNodeCommand(target, action)
 		} else if targetType == "python_process" {
 			result = monitor.ExecutePythonCommand(target, action)
+		} else if targetType == "runtime_process" {
+			result = monitor.ExecuteRuntimeCommand(target, action)
 		} else if targetType == "port" {
 			result = monitor.ExecutePortCommand(target, action)
 		} else if targetType == "top_process" {
@@ -1714,9 +2633,20 @@ func executeCommandCmd(target, action, targetType string) tea.Cmd {
 			} else if action == "force_kill_top_process" {
 				result = monitor.ExecutePortCommand(target, "force_kill_port")
 			}
+		} else if targetType == "docker_volume" || targetType == "docker_network" || targetType == "docker_image" {
+			result = monitor.ExecuteDockerResourceCommand(target, action)
 		} else if targetType == "docker_system" {
-			if action == "clean_dangling" {
+			switch action {
+			case "clean_dangling":
 				result = monitor.CleanDanglingImages()
+			case "prune_volumes":
+				result = monitor.PruneOrphanedVolumes()
+			case "prune_networks":
+				result = monitor.PruneNetworks()
+			case "prune_unused_images":
+				result = monitor.PruneUnusedImages()
+			case "prune_build_cache":
+				result = monitor.PruneBuildCache()
 			}
 		} else {
 			result = monitor.ExecuteDockerCommand(target, action, targetType)
@@ -1769,9 +2699,18 @@ func (m Model) fetchContainerStatsCmd() tea.Cmd {
 
 		close(results)
 
+		// Composeプロジェクトの設定をラベルから取得して記憶する（変わったものだけ保存）
+		changed := monitor.RememberComposeProjects(monitor.GetComposeProjects(containers))
+		if m.dbStore != nil {
+			if err := m.dbStore.SaveComposeProjects(changed); err != nil {
+				logger.LogIssue("DB_WRITE_ERROR", err.Error())
+			}
+		}
+
 		return containerStatsMsg{
-			Containers:     cacheMap,
-			ContainersList: containers,
+			Containers:      cacheMap,
+			ContainersList:  containers,
+			ComposeProjects: monitor.KnownComposeProjects(),
 		}
 	}
 }
@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"github.com/Masahide-S/bho_hacka_go/internal/ai"
+	"github.com/Masahide-S/bho_hacka_go/internal/llm"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// aiAgentStartMsg is sent when the agent has started answering with tools
+type aiAgentStartMsg struct {
+	History []llm.Message
+	Events  <-chan ai.AgentEvent
+}
+
+// aiAgentEventMsg carries a tool call or the final answer of the agent
+type aiAgentEventMsg ai.AgentEvent
+
+// waitForAgentEvent waits for the next event of the agent
+func waitForAgentEvent(events <-chan ai.AgentEvent) tea.Cmd {
+	return func() tea.Msg {
+		event, ok := <-events
+		if !ok {
+			// チャネルが閉じられた場合は完了とみなす
+			return aiAgentEventMsg{Done: true}
+		}
+		return aiAgentEventMsg(event)
+	}
+}
+
+// handleAIAgentModeToggle switches between answering from the snapshot only and investigating with tools
+func (m Model) handleAIAgentModeToggle() (Model, tea.Cmd) {
+	if m.aiState == aiStateLoading {
+		return m, nil
+	}
+	m.aiAgentMode = !m.aiAgentMode
+	return m, nil
+}
+
+// handleAIAgentStart starts receiving the events of the agent
+func (m Model) handleAIAgentStart(msg aiAgentStartMsg) (Model, tea.Cmd) {
+	m.aiHistory = msg.History
+	m.aiAgentEvents = msg.Events
+	m.aiToolTrace = nil
+	return m, waitForAgentEvent(m.aiAgentEvents)
+}
+
+// handleAIAgentEvent shows a tool call or the final answer of the agent
+func (m Model) handleAIAgentEvent(msg aiAgentEventMsg) (Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.aiState = aiStateError
+		m.aiResponse = "エラーが発生しました:\n" + msg.Err.Error() + "\n\n（ツールに対応していないモデルの場合は T でツールを使わないモードに切り替えてください）"
+		m.aiAgentEvents = nil
+		// 回答のない質問は会話から外す（再度質問できるように）
+		if n := len(m.aiHistory); n > 0 && m.aiHistory[n-1].Role == "user" {
+			m.aiHistory = m.aiHistory[:n-1]
+		}
+		return m, nil
+	}
+
+	if msg.Trace != nil {
+		m.aiToolTrace = append(append([]ai.ToolTrace{}, m.aiToolTrace...), *msg.Trace)
+		return m, waitForAgentEvent(m.aiAgentEvents)
+	}
+
+	if msg.Done {
+		m.aiState = aiStateSuccess
+		m.aiAgentEvents = nil
+		if msg.History != nil {
+			m.aiHistory = msg.History
+			m.aiResponse = msg.Answer
+		}
+		// コマンド解析は完了後に実行
+		m = m.proposeAICommand(m.aiResponse)
+		return m, nil
+	}
+
+	return m, waitForAgentEvent(m.aiAgentEvents)
+}
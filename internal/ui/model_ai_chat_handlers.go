@@ -0,0 +1,214 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/llm"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// aiConversationTitleLen は保存する会話のタイトルにする質問の最大文字数
+const aiConversationTitleLen = 40
+
+// aiSnapshotMsg is sent when the system snapshot of the conversation has been refreshed
+type aiSnapshotMsg struct {
+	History []llm.Message
+}
+
+// aiConversationSavedMsg is sent when the conversation has been saved to the store
+type aiConversationSavedMsg struct {
+	ID  int64
+	Err error
+}
+
+// handleAIChatPrompt starts typing a question to the AI
+func (m Model) handleAIChatPrompt() (Model, tea.Cmd) {
+	if m.aiState == aiStateLoading || m.aiPendingCmd != "" {
+		return m, nil
+	}
+	m.aiChatEditing = true
+	m.aiChatInput = ""
+	return m, nil
+}
+
+// handleAIChatInput handles typing a question or a chat command (/clear, /refresh, /save)
+func (m Model) handleAIChatInput(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.aiChatEditing = false
+		input := strings.TrimSpace(m.aiChatInput)
+		m.aiChatInput = ""
+		if input == "" {
+			return m, nil
+		}
+		if strings.HasPrefix(input, "/") {
+			return m.handleAIChatCommand(input)
+		}
+		return m.askAI(input)
+
+	case tea.KeyEsc:
+		m.aiChatEditing = false
+		m.aiChatInput = ""
+		return m, nil
+
+	case tea.KeyBackspace:
+		runes := []rune(m.aiChatInput)
+		if len(runes) > 0 {
+			m.aiChatInput = string(runes[:len(runes)-1])
+		}
+		return m, nil
+
+	case tea.KeyRunes, tea.KeySpace:
+		m.aiChatInput += string(msg.Runes)
+		return m, nil
+	}
+
+	return m, nil
+}
+
+// handleAIChatCommand runs a chat command
+func (m Model) handleAIChatCommand(input string) (Model, tea.Cmd) {
+	command, arg, _ := strings.Cut(input, " ")
+	switch command {
+	case "/clear", "/reset":
+		// 会話とレポートを捨てて最初からやり直す
+		m = m.closeAIPreview()
+		m.aiHistory = nil
+		m.aiToolTrace = nil
+		m.aiResponse = ""
+		m.aiPendingCmd = ""
+		m.aiCmdResult = ""
+		m.aiState = aiStateIdle
+		return m, nil
+
+	case "/refresh":
+		if len(m.aiHistory) == 0 {
+			m.aiCmdResult = "✗ 会話がありません（質問すると最新の状態で会話を始めます）"
+			return m, nil
+		}
+		m.aiCmdResult = "システム状況レポートを更新中..."
+		return m, refreshAISnapshotCmd(m, m.aiHistory)
+
+	case "/save":
+		if len(m.aiHistory) == 0 {
+			m.aiCmdResult = "✗ 保存する会話がありません"
+			return m, nil
+		}
+		title := strings.TrimSpace(arg)
+		if title == "" {
+			title = defaultConversationTitle(m.aiHistory)
+		}
+		return m, saveAIConversationCmd(m, title, m.aiHistory)
+	}
+
+	m.aiCmdResult = fmt.Sprintf("✗ 不明なコマンドです: %s（/clear, /refresh, /save [タイトル]）", command)
+	return m, nil
+}
+
+// askAI sends a question, starting a new conversation if there is none
+func (m Model) askAI(question string) (Model, tea.Cmd) {
+	if !m.aiAvailable {
+		m.aiState = aiStateError
+		m.aiResponse = m.aiUnavailableMessage()
+		return m, nil
+	}
+
+	// Model は値で受け渡されるため、履歴はコピーしてから追記する
+	// 会話がなくプレビューを表示中なら、確認したレポートから会話を始める
+	m, previewed := m.takeAIPreview()
+	history := append([]llm.Message{}, m.aiHistory...)
+	if len(history) == 0 {
+		history = append(history, previewed...)
+	}
+	history = append(history, llm.Message{Role: "user", Content: question})
+	if len(m.aiHistory) > 0 {
+		m.aiHistory = history
+	}
+
+	m.aiState = aiStateLoading
+	m.aiResponse = ""
+	m.aiPendingCmd = ""
+	m.aiCmdResult = ""
+	m.aiToolTrace = nil
+	return m, m.runAIChatCmd(history)
+}
+
+// runAIChatCmd streams the answer to the last question of the conversation
+// 会話がまだない場合は現在のシステム状況レポートから会話を始める
+func (m Model) runAIChatCmd(history []llm.Message) tea.Cmd {
+	return func() tea.Msg {
+		if len(history) == 1 {
+			history = append(m.aiService.NewConversation(), history...)
+		}
+
+		if m.aiAgentMode {
+			return aiAgentStartMsg{History: history, Events: m.aiService.RunAgent(context.Background(), history)}
+		}
+
+		stream, err := m.aiService.ChatStream(context.Background(), history)
+		if err != nil {
+			return aiAnalysisMsg{Err: err}
+		}
+		return aiStreamStartMsg{History: history, Stream: stream}
+	}
+}
+
+// refreshAISnapshotCmd replaces the system snapshot of the conversation with the current state
+func refreshAISnapshotCmd(m Model, history []llm.Message) tea.Cmd {
+	return func() tea.Msg {
+		return aiSnapshotMsg{History: m.aiService.RefreshSnapshot(history)}
+	}
+}
+
+// saveAIConversationCmd saves the conversation to the store
+func saveAIConversationCmd(m Model, title string, history []llm.Message) tea.Cmd {
+	return func() tea.Msg {
+		id, err := m.aiService.SaveConversation(title, history)
+		return aiConversationSavedMsg{ID: id, Err: err}
+	}
+}
+
+// handleAISnapshot replaces the conversation with the refreshed one
+func (m Model) handleAISnapshot(msg aiSnapshotMsg) (Model, tea.Cmd) {
+	// 更新中に会話をリセットした場合は反映しない
+	if len(m.aiHistory) == 0 {
+		return m, nil
+	}
+	m.aiHistory = msg.History
+	// 最新の回答も会話の一部として、更新の記録より前に表示する
+	m.aiResponse = ""
+	m.aiToolTrace = nil
+	m.aiCmdResult = fmt.Sprintf("✓ システム状況レポートを更新しました (%s)", time.Now().Format("15:04:05"))
+	return m, nil
+}
+
+// handleAIConversationSaved shows the result of saving the conversation
+func (m Model) handleAIConversationSaved(msg aiConversationSavedMsg) (Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.aiCmdResult = fmt.Sprintf("✗ 会話を保存できませんでした: %v", msg.Err)
+	} else {
+		m.aiCmdResult = fmt.Sprintf("✓ 会話を保存しました (ID: %d)", msg.ID)
+	}
+	return m, nil
+}
+
+// defaultConversationTitle uses the first question (or the analysis time) as the title
+func defaultConversationTitle(history []llm.Message) string {
+	for _, msg := range aiConversationTurns(history) {
+		if msg.Role == "user" {
+			return truncateCommand(strings.Join(strings.Fields(msg.Content), " "), aiConversationTitleLen)
+		}
+	}
+	return "環境分析 " + time.Now().Format("2006-01-02 15:04")
+}
+
+// aiConversationTurns returns the messages after the system prompt and the snapshot
+func aiConversationTurns(history []llm.Message) []llm.Message {
+	if len(history) <= 2 {
+		return nil
+	}
+	return history[2:]
+}
@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/ai"
+	"github.com/Masahide-S/bho_hacka_go/internal/db"
+	"github.com/Masahide-S/bho_hacka_go/internal/logger"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// AIが提案したコマンドの実行
+const (
+	aiCommandTimeout     = 60 * time.Second // 終わらないコマンド（logs -f など）で待ち続けないように
+	aiCommandConfirmWord = "yes"            // 危険なコマンドの実行に入力が必要な文字列
+)
+
+// proposeAICommand extracts the command wrapped in <cmd> from the answer and assesses its risk
+func (m Model) proposeAICommand(response string) Model {
+	matches := cmdRegex.FindStringSubmatch(response)
+	if len(matches) < 2 || strings.TrimSpace(matches[1]) == "" {
+		m.aiPendingCmd = ""
+		return m
+	}
+	m.aiPendingCmd = strings.TrimSpace(matches[1])
+	m.aiCmdAssessment = ai.AssessCommand(m.aiPendingCmd)
+	m.aiCmdConfirmInput = ""
+	return m
+}
+
+// handleAIPendingCmdKey handles keys while an AI-suggested command is waiting for confirmation
+// 危険なコマンドは "yes" と入力してから Enter で実行する
+func (m Model) handleAIPendingCmdKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	assessment := m.aiCmdAssessment
+	dangerous := assessment.Risk == ai.RiskDangerous
+
+	switch msg.String() {
+	case "enter":
+		if dangerous && m.aiCmdConfirmInput != aiCommandConfirmWord {
+			m.aiCmdResult = fmt.Sprintf("✗ 危険なコマンドです。実行するには %s と入力してから Enter を押してください", aiCommandConfirmWord)
+			return m, nil
+		}
+		m.aiPendingCmd = ""
+		m.aiCmdConfirmInput = ""
+		m.aiCmdResult = fmt.Sprintf("実行中: %s...", assessment.Command)
+		return m, executeAICommandCmd(m.dbStore, m.aiService.GetModel(), assessment.Command, assessment.Risk, false)
+
+	case "tab":
+		// ドライランは実行待ちのまま結果だけ表示する
+		if assessment.DryRun == "" {
+			m.aiCmdResult = "✗ このコマンドにはドライランがありません"
+			return m, nil
+		}
+		m.aiCmdResult = fmt.Sprintf("ドライラン中: %s...", assessment.DryRun)
+		return m, executeAICommandCmd(m.dbStore, m.aiService.GetModel(), assessment.DryRun, assessment.Risk, true)
+
+	case "esc":
+		return m.cancelAIPendingCmd(), nil
+
+	case "ctrl+c":
+		m = m.stopLogStream()
+		m.quitting = true
+		return m, tea.Quit
+	}
+
+	// 危険なコマンドは確認の文字列を入力する（誤って n や q を押しても実行・終了しない）
+	if dangerous {
+		switch msg.Type {
+		case tea.KeyBackspace:
+			runes := []rune(m.aiCmdConfirmInput)
+			if len(runes) > 0 {
+				m.aiCmdConfirmInput = string(runes[:len(runes)-1])
+			}
+		case tea.KeyRunes:
+			m.aiCmdConfirmInput += string(msg.Runes)
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "n":
+		return m.cancelAIPendingCmd(), nil
+	case "q":
+		m = m.stopLogStream()
+		m.quitting = true
+		return m, tea.Quit
+	}
+	// コマンド待ちの時は他の操作をブロック
+	return m, nil
+}
+
+// cancelAIPendingCmd discards the suggested command
+func (m Model) cancelAIPendingCmd() Model {
+	m.aiPendingCmd = ""
+	m.aiCmdConfirmInput = ""
+	m.aiCmdResult = "コマンド実行をキャンセルしました。"
+	return m
+}
+
+// executeAICommandCmd runs an AI-suggested command through the shell and records it in the audit log
+func executeAICommandCmd(store *db.Store, model, command string, risk ai.RiskLevel, dryRun bool) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), aiCommandTimeout)
+		defer cancel()
+
+		// sh -c を使うことでパイプやリダイレクトを含むコマンドも実行可能
+		output, err := exec.CommandContext(ctx, "sh", "-c", command).CombinedOutput()
+
+		exitCode := 0
+		if err != nil {
+			exitCode = -1
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				exitCode = exitErr.ExitCode()
+			}
+			if ctx.Err() == context.DeadlineExceeded {
+				err = fmt.Errorf("%s でタイムアウトしました", aiCommandTimeout)
+			}
+		}
+
+		if store != nil {
+			audit := db.CommandAudit{
+				Command:  command,
+				Risk:     risk.String(),
+				DryRun:   dryRun,
+				ExitCode: exitCode,
+				Output:   string(output),
+				Model:    model,
+			}
+			if auditErr := store.SaveCommandAudit(audit); auditErr != nil {
+				logger.LogIssue("DB_WRITE_ERROR", auditErr.Error())
+			}
+		}
+
+		var result string
+		switch {
+		case err != nil:
+			result = fmt.Sprintf("✗ 実行エラー: %v\n%s", err, string(output))
+		case dryRun:
+			result = fmt.Sprintf("✓ ドライラン結果（変更は加えていません）: %s\n%s", command, string(output))
+		default:
+			result = fmt.Sprintf("✓ 実行成功:\n%s", string(output))
+		}
+		return cmdExecMsg{Result: result, DryRun: dryRun}
+	}
+}
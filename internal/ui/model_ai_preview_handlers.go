@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"github.com/Masahide-S/bho_hacka_go/internal/llm"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// aiPreview is a new conversation built for the preview
+// プレビューを閉じずに分析・質問した場合は、確認した内容をそのまま送る
+type aiPreview struct {
+	Conversation []llm.Message       // システムプロンプトとマスク済みのレポート
+	Redactions   llm.RedactionReport // レポートでマスクした値の件数
+}
+
+// aiPreviewMsg is sent when the conversation for the preview has been built
+type aiPreviewMsg aiPreview
+
+// handleAIPreviewToggle shows or hides exactly what will be sent to the LLM
+// 会話中の場合は会話を、会話がない場合は新しく集めたレポートを表示する
+func (m Model) handleAIPreviewToggle() (Model, tea.Cmd) {
+	if m.aiState == aiStateLoading || m.aiPendingCmd != "" {
+		return m, nil
+	}
+	if m.aiShowPreview {
+		return m.closeAIPreview(), nil
+	}
+
+	m.aiShowPreview = true
+	m.aiPreview = nil
+	m.detailScroll = 0
+	if len(m.aiHistory) > 0 {
+		return m, nil
+	}
+	return m, buildAIPreviewCmd(m)
+}
+
+// buildAIPreviewCmd collects the system snapshot to preview
+func buildAIPreviewCmd(m Model) tea.Cmd {
+	return func() tea.Msg {
+		conversation, redactions := m.aiService.NewConversationWithReport()
+		return aiPreviewMsg{Conversation: conversation, Redactions: redactions}
+	}
+}
+
+// handleAIPreview shows the built conversation
+func (m Model) handleAIPreview(msg aiPreviewMsg) (Model, tea.Cmd) {
+	// 準備中に閉じた場合や会話が始まった場合は反映しない
+	if !m.aiShowPreview || len(m.aiHistory) > 0 {
+		return m, nil
+	}
+	preview := aiPreview(msg)
+	m.aiPreview = &preview
+	return m, nil
+}
+
+// takeAIPreview closes the preview and returns the previewed conversation (nil if there is none)
+func (m Model) takeAIPreview() (Model, []llm.Message) {
+	var conversation []llm.Message
+	if m.aiShowPreview && m.aiPreview != nil {
+		conversation = m.aiPreview.Conversation
+	}
+	return m.closeAIPreview(), conversation
+}
+
+// closeAIPreview hides the preview
+func (m Model) closeAIPreview() Model {
+	m.aiShowPreview = false
+	m.aiPreview = nil
+	m.detailScroll = 0
+	return m
+}
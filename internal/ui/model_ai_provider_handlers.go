@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// aiModelChoice is a model of a provider in the model picker
+type aiModelChoice struct {
+	Provider string
+	Model    string
+}
+
+// handleAIModels lists the models of all providers for the model picker
+func (m Model) handleAIModels(msg aiModelsMsg) (Model, tea.Cmd) {
+	m.aiProviderModels = msg.Providers
+
+	var choices []aiModelChoice
+	for _, provider := range msg.Providers {
+		for _, model := range provider.Models {
+			choices = append(choices, aiModelChoice{Provider: provider.Provider, Model: model})
+		}
+	}
+	m.availableModels = choices
+
+	// 選択中のモデルがリストにあるか確認
+	currentProvider, currentModel := m.aiService.GetProvider(), m.aiService.GetModel()
+	first := -1
+	for i, choice := range choices {
+		if choice.Provider != currentProvider {
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		if choice.Model == currentModel {
+			m.selectedModel = i
+			return m, nil
+		}
+	}
+	// ない場合は同じプロバイダーの先頭のモデルを使う（OpenAI互換のサーバーはモデル名がさまざまなため）
+	if first >= 0 {
+		m.selectedModel = first
+		m.aiService.SetModel(choices[first].Model)
+	}
+	return m, nil
+}
+
+// handleAIModelNext switches to the next model, across providers
+func (m Model) handleAIModelNext() (Model, tea.Cmd) {
+	if len(m.availableModels) == 0 {
+		return m, nil
+	}
+	m.selectedModel = (m.selectedModel + 1) % len(m.availableModels)
+	choice := m.availableModels[m.selectedModel]
+
+	if choice.Provider == m.aiService.GetProvider() {
+		m.aiService.SetModel(choice.Model)
+		return m, nil
+	}
+	if err := m.aiService.SelectModel(choice.Provider, choice.Model); err != nil {
+		m.aiCmdResult = "✗ " + err.Error()
+		return m, nil
+	}
+	// プロバイダーが変わったら接続状態を確認し直す
+	return m, m.checkHealthCmd()
+}
+
+// aiUnavailableMessage returns the message shown when the selected provider cannot be reached
+func (m Model) aiUnavailableMessage() string {
+	name := m.aiService.GetProvider()
+	return fmt.Sprintf("%sのサーバーに接続できません。\n%sが起動しているか確認してください。", name, name)
+}
@@ -0,0 +1,148 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// composeConfigRefreshInterval はcompose configを再取得する間隔
+const composeConfigRefreshInterval = 30 * time.Second
+
+// getSelectedComposeService returns the project and service of the selected compose item
+func (m Model) getSelectedComposeService() (project, service string) {
+	if m.rightPanelCursor >= len(m.rightPanelItems) {
+		return "", ""
+	}
+
+	selectedItem := m.rightPanelItems[m.rightPanelCursor]
+
+	switch selectedItem.Type {
+	case "service":
+		// コンテナが未作成のサービス
+		return selectedItem.ProjectName, selectedItem.Name
+	case "container":
+		if selectedItem.ProjectName == "" {
+			return "", ""
+		}
+		for _, c := range m.cachedContainers {
+			if c.ID == selectedItem.ContainerID {
+				return c.ComposeProject, c.ComposeService
+			}
+		}
+	}
+
+	return "", ""
+}
+
+// getSelectedComposeProjectName returns the compose project of the selected item
+func (m Model) getSelectedComposeProjectName() string {
+	if m.rightPanelCursor >= len(m.rightPanelItems) {
+		return ""
+	}
+
+	selectedItem := m.rightPanelItems[m.rightPanelCursor]
+	if selectedItem.Type == "project" {
+		return selectedItem.Name
+	}
+	return selectedItem.ProjectName
+}
+
+// handleComposeServiceAction shows a confirm dialog for a service level compose action
+func (m Model) handleComposeServiceAction(action string) (Model, tea.Cmd) {
+	project, service := m.getSelectedComposeService()
+	if project == "" || service == "" {
+		return m, nil
+	}
+
+	m.showConfirmDialog = true
+	m.confirmAction = action
+	m.confirmTarget = project + ":" + service
+	m.confirmType = "service"
+
+	return m, nil
+}
+
+// handleComposeServiceScale shows a confirm dialog to scale a service by delta replicas
+func (m Model) handleComposeServiceScale(delta int) (Model, tea.Cmd) {
+	project, service := m.getSelectedComposeService()
+	if project == "" || service == "" {
+		return m, nil
+	}
+
+	// 現在のレプリカ数（停止中のコンテナも含む）から増減
+	_, total, _ := monitor.ComposeServiceState(project, service, m.cachedContainers)
+	replicas := total + delta
+	if replicas < 0 {
+		return m, nil
+	}
+
+	m.showConfirmDialog = true
+	m.confirmAction = "scale_service"
+	m.confirmTarget = fmt.Sprintf("%s:%s:%d", project, service, replicas)
+	m.confirmType = "service"
+
+	return m, nil
+}
+
+// handleViewComposeServiceLogs handles viewing logs of all replicas of a compose service
+func (m Model) handleViewComposeServiceLogs() (Model, tea.Cmd) {
+	project, service := m.getSelectedComposeService()
+	if project == "" || service == "" {
+		return m, nil
+	}
+
+	return m, followComposeServiceLogsCmd(project, service)
+}
+
+// composeConfigMsg is sent when a compose project configuration is resolved
+type composeConfigMsg struct {
+	Project string
+	Config  *monitor.ComposeConfig
+	Err     error
+}
+
+// fetchComposeConfigCmd resolves the compose configuration of the selected project if stale
+func (m Model) fetchComposeConfigCmd() tea.Cmd {
+	project := m.getSelectedComposeProjectName()
+	if project == "" {
+		return nil
+	}
+
+	if cache, exists := m.composeConfigCache[project]; exists && time.Since(cache.UpdatedAt) < composeConfigRefreshInterval {
+		return nil
+	}
+
+	return func() tea.Msg {
+		config, err := monitor.GetComposeConfig(project)
+		return composeConfigMsg{Project: project, Config: config, Err: err}
+	}
+}
+
+// composeServiceItems returns right panel items for services that have no container yet
+func (m Model) composeServiceItems(project string, containers []monitor.DockerContainer) []RightPanelItem {
+	cache, exists := m.composeConfigCache[project]
+	if !exists || cache.Config == nil {
+		return nil
+	}
+
+	created := make(map[string]bool)
+	for _, c := range containers {
+		created[c.ComposeService] = true
+	}
+
+	var items []RightPanelItem
+	for _, svc := range cache.Config.Services {
+		if created[svc.Name] {
+			continue
+		}
+		items = append(items, RightPanelItem{
+			Type:        "service",
+			Name:        svc.Name,
+			ProjectName: project,
+		})
+	}
+	return items
+}
@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// containerConfigMsg is sent when the configuration of a container has been inspected
+type containerConfigMsg struct {
+	ContainerID string
+	Config      *monitor.ContainerConfig
+	Err         error
+}
+
+// dockerRunExportMsg is sent when the "docker run" command has been exported
+type dockerRunExportMsg struct {
+	ContainerName  string
+	IncludeSecrets bool
+	Path          string // クリップボードが使えずファイルに書き出した場合のパス
+	Err           error
+}
+
+// selectedContainerID returns the container ID of the selected right panel item (without docker calls)
+func (m Model) selectedContainerID() string {
+	if m.rightPanelCursor >= len(m.rightPanelItems) {
+		return ""
+	}
+	item := m.rightPanelItems[m.rightPanelCursor]
+	if item.Type != "container" {
+		return ""
+	}
+	return item.ContainerID
+}
+
+// handleToggleContainerConfig switches the container details between overview and config tabs
+func (m Model) handleToggleContainerConfig() (Model, tea.Cmd) {
+	containerID := m.selectedContainerID()
+	if containerID == "" {
+		return m, nil
+	}
+
+	m.showContainerConfig = !m.showContainerConfig
+	m.detailScroll = 0
+	if !m.showContainerConfig {
+		return m, nil
+	}
+	return m, m.fetchContainerConfigCmd()
+}
+
+// fetchContainerConfigCmd inspects the selected container if the cached config is for another container
+func (m *Model) fetchContainerConfigCmd() tea.Cmd {
+	containerID := m.selectedContainerID()
+	if containerID == "" || containerID == m.containerConfigFetching {
+		return nil
+	}
+	if m.cachedContainerConfig != nil && m.containerConfigID == containerID {
+		return nil
+	}
+
+	m.containerConfigFetching = containerID
+	return func() tea.Msg {
+		config, err := monitor.GetContainerConfig(containerID)
+		return containerConfigMsg{ContainerID: containerID, Config: config, Err: err}
+	}
+}
+
+// handleContainerConfig stores the inspected container configuration
+func (m Model) handleContainerConfig(msg containerConfigMsg) (Model, tea.Cmd) {
+	if m.containerConfigFetching == msg.ContainerID {
+		m.containerConfigFetching = ""
+	}
+	m.containerConfigID = msg.ContainerID
+	m.cachedContainerConfig = msg.Config
+	m.containerConfigErr = ""
+	if msg.Err != nil {
+		m.containerConfigErr = msg.Err.Error()
+	}
+	return m, nil
+}
+
+// handleExportDockerRun copies an equivalent "docker run" command for the selected container
+// includeSecrets が false の場合、シークレットの環境変数の値は含めない
+func (m Model) handleExportDockerRun(includeSecrets bool) (Model, tea.Cmd) {
+	containerID := m.selectedContainerID()
+	if containerID == "" {
+		return m, nil
+	}
+
+	m.lastCommandResult = "docker run コマンドを生成中..."
+	return m, func() tea.Msg {
+		config, err := monitor.GetContainerConfig(containerID)
+		if err != nil {
+			return dockerRunExportMsg{Err: err}
+		}
+		path, err := monitor.CopyToClipboard(config.DockerRunCommand(includeSecrets), config.Name)
+		return dockerRunExportMsg{ContainerName: config.Name, IncludeSecrets: includeSecrets, Path: path, Err: err}
+	}
+}
+
+// handleDockerRunExport reports where the "docker run" command was exported
+func (m Model) handleDockerRunExport(msg dockerRunExportMsg) (Model, tea.Cmd) {
+	note := "シークレットの環境変数は値を含みません（E: 値を含めて出力）"
+	if msg.IncludeSecrets {
+		note = "シークレットの値を含みます"
+	}
+	switch {
+	case msg.Err != nil:
+		m.lastCommandResult = "docker run コマンドを出力できませんでした: " + msg.Err.Error()
+	case msg.Path != "":
+		m.lastCommandResult = fmt.Sprintf("クリップボードが使えないためファイルに保存しました（%s）: %s", note, msg.Path)
+	default:
+		m.lastCommandResult = fmt.Sprintf("docker run コマンドをコピーしました（%s）: %s", note, msg.ContainerName)
+	}
+	return m, nil
+}
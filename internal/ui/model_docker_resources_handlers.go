@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// dockerResourcesRefreshInterval は docker system df -v などの重い取得を繰り返さない間隔
+const dockerResourcesRefreshInterval = 10 * time.Second
+
+// dockerResourcesMsg is sent when volumes, networks or images are fetched
+type dockerResourcesMsg struct {
+	Menu      string
+	DiskUsage []monitor.DockerDiskUsage
+	Volumes   []monitor.DockerVolume
+	Networks  []monitor.DockerNetwork
+	Images    []monitor.DockerImage
+}
+
+// fetchDockerResourcesCmd fetches the resources of the selected Docker resource panel if stale
+func (m Model) fetchDockerResourcesCmd(menu string) tea.Cmd {
+	if updatedAt, exists := m.dockerFetchedAt[menu]; exists && time.Since(updatedAt) < dockerResourcesRefreshInterval {
+		return nil
+	}
+
+	return func() tea.Msg {
+		msg := dockerResourcesMsg{
+			Menu:      menu,
+			DiskUsage: monitor.GetDockerDiskUsage(),
+		}
+		switch menu {
+		case "Docker Volumes":
+			msg.Volumes = monitor.GetDockerVolumes()
+		case "Docker Networks":
+			msg.Networks = monitor.GetDockerNetworks()
+		case "Docker Images":
+			msg.Images = monitor.GetDockerImages()
+		}
+		return msg
+	}
+}
+
+// handleDockerResourceRemove handles removing the selected volume, network or image
+func (m Model) handleDockerResourceRemove() (Model, tea.Cmd) {
+	if m.rightPanelCursor >= len(m.rightPanelItems) {
+		return m, nil
+	}
+
+	selectedItem := m.rightPanelItems[m.rightPanelCursor]
+
+	switch selectedItem.Type {
+	case "docker_volume":
+		m.confirmAction = "remove_volume"
+	case "docker_network":
+		network := m.getSelectedDockerNetwork()
+		if network == nil {
+			return m, nil
+		}
+		if network.Builtin() {
+			m.lastCommandResult = "デフォルトネットワークは削除できません"
+			return m, nil
+		}
+		m.confirmAction = "remove_network"
+	case "docker_image":
+		m.confirmAction = "remove_image"
+	default:
+		return m, nil
+	}
+
+	// 確認ダイアログを表示
+	m.showConfirmDialog = true
+	m.confirmTarget = selectedItem.Name
+	m.confirmType = selectedItem.Type
+
+	return m, nil
+}
+
+// handleDockerResourcePrune handles pruning unused resources of the current panel
+func (m Model) handleDockerResourcePrune() (Model, tea.Cmd) {
+	switch m.menuItems[m.selectedItem].Name {
+	case "Docker Volumes":
+		m.confirmAction = "prune_volumes"
+	case "Docker Networks":
+		m.confirmAction = "prune_networks"
+	case "Docker Images":
+		m.confirmAction = "prune_unused_images"
+	default:
+		return m, nil
+	}
+
+	// 確認ダイアログを表示
+	m.showConfirmDialog = true
+	m.confirmTarget = ""
+	m.confirmType = "docker_system"
+
+	return m, nil
+}
+
+// handleBuildCachePrune handles build cache removal
+func (m Model) handleBuildCachePrune() (Model, tea.Cmd) {
+	m.showConfirmDialog = true
+	m.confirmAction = "prune_build_cache"
+	m.confirmTarget = ""
+	m.confirmType = "docker_system"
+
+	return m, nil
+}
+
+// getSelectedDockerVolume returns the currently selected volume
+func (m Model) getSelectedDockerVolume() *monitor.DockerVolume {
+	if m.rightPanelCursor >= len(m.rightPanelItems) {
+		return nil
+	}
+
+	selectedItem := m.rightPanelItems[m.rightPanelCursor]
+	if selectedItem.Type != "docker_volume" {
+		return nil
+	}
+
+	for i := range m.cachedDockerVolumes {
+		if m.cachedDockerVolumes[i].Name == selectedItem.Name {
+			return &m.cachedDockerVolumes[i]
+		}
+	}
+	return nil
+}
+
+// getSelectedDockerNetwork returns the currently selected network
+func (m Model) getSelectedDockerNetwork() *monitor.DockerNetwork {
+	if m.rightPanelCursor >= len(m.rightPanelItems) {
+		return nil
+	}
+
+	selectedItem := m.rightPanelItems[m.rightPanelCursor]
+	if selectedItem.Type != "docker_network" {
+		return nil
+	}
+
+	for i := range m.cachedDockerNetworks {
+		if m.cachedDockerNetworks[i].Name == selectedItem.Name {
+			return &m.cachedDockerNetworks[i]
+		}
+	}
+	return nil
+}
+
+// getSelectedDockerImage returns the currently selected image
+func (m Model) getSelectedDockerImage() *monitor.DockerImage {
+	if m.rightPanelCursor >= len(m.rightPanelItems) {
+		return nil
+	}
+
+	selectedItem := m.rightPanelItems[m.rightPanelCursor]
+	if selectedItem.Type != "docker_image" {
+		return nil
+	}
+
+	for i := range m.cachedDockerImages {
+		if m.cachedDockerImages[i].ID == selectedItem.Name {
+			return &m.cachedDockerImages[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// handleElasticsearchIndexDelete handles index deletion
+func (m Model) handleElasticsearchIndexDelete() (Model, tea.Cmd) {
+	if m.rightPanelCursor >= len(m.rightPanelItems) {
+		return m, nil
+	}
+
+	selectedItem := m.rightPanelItems[m.rightPanelCursor]
+
+	// インデックス以外は何もしない
+	if selectedItem.Type != "es_index" {
+		return m, nil
+	}
+
+	// システムインデックスは削除不可
+	if monitor.IsElasticsearchSystemIndex(selectedItem.Name) {
+		return m, nil
+	}
+
+	// 確認ダイアログを表示
+	m.showConfirmDialog = true
+	m.confirmAction = "delete_index"
+	m.confirmTarget = selectedItem.Name
+	m.confirmType = "es_index"
+
+	return m, nil
+}
+
+// handleElasticsearchClearCache handles index cache clear
+func (m Model) handleElasticsearchClearCache() (Model, tea.Cmd) {
+	if m.rightPanelCursor >= len(m.rightPanelItems) {
+		return m, nil
+	}
+
+	selectedItem := m.rightPanelItems[m.rightPanelCursor]
+
+	// インデックス以外は何もしない
+	if selectedItem.Type != "es_index" {
+		return m, nil
+	}
+
+	// 確認ダイアログを表示
+	m.showConfirmDialog = true
+	m.confirmAction = "clear_cache"
+	m.confirmTarget = selectedItem.Name
+	m.confirmType = "es_index"
+
+	return m, nil
+}
+
+// elasticsearchStatusMsg is sent when cluster health, nodes and pending tasks are fetched
+type elasticsearchStatusMsg struct {
+	Cluster      monitor.ElasticsearchCluster
+	Nodes        []monitor.ElasticsearchNode
+	PendingTasks []monitor.ElasticsearchPendingTask
+}
+
+// fetchElasticsearchStatusCmd fetches cluster health, nodes and pending tasks asynchronously
+func fetchElasticsearchStatusCmd() tea.Cmd {
+	return func() tea.Msg {
+		cluster := monitor.GetElasticsearchCluster()
+		if !cluster.IsReachable {
+			return elasticsearchStatusMsg{Cluster: cluster}
+		}
+
+		return elasticsearchStatusMsg{
+			Cluster:      cluster,
+			Nodes:        monitor.GetElasticsearchNodes(),
+			PendingTasks: monitor.GetElasticsearchPendingTasks(),
+		}
+	}
+}
@@ -3,7 +3,6 @@ package ui
 import (
 	"os/exec"
 
-	"github.com/Masahide-S/bho_hacka_go/internal/monitor/logs"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -60,6 +59,9 @@ func (m Model) handleContainerToggle() (Model, tea.Cmd) {
 		m.confirmAction = action
 		m.confirmTarget = selectedItem.Name
 		m.confirmType = "project"
+	} else if selectedItem.Type == "service" {
+		// コンテナ未作成のサービスは起動のみ
+		return m.handleComposeServiceAction("up_service")
 	} else {
 		// 個別コンテナの操作
 		container := m.getSelectedContainer()
@@ -183,6 +185,11 @@ func (m Model) handleOpenInVSCode() (Model, tea.Cmd) {
 		container := m.getSelectedContainer()
 		if container != nil {
 			directory = container.ProjectDir
+		} else if item := m.rightPanelItems[m.rightPanelCursor]; item.Type == "project" {
+			// Composeプロジェクトの場合は作業ディレクトリを開く
+			if project := m.getComposeProject(item.Name); project != nil {
+				directory = project.WorkingDir
+			}
 		}
 
 	case "Node.js":
@@ -198,6 +205,14 @@ func (m Model) handleOpenInVSCode() (Model, tea.Cmd) {
 		if process != nil {
 			directory = process.ProjectDir
 		}
+
+	default:
+		// ランタイムプロセスの場合
+		if isRuntimeMenu(selectedMenuItem.Name) {
+			if process := m.getSelectedRuntimeProcess(); process != nil {
+				directory = process.ProjectDir
+			}
+		}
 	}
 
 	// ディレクトリが取得できた場合、VSCodeで開く
@@ -224,26 +239,7 @@ func (m Model) handleViewContainerLogs() (Model, tea.Cmd) {
 		return m, nil
 	}
 
-	return m, fetchContainerLogsCmd(container.ID, container.Name)
-}
-
-// containerLogsMsg is sent when container logs are fetched
-type containerLogsMsg struct {
-	content    string
-	targetName string
-	err        error
-}
-
-// fetchContainerLogsCmd fetches container logs asynchronously
-func fetchContainerLogsCmd(containerID, containerName string) tea.Cmd {
-	return func() tea.Msg {
-		logContent, err := logs.GetContainerLogs(containerID, 100)
-		return containerLogsMsg{
-			content:    logContent,
-			targetName: containerName,
-			err:        err,
-		}
-	}
+	return m, followContainerLogsCmd(container.ID, container.Name)
 }
 
 // handleViewNodeProcessLogs handles viewing Node.js process logs
@@ -253,7 +249,7 @@ func (m Model) handleViewNodeProcessLogs() (Model, tea.Cmd) {
 		return m, nil
 	}
 
-	return m, fetchProcessLogsCmd(process.ProjectDir, process.ProjectName)
+	return m, followProcessLogsCmd(process.ProjectDir, process.ProjectName)
 }
 
 // handleViewPythonProcessLogs handles viewing Python process logs
@@ -263,26 +259,7 @@ func (m Model) handleViewPythonProcessLogs() (Model, tea.Cmd) {
 		return m, nil
 	}
 
-	return m, fetchProcessLogsCmd(process.ProjectDir, process.ProcessType)
-}
-
-// processLogsMsg is sent when process logs are fetched
-type processLogsMsg struct {
-	content    string
-	targetName string
-	err        error
-}
-
-// fetchProcessLogsCmd fetches process logs asynchronously
-func fetchProcessLogsCmd(projectDir, processName string) tea.Cmd {
-	return func() tea.Msg {
-		logContent, err := logs.GetProcessLogs(projectDir, 100)
-		return processLogsMsg{
-			content:    logContent,
-			targetName: processName,
-			err:        err,
-		}
-	}
+	return m, followProcessLogsCmd(process.ProjectDir, process.ProcessType)
 }
 
 // handleCleanDanglingImages handles cleaning dangling images
@@ -0,0 +1,22 @@
+package ui
+
+import (
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// kafkaStatusMsg is sent when Kafka topics and consumer group lag are fetched
+type kafkaStatusMsg monitor.KafkaStatus
+
+// fetchKafkaStatusCmd fetches Kafka topics and consumer group lag asynchronously
+// Kafka CLIはJVMの起動に時間がかかるため、取得中は重複して実行しない
+func (m *Model) fetchKafkaStatusCmd() tea.Cmd {
+	if m.kafkaFetching {
+		return nil
+	}
+	m.kafkaFetching = true
+
+	return func() tea.Msg {
+		return kafkaStatusMsg(monitor.GetKafkaStatus())
+	}
+}
@@ -0,0 +1,326 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor/logs"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// logInitialLines はログビューを開いたときに読み込む過去の行数
+const logInitialLines = 100
+
+// logBatchWindow は新着行をまとめて画面に反映する間隔（大量出力時の再描画を抑える）
+const logBatchWindow = 50 * time.Millisecond
+
+// logBatchMaxLines は1回の反映でまとめる最大行数
+const logBatchMaxLines = 1000
+
+// logStreamStartMsg is sent when a log follow stream has been started
+type logStreamStartMsg struct {
+	stream     *logs.LogStream
+	targetName string
+	sources    []string // 統合ログのソース名（単一のログでは空）
+	err        error
+}
+
+// logLinesMsg carries newly followed log lines
+type logLinesMsg struct {
+	stream *logs.LogStream
+	lines  []logs.Line
+	done   bool // ストリームが終了した
+}
+
+// followContainerLogsCmd starts following container logs asynchronously
+func followContainerLogsCmd(containerID, containerName string) tea.Cmd {
+	return func() tea.Msg {
+		stream, err := logs.FollowContainerLogs(containerID, logInitialLines)
+		return logStreamStartMsg{stream: stream, targetName: containerName, err: err}
+	}
+}
+
+// followComposeServiceLogsCmd starts following compose service logs asynchronously
+func followComposeServiceLogsCmd(project, service string) tea.Cmd {
+	return func() tea.Msg {
+		stream, err := logs.FollowComposeServiceLogs(project, service, logInitialLines)
+		return logStreamStartMsg{stream: stream, targetName: fmt.Sprintf("%s / %s", project, service), err: err}
+	}
+}
+
+// followProcessLogsCmd starts tailing the log file of a process asynchronously
+func followProcessLogsCmd(projectDir, processName string) tea.Cmd {
+	return func() tea.Msg {
+		stream, err := logs.FollowProcessLogs(projectDir, logInitialLines)
+		if err != nil {
+			return logStreamStartMsg{targetName: processName, err: err}
+		}
+		return logStreamStartMsg{
+			stream:     stream,
+			targetName: fmt.Sprintf("%s (%s)", processName, filepath.Base(stream.Source)),
+		}
+	}
+}
+
+// waitForLogLines waits for the next lines of a log stream and batches them
+func waitForLogLines(stream *logs.LogStream) tea.Cmd {
+	return func() tea.Msg {
+		line, ok := <-stream.Lines
+		if !ok {
+			return logLinesMsg{stream: stream, done: true}
+		}
+
+		lines := []logs.Line{line}
+		timer := time.NewTimer(logBatchWindow)
+		defer timer.Stop()
+		for len(lines) < logBatchMaxLines {
+			select {
+			case line, ok := <-stream.Lines:
+				if !ok {
+					return logLinesMsg{stream: stream, lines: lines, done: true}
+				}
+				lines = append(lines, line)
+			case <-timer.C:
+				return logLinesMsg{stream: stream, lines: lines}
+			}
+		}
+		return logLinesMsg{stream: stream, lines: lines}
+	}
+}
+
+// handleLogStreamStart opens the log view and starts receiving lines
+func (m Model) handleLogStreamStart(msg logStreamStartMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		if msg.stream != nil {
+			msg.stream.Stop()
+		}
+		m.lastCommandResult = fmt.Sprintf("ログ取得失敗: %v", msg.err)
+		return m, nil
+	}
+
+	// 別のログを開いていた場合は前のストリームを止める
+	m = m.stopLogStream()
+
+	m.showLogView = true
+	m.logStream = msg.stream
+	m.logBuffer = logs.NewRingBuffer(logs.FollowBufferLines)
+	m.logScroll = 0
+	m.logAutoScroll = true
+	m.logPaused = false
+	m.logPausedLines = nil
+	m.logPendingLines = 0
+	m.logStreamErr = ""
+	m.logTargetName = msg.targetName
+	m.logSources = msg.sources
+	m.logHiddenSources = nil
+	m = m.clearLogSearch()
+	m.logPrettyJSON = false
+
+	return m, waitForLogLines(msg.stream)
+}
+
+// handleLogLines appends followed lines to the ring buffer
+func (m Model) handleLogLines(msg logLinesMsg) (Model, tea.Cmd) {
+	// 閉じたログビューや切り替え前のストリームからのメッセージは無視する
+	if msg.stream != m.logStream || m.logBuffer == nil {
+		return m, nil
+	}
+
+	dropped := m.logBuffer.Dropped()
+	m.logBuffer.Append(msg.lines...)
+
+	if m.logPaused {
+		m.logPendingLines += len(msg.lines)
+	} else if shift := m.logBuffer.Dropped() - dropped; shift > 0 {
+		// 古い行が捨てられた分だけスクロール位置と一致行をずらし、読んでいる箇所を保つ
+		if !m.logAutoScroll {
+			m.logScroll -= shift
+			if m.logScroll < 0 {
+				m.logScroll = 0
+			}
+		}
+		if m.logMatchLine >= 0 {
+			m.logMatchLine -= shift
+		}
+	}
+
+	if msg.done {
+		m.logStreamErr = "ログのフォローが終了しました"
+		if err := msg.stream.Err(); err != nil {
+			m.logStreamErr = err.Error()
+		}
+		return m, nil
+	}
+	return m, waitForLogLines(msg.stream)
+}
+
+// handleLogViewKey handles keys specific to the log view (handled=false の場合は通常のキー処理へ)
+func (m Model) handleLogViewKey(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	if m.logSearchEditing {
+		next, cmd := m.handleLogSearchInput(msg)
+		return next, cmd, true
+	}
+
+	switch msg.String() {
+	case "/":
+		m.logSearchEditing = true
+		m.logSearchInput = ""
+		return m, nil, true
+
+	case "n", "N":
+		// 検索していない場合は通常の n（ログビューを閉じる）として扱う
+		if m.logSearchRegex == nil {
+			return m, nil, false
+		}
+		forward := msg.String() == "n"
+		from := m.logMatchLine
+		if from < 0 {
+			from = m.logCurrentScroll() - 1
+			if !forward {
+				from = m.logCurrentScroll() + m.logContentHeight()
+			}
+		}
+		return m.jumpToLogMatch(forward, from), nil, true
+
+	case "f":
+		// 一致しない行を隠す
+		if m.logSearchRegex == nil {
+			m.logSearchErr = "先に / で検索してください"
+			return m, nil, true
+		}
+		m.logFilter = !m.logFilter
+		m.logMatchLine = -1
+		m.logAutoScroll = !m.logPaused
+		return m, nil, true
+
+	case "e", "E":
+		return m.jumpToLogLevel(logs.LevelError, msg.String() == "e"), nil, true
+
+	case "J":
+		// 構造化ログ（JSON）を整形して表示
+		m.logPrettyJSON = !m.logPrettyJSON
+		m.logMatchLine = -1
+		m.logAutoScroll = !m.logPaused
+		return m, nil, true
+
+	case "esc":
+		// 検索中は検索を解除し、もう一度押すとログビューを閉じる
+		if m.logSearchRegex != nil || m.logSearchErr != "" {
+			return m.clearLogSearch(), nil, true
+		}
+		return m, nil, false
+
+	case " ", "p":
+		// 一時停止中は表示を固定し、新着行はバッファにだけ溜める
+		if m.logPaused {
+			m.logPaused = false
+			m.logPausedLines = nil
+			m.logPendingLines = 0
+			m.logAutoScroll = true
+		} else if m.logBuffer != nil {
+			m.logScroll = m.logCurrentScroll()
+			m.logPaused = true
+			m.logPausedLines = m.logBuffer.Lines()
+			m.logAutoScroll = false
+		}
+		return m, nil, true
+
+	case "ctrl+d":
+		m.logScroll = m.logCurrentScroll() + 5
+		// 最下部まで来たら自動スクロールを再開
+		if m.logScroll >= m.logMaxScroll() {
+			m.logScroll = m.logMaxScroll()
+			m.logAutoScroll = !m.logPaused
+		}
+		return m, nil, true
+
+	case "ctrl+u":
+		m.logScroll = m.logCurrentScroll() - 5
+		if m.logScroll < 0 {
+			m.logScroll = 0
+		}
+		m.logAutoScroll = false
+		return m, nil, true
+
+	case "G", "end":
+		m.logScroll = m.logMaxScroll()
+		m.logAutoScroll = !m.logPaused
+		return m, nil, true
+
+	case "home":
+		m.logScroll = 0
+		m.logAutoScroll = false
+		return m, nil, true
+
+	case "0", "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		// 統合ログのソースの表示切替
+		if len(m.logSources) == 0 {
+			return m, nil, false
+		}
+		return m.toggleLogSource(int(msg.String()[0] - '0')), nil, true
+	}
+	return m, nil, false
+}
+
+// closeLogView closes the log view and stops following
+func (m Model) closeLogView() Model {
+	m = m.stopLogStream()
+	m.showLogView = false
+	m.logBuffer = nil
+	m.logScroll = 0
+	m.logAutoScroll = false
+	m.logPaused = false
+	m.logPausedLines = nil
+	m.logPendingLines = 0
+	m.logStreamErr = ""
+	m.logTargetName = ""
+	m.logSources = nil
+	m.logHiddenSources = nil
+	m = m.clearLogSearch()
+	m.logAutoScroll = false
+	m.logPrettyJSON = false
+	return m
+}
+
+// stopLogStream stops the running log follow (docker logs -f のプロセスも終了する)
+func (m Model) stopLogStream() Model {
+	if m.logStream != nil {
+		m.logStream.Stop()
+		m.logStream = nil
+	}
+	return m
+}
+
+// logContentHeight returns the number of log lines visible in the log view
+func (m Model) logContentHeight() int {
+	// ログビューのサイズ設定（画面の80%、最小20行）
+	logHeight := int(float64(m.height) * 0.8)
+	if logHeight < 20 {
+		logHeight = 20
+	}
+	// タイトル、ステータス、検索、ヘルプ（2行）、パディングを除く
+	height := logHeight - 8
+	if len(m.logSources) > 0 {
+		// 統合ログのソース一覧
+		height--
+	}
+	return height
+}
+
+// logMaxScroll returns the scroll position that shows the last line
+func (m Model) logMaxScroll() int {
+	maxScroll := len(m.logViewLines()) - m.logContentHeight()
+	if maxScroll < 0 {
+		return 0
+	}
+	return maxScroll
+}
+
+// logCurrentScroll returns the effective scroll position (自動スクロール中は最下部)
+func (m Model) logCurrentScroll() int {
+	if m.logAutoScroll || m.logScroll > m.logMaxScroll() {
+		return m.logMaxScroll()
+	}
+	return m.logScroll
+}
@@ -0,0 +1,204 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor/logs"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// logSourceColors は統合ログでソースごとに付ける接頭辞の色
+var logSourceColors = []lipgloss.Color{
+	lipgloss.Color("#7aa2f7"),
+	lipgloss.Color("#9ece6a"),
+	lipgloss.Color("#e0af68"),
+	lipgloss.Color("#bb9af7"),
+	lipgloss.Color("#7dcfff"),
+	lipgloss.Color("#ff9e64"),
+	lipgloss.Color("#73daca"),
+	lipgloss.Color("#f7768e"),
+	lipgloss.Color("#c0caf5"),
+}
+
+// followMergedLogsCmd starts a merged log view of several sources asynchronously
+func followMergedLogsCmd(sources []logs.MergedSource, title string) tea.Cmd {
+	names := make([]string, len(sources))
+	for i, source := range sources {
+		names[i] = source.Name
+	}
+	return func() tea.Msg {
+		stream, err := logs.FollowMerged(sources, logInitialLines)
+		return logStreamStartMsg{stream: stream, targetName: title, sources: names, err: err}
+	}
+}
+
+// handleViewComposeProjectLogs opens the merged logs of all containers in the selected compose project
+func (m Model) handleViewComposeProjectLogs() (Model, tea.Cmd) {
+	if m.rightPanelCursor >= len(m.rightPanelItems) {
+		return m, nil
+	}
+	project := m.rightPanelItems[m.rightPanelCursor].Name
+
+	// レプリカがある場合はサービス名が重なるためコンテナ名で表示する
+	serviceCount := make(map[string]int)
+	for _, c := range m.cachedContainers {
+		if c.ComposeProject == project {
+			serviceCount[c.ComposeService]++
+		}
+	}
+
+	var sources []logs.MergedSource
+	for _, c := range m.cachedContainers {
+		if c.ComposeProject != project {
+			continue
+		}
+		name := c.ComposeService
+		if name == "" || serviceCount[name] > 1 {
+			name = c.Name
+		}
+		sources = append(sources, logs.MergedSource{Name: name, ContainerID: c.ID})
+	}
+	if len(sources) == 0 {
+		m.lastCommandResult = "ログを表示できるコンテナがありません（プロジェクトを起動してください）"
+		return m, nil
+	}
+
+	return m, followMergedLogsCmd(sources, project+" (Compose)")
+}
+
+// selectedLogSource returns the selected container or process as a merged log source
+func (m Model) selectedLogSource() (logs.MergedSource, bool) {
+	if m.rightPanelCursor >= len(m.rightPanelItems) {
+		return logs.MergedSource{}, false
+	}
+
+	switch menu := m.menuItems[m.selectedItem].Name; {
+	case menu == "Docker":
+		if container := m.getSelectedContainer(); container != nil {
+			return logs.MergedSource{Name: container.Name, ContainerID: container.ID}, true
+		}
+	case menu == "Node.js":
+		if process := m.getSelectedNodeProcess(); process != nil && process.ProjectDir != "" {
+			return logs.MergedSource{Name: process.ProjectName, ProjectDir: process.ProjectDir}, true
+		}
+	case menu == "Python":
+		if process := m.getSelectedPythonProcess(); process != nil && process.ProjectDir != "" {
+			return logs.MergedSource{Name: filepath.Base(process.ProjectDir), ProjectDir: process.ProjectDir}, true
+		}
+	case isRuntimeMenu(menu):
+		if process := m.getSelectedRuntimeProcess(); process != nil && process.ProjectDir != "" {
+			return logs.MergedSource{Name: process.ProjectName, ProjectDir: process.ProjectDir}, true
+		}
+	}
+	return logs.MergedSource{}, false
+}
+
+// handleToggleLogMark adds or removes the selected container/process from the merged log set
+func (m Model) handleToggleLogMark() (Model, tea.Cmd) {
+	source, ok := m.selectedLogSource()
+	if !ok {
+		return m, nil
+	}
+
+	for i, marked := range m.logMarks {
+		if marked == source {
+			m.logMarks = append(m.logMarks[:i:i], m.logMarks[i+1:]...)
+			m.lastCommandResult = fmt.Sprintf("統合ログから外しました: %s（%d件選択中）", source.Name, len(m.logMarks))
+			return m, nil
+		}
+	}
+
+	m.logMarks = append(m.logMarks, source)
+	m.lastCommandResult = fmt.Sprintf("統合ログに追加しました: %s（%d件選択中、M: 表示）", source.Name, len(m.logMarks))
+	return m, nil
+}
+
+// handleViewMarkedLogs opens the merged logs of the marked containers and processes
+func (m Model) handleViewMarkedLogs() (Model, tea.Cmd) {
+	if len(m.logMarks) == 0 {
+		m.lastCommandResult = "統合ログの対象がありません（m: コンテナ・プロセスを追加）"
+		return m, nil
+	}
+
+	sources := make([]logs.MergedSource, len(m.logMarks))
+	copy(sources, m.logMarks)
+
+	// 同じ名前のソースは区別できるよう番号を付ける
+	seen := make(map[string]int)
+	for i := range sources {
+		seen[sources[i].Name]++
+		if n := seen[sources[i].Name]; n > 1 {
+			sources[i].Name = fmt.Sprintf("%s#%d", sources[i].Name, n)
+		}
+	}
+
+	names := make([]string, len(sources))
+	for i, source := range sources {
+		names[i] = source.Name
+	}
+	return m, followMergedLogsCmd(sources, strings.Join(names, " + "))
+}
+
+// isContainerLogMarked reports whether the container is in the merged log set
+func (m Model) isContainerLogMarked(containerID string) bool {
+	for _, marked := range m.logMarks {
+		if marked.ContainerID == containerID {
+			return true
+		}
+	}
+	return false
+}
+
+// logMarkHelp returns the footer help for the merged log set
+func (m Model) logMarkHelp() string {
+	if len(m.logMarks) == 0 {
+		return " | m: 統合ログに追加"
+	}
+	return fmt.Sprintf(" | m: 統合ログに追加 | M: 統合ログ（%d件）", len(m.logMarks))
+}
+
+// toggleLogSource shows or hides the nth source of the merged log view (0 なら全て表示)
+func (m Model) toggleLogSource(n int) Model {
+	if n == 0 {
+		m.logHiddenSources = nil
+	} else if n <= len(m.logSources) {
+		name := m.logSources[n-1]
+		if m.logHiddenSources == nil {
+			m.logHiddenSources = make(map[string]bool)
+		}
+		m.logHiddenSources[name] = !m.logHiddenSources[name]
+	}
+	m.logMatchLine = -1
+	m.logAutoScroll = !m.logPaused
+	return m
+}
+
+// logSourceStyle returns the prefix color of a source in the merged log view
+func (m Model) logSourceStyle(name string) lipgloss.Style {
+	for i, source := range m.logSources {
+		if source == name {
+			return lipgloss.NewStyle().Foreground(logSourceColors[i%len(logSourceColors)]).Bold(true)
+		}
+	}
+	return CommentStyle
+}
+
+// renderLogSourceLegend renders the sources of the merged log view with their toggle keys
+func (m Model) renderLogSourceLegend() string {
+	var parts []string
+	for i, name := range m.logSources {
+		label := name
+		if i < 9 {
+			label = fmt.Sprintf("%d:%s", i+1, name)
+		}
+		if m.logHiddenSources[name] {
+			parts = append(parts, CommentStyle.Render("✗ "+label))
+		} else {
+			parts = append(parts, m.logSourceStyle(name).Render("✓ "+label))
+		}
+	}
+	return strings.Join(parts, "  ") + CommentStyle.Render("  (1-9: 表示切替 | 0: 全て表示)")
+}
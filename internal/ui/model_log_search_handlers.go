@@ -0,0 +1,322 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor/logs"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// handleLogSearchInput handles typing a search pattern in the log view (入力中に逐次検索する)
+func (m Model) handleLogSearchInput(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		// パターンを確定（空なら検索を解除）
+		m.logSearchEditing = false
+		m.logSearch = m.logSearchInput
+		if m.logSearch == "" {
+			return m.clearLogSearch(), nil
+		}
+		return m, nil
+
+	case tea.KeyEsc:
+		// 入力をキャンセルして元の検索に戻す
+		m.logSearchEditing = false
+		m.logSearchInput = m.logSearch
+		return m.applyLogSearch(m.logSearch), nil
+
+	case tea.KeyBackspace:
+		runes := []rune(m.logSearchInput)
+		if len(runes) > 0 {
+			m.logSearchInput = string(runes[:len(runes)-1])
+		}
+		return m.applyLogSearch(m.logSearchInput), nil
+
+	case tea.KeyRunes, tea.KeySpace:
+		m.logSearchInput += string(msg.Runes)
+		return m.applyLogSearch(m.logSearchInput), nil
+	}
+
+	return m, nil
+}
+
+// applyLogSearch compiles the pattern and jumps to the first match from the current position
+func (m Model) applyLogSearch(pattern string) Model {
+	m.logSearchRegex, m.logSearchErr = compileLogSearch(pattern)
+	m.logMatchLine = -1
+	if m.logSearchRegex == nil {
+		m.logFilter = false
+		return m
+	}
+	if m.logFilter {
+		// 絞り込み中は表示行が変わるため最新の一致行から表示する
+		m.logAutoScroll = !m.logPaused
+		return m
+	}
+	return m.jumpToLogMatch(true, m.logCurrentScroll()-1)
+}
+
+// compileLogSearch compiles a search pattern as a regular expression
+// 大文字を含まない場合は大文字小文字を区別しない。正規表現として不正な場合は文字列として検索する
+func compileLogSearch(pattern string) (*regexp.Regexp, string) {
+	if pattern == "" {
+		return nil, ""
+	}
+
+	flags := "(?i)"
+	for _, r := range pattern {
+		if unicode.IsUpper(r) {
+			flags = ""
+			break
+		}
+	}
+
+	re, err := regexp.Compile(flags + pattern)
+	if err == nil {
+		return re, ""
+	}
+	return regexp.MustCompile(flags + regexp.QuoteMeta(pattern)), "正規表現として不正なため文字列として検索しています"
+}
+
+// clearLogSearch removes the search pattern and the filter
+func (m Model) clearLogSearch() Model {
+	if m.logFilter {
+		m.logAutoScroll = !m.logPaused
+	}
+	m.logSearchEditing = false
+	m.logSearchInput = ""
+	m.logSearch = ""
+	m.logSearchRegex = nil
+	m.logSearchErr = ""
+	m.logFilter = false
+	m.logMatchLine = -1
+	return m
+}
+
+// jumpToLogMatch moves to the next (or previous) line after from that matches the search
+func (m Model) jumpToLogMatch(forward bool, from int) Model {
+	if m.logSearchRegex == nil {
+		return m
+	}
+	re := m.logSearchRegex
+	index := findLogLine(m.logViewLines(), from, forward, func(line logs.Line) bool {
+		return re.MatchString(line.Text)
+	})
+	if index < 0 {
+		return m
+	}
+	m.logMatchLine = index
+	return m.scrollLogTo(index)
+}
+
+// jumpToLogLevel moves to the next (or previous) line of the given level
+func (m Model) jumpToLogLevel(level logs.Level, forward bool) Model {
+	from := m.logMatchLine
+	if from < 0 {
+		from = m.logCurrentScroll() - 1
+		if !forward {
+			from = m.logCurrentScroll() + m.logContentHeight()
+		}
+	}
+	index := findLogLine(m.logViewLines(), from, forward, func(line logs.Line) bool {
+		return line.Level == level
+	})
+	if index < 0 {
+		return m
+	}
+	m.logMatchLine = index
+	return m.scrollLogTo(index)
+}
+
+// findLogLine returns the index of the first line after from that satisfies match (末尾まで行ったら先頭に戻る)
+func findLogLine(lines []logs.Line, from int, forward bool, match func(logs.Line) bool) int {
+	count := len(lines)
+	for i := 1; i <= count; i++ {
+		index := from - i
+		if forward {
+			index = from + i
+		}
+		index = ((index % count) + count) % count
+		if match(lines[index]) {
+			return index
+		}
+	}
+	return -1
+}
+
+// scrollLogTo scrolls the log view so that the line is visible (画面外なら中央に表示する)
+func (m Model) scrollLogTo(index int) Model {
+	top := m.logCurrentScroll()
+	height := m.logContentHeight()
+	m.logAutoScroll = false
+	if index >= top && index < top+height {
+		m.logScroll = top
+		return m
+	}
+
+	m.logScroll = index - height/2
+	if m.logScroll > m.logMaxScroll() {
+		m.logScroll = m.logMaxScroll()
+	}
+	if m.logScroll < 0 {
+		m.logScroll = 0
+	}
+	return m
+}
+
+// logViewCache holds the lines computed by logViewLines
+// 描画のたびに絞り込みやJSON整形をやり直さないよう、行の追加や表示条件が変わるまで使い回す
+type logViewCache struct {
+	key   logViewKey
+	lines []logs.Line
+	valid bool
+}
+
+// logViewKey is the state the lines shown in the log view depend on
+type logViewKey struct {
+	buffer    *logs.RingBuffer
+	appended  int // 追加された行数の累計（一時停止中は見ない）
+	paused    bool
+	pausedLen int
+	regex     *regexp.Regexp
+	filter    bool
+	pretty    bool
+	hidden    string // 非表示にしたソース名
+}
+
+// logViewKey returns the current state the log view lines depend on
+func (m Model) logViewKey() logViewKey {
+	key := logViewKey{
+		buffer:    m.logBuffer,
+		paused:    m.logPaused,
+		pausedLen: len(m.logPausedLines),
+		regex:     m.logSearchRegex,
+		filter:    m.logFilter,
+		pretty:    m.logPrettyJSON,
+	}
+	if m.logBuffer != nil && !m.logPaused {
+		key.appended = m.logBuffer.Appended()
+	}
+
+	var hidden []string
+	for name, isHidden := range m.logHiddenSources {
+		if isHidden {
+			hidden = append(hidden, name)
+		}
+	}
+	sort.Strings(hidden)
+	key.hidden = strings.Join(hidden, "\x00")
+	return key
+}
+
+// logViewLines returns the lines shown in the log view
+// 同じ状態では前回の結果を返す（呼び出し側は変更しないこと）
+func (m Model) logViewLines() []logs.Line {
+	key := m.logViewKey()
+	if m.logView != nil && m.logView.valid && m.logView.key == key {
+		return m.logView.lines
+	}
+
+	lines := m.buildLogViewLines()
+	if m.logView != nil {
+		*m.logView = logViewCache{key: key, lines: lines, valid: true}
+	}
+	return lines
+}
+
+// buildLogViewLines computes the lines shown in the log view
+// 一時停止中は停止時点の内容。絞り込み中は一致する行だけ、JSON整形中は構造化ログを複数行に展開する
+// 統合ログで非表示にしたソースの行は除く
+func (m Model) buildLogViewLines() []logs.Line {
+	var source []logs.Line
+	switch {
+	case m.logPaused:
+		source = m.logPausedLines
+	case m.logBuffer != nil:
+		source = m.logBuffer.Lines()
+	}
+
+	filter := m.logFilter && m.logSearchRegex != nil
+	if !filter && !m.logPrettyJSON && len(m.logHiddenSources) == 0 {
+		return source
+	}
+
+	lines := make([]logs.Line, 0, len(source))
+	for _, line := range source {
+		if m.logHiddenSources[line.Source] {
+			continue
+		}
+		if filter && !m.logSearchRegex.MatchString(line.Text) {
+			continue
+		}
+		if m.logPrettyJSON && line.JSON {
+			if pretty, ok := logs.PrettyJSON(line.Text); ok {
+				for _, text := range pretty {
+					lines = append(lines, logs.Line{Text: text, Level: line.Level, JSON: true, Source: line.Source, Time: line.Time})
+				}
+				continue
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// logLevelCounts counts ERROR and WARN lines in the buffer
+func (m Model) logLevelCounts() (int, int) {
+	var source []logs.Line
+	switch {
+	case m.logPaused:
+		source = m.logPausedLines
+	case m.logBuffer != nil:
+		source = m.logBuffer.Lines()
+	}
+
+	errors, warnings := 0, 0
+	for _, line := range source {
+		switch line.Level {
+		case logs.LevelError:
+			errors++
+		case logs.LevelWarn:
+			warnings++
+		}
+	}
+	return errors, warnings
+}
+
+// logSearchStatus describes the search state for the log view status line
+func (m Model) logSearchStatus() string {
+	if m.logSearchEditing {
+		return "/" + m.logSearchInput + "█"
+	}
+	if m.logSearchRegex == nil {
+		return ""
+	}
+
+	matches := 0
+	current := 0
+	for i, line := range m.logViewLines() {
+		if m.logSearchRegex.MatchString(line.Text) {
+			matches++
+			if i <= m.logMatchLine {
+				current = matches
+			}
+		}
+	}
+
+	var parts []string
+	parts = append(parts, "/"+m.logSearch)
+	if m.logMatchLine >= 0 && current > 0 {
+		parts = append(parts, fmt.Sprintf("一致 %d/%d", current, matches))
+	} else {
+		parts = append(parts, fmt.Sprintf("一致 %d件", matches))
+	}
+	if m.logFilter {
+		parts = append(parts, "[絞り込み]")
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,171 @@
+package ui
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/db"
+	"github.com/Masahide-S/bho_hacka_go/internal/logger"
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor/logs"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ログのエラー検知
+const (
+	logWatchInterval   = 30               // 収集間隔（tick数 = 秒）
+	logIssueMax        = 20               // 保持する問題の最大数
+	logIssueTTL        = 15 * time.Minute // 問題を表示し続ける時間
+	logIssueMessageLen = 80               // 表示するエラーメッセージの最大文字数
+	logWatchProcessTTL = 5 * time.Minute  // Node.js/Pythonプロセスを検出し直す間隔
+)
+
+// logWatcher collects logs in the background and keeps the state needed to detect errors
+// Model は値で受け渡されるため、ポインタで共有する
+type logWatcher struct {
+	collector *logs.Collector
+	spikes    *logs.SpikeDetector
+	running   atomic.Bool // 前回の収集が終わっていなければ次を始めない
+
+	// Node.js/Pythonプロセスの一覧（仮想環境の検索やバージョンの取得が重いため logWatchProcessTTL ごとに取り直す）
+	// 収集は同時に1つしか実行しないため、収集中のみ読み書きする
+	nodes       []monitor.NodeProcess
+	pythons     []monitor.PythonProcess
+	processesAt time.Time
+}
+
+// devProcesses returns the Node.js and Python processes, detecting them again when the list is old
+func (w *logWatcher) devProcesses(now time.Time) ([]monitor.NodeProcess, []monitor.PythonProcess) {
+	if now.Sub(w.processesAt) >= logWatchProcessTTL {
+		w.nodes = monitor.GetNodeProcesses()
+		w.pythons = monitor.GetPythonProcesses()
+		w.processesAt = now
+	}
+	return w.nodes, w.pythons
+}
+
+// newLogWatcher creates a watcher with no history
+func newLogWatcher() *logWatcher {
+	return &logWatcher{
+		collector: logs.NewCollector(),
+		spikes:    logs.NewSpikeDetector(),
+	}
+}
+
+// logIssuesMsg is sent when a log collection has finished
+type logIssuesMsg struct {
+	issues []logs.LogIssue
+}
+
+// collectLogIssuesCmd saves new log lines of running containers and processes and detects error spikes and new errors
+// コンテナは定期的に更新しているコンテナリストのキャッシュを使う
+func collectLogIssuesCmd(watcher *logWatcher, store *db.Store, containers []monitor.DockerContainer) tea.Cmd {
+	if watcher == nil || store == nil || !watcher.running.CompareAndSwap(false, true) {
+		return nil
+	}
+	return func() tea.Msg {
+		defer watcher.running.Store(false)
+
+		now := time.Now()
+		nodes, pythons := watcher.devProcesses(now)
+		sources := logs.DiscoverWatchSources(containers, nodes, pythons)
+		batches := watcher.collector.Collect(sources)
+
+		var issues []logs.LogIssue
+		for _, batch := range batches {
+			source := batch.Source
+			if err := store.SaveLogLines(source.Name, batch.Lines); err != nil {
+				logger.LogIssue("DB_WRITE_ERROR", err.Error())
+			}
+
+			events := logs.ExtractErrorEvents(batch.Lines)
+			for _, event := range events {
+				isNew, err := store.RecordErrorSignature(event)
+				if err != nil {
+					logger.LogIssue("DB_WRITE_ERROR", err.Error())
+					continue
+				}
+				// 監視を始めたときの過去ログは既知のエラーとして記録するだけ
+				if isNew && !batch.Initial {
+					issues = append(issues, logs.LogIssue{
+						Kind:      logs.IssueNewError,
+						Source:    source.Name,
+						Group:     source.Group,
+						Message:   event.Message,
+						Signature: event.Signature,
+						Time:      now,
+					})
+				}
+			}
+
+			if batch.Initial {
+				continue
+			}
+			if count, spike := watcher.spikes.Observe(source.Name, events, now); spike {
+				issues = append(issues, logs.LogIssue{
+					Kind:    logs.IssueErrorSpike,
+					Source:  source.Name,
+					Group:   source.Group,
+					Message: fmt.Sprintf("エラーが急増しています（1分間に%d件）", count),
+					Count:   count,
+					Time:    now,
+				})
+			}
+		}
+
+		active := make(map[string]bool)
+		for _, source := range sources {
+			active[source.Name] = true
+		}
+		watcher.spikes.Forget(active)
+
+		return logIssuesMsg{issues: issues}
+	}
+}
+
+// handleLogIssues adds newly detected issues and marks the affected menu items
+func (m Model) handleLogIssues(msg logIssuesMsg) (Model, tea.Cmd) {
+	now := time.Now()
+
+	// 古い問題を消して新しい問題を追加（新しい順）
+	var issues []logs.LogIssue
+	for i := len(msg.issues) - 1; i >= 0; i-- {
+		issues = append(issues, msg.issues[i])
+	}
+	for _, issue := range m.logIssues {
+		if now.Sub(issue.Time) < logIssueTTL {
+			issues = append(issues, issue)
+		}
+	}
+	if len(issues) > logIssueMax {
+		issues = issues[:logIssueMax]
+	}
+	m.logIssues = issues
+
+	groups := make(map[string]bool)
+	for _, issue := range m.logIssues {
+		if issue.Group != "" {
+			groups[issue.Group] = true
+		}
+	}
+	for i := range m.menuItems {
+		m.menuItems[i].HasIssue = groups[m.menuItems[i].Name]
+	}
+	m.aiIssueCount = len(m.logIssues)
+
+	if len(msg.issues) > 0 {
+		issue := msg.issues[len(msg.issues)-1]
+		m.lastCommandResult = "⚠ " + formatLogIssue(issue)
+		logger.LogIssue("LOG_"+string(issue.Kind), fmt.Sprintf("%s: %s", issue.Source, issue.Message))
+	}
+	return m, nil
+}
+
+// formatLogIssue returns a one-line description of an issue
+func formatLogIssue(issue logs.LogIssue) string {
+	if issue.Kind == logs.IssueNewError {
+		return fmt.Sprintf("%s: 新しいエラー: %s", issue.Source, truncateCommand(issue.Message, logIssueMessageLen))
+	}
+	return fmt.Sprintf("%s: %s", issue.Source, issue.Message)
+}
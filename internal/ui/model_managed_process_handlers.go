@@ -0,0 +1,181 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/db"
+	"github.com/Masahide-S/bho_hacka_go/internal/logger"
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// managedRegisterWait は起動した devmon run がDBに登録するのを待つ時間
+const managedRegisterWait = time.Second
+
+// managedProcessesMsg is sent when the processes started with "devmon run" are loaded from the store
+type managedProcessesMsg struct {
+	processes []monitor.ManagedProcess
+}
+
+// managedLaunchMsg is sent when a process has been launched from the TUI
+type managedLaunchMsg struct {
+	process   monitor.ManagedProcess
+	processes []monitor.ManagedProcess // 起動後に読み直した一覧（DBがない場合はnil）
+	err       error
+}
+
+// loadManagedProcessesCmd reads the processes started with "devmon run" from the store
+func loadManagedProcessesCmd(store *db.Store) tea.Cmd {
+	return func() tea.Msg {
+		processes, err := store.GetManagedProcesses()
+		if err != nil {
+			logger.LogIssue("DB_READ_ERROR", err.Error())
+			return nil
+		}
+		return managedProcessesMsg{processes: processes}
+	}
+}
+
+// launchManagedProcessCmd starts a command with "devmon run" in the background
+func launchManagedProcessCmd(store *db.Store, dir string, command []string) tea.Cmd {
+	return func() tea.Msg {
+		process, err := monitor.LaunchManagedProcess("", dir, command)
+		if err != nil {
+			return managedLaunchMsg{err: err}
+		}
+
+		msg := managedLaunchMsg{process: process}
+		if store != nil {
+			time.Sleep(managedRegisterWait)
+			if processes, err := store.GetManagedProcesses(); err == nil {
+				msg.processes = processes
+			}
+		}
+		return msg
+	}
+}
+
+// restartManagedProcessCmd restarts a process started with "devmon run"
+// 記録したPIDが別のプロセスに再利用されている場合は停止せず、終了したものとして記録してから起動する
+func restartManagedProcessCmd(store *db.Store, key string) tea.Cmd {
+	return func() tea.Msg {
+		if process, ok := monitor.LookupManagedProcess(key); ok && process.Running() && !monitor.ManagedProcessAlive(process) && store != nil {
+			if err := store.FinishManagedProcess(process.Key, process.PID, -1, time.Now()); err != nil {
+				logger.LogIssue("DB_WRITE_ERROR", err.Error())
+			}
+		}
+
+		result := monitor.RestartManagedProcess(key)
+		return executeCommandMsg{
+			success: result.Success,
+			message: result.Message,
+		}
+	}
+}
+
+// handleLaunchPrompt starts typing a command to run in the selected process's project directory
+func (m Model) handleLaunchPrompt() (Model, tea.Cmd) {
+	// プロセスを選択していればそのプロジェクト、なければdevmonを起動したディレクトリで実行する
+	dir := ""
+	if m.focusedPanel == "right" {
+		if source, ok := m.selectedLogSource(); ok {
+			dir = source.ProjectDir
+		}
+	}
+	if dir == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			m.lastCommandResult = fmt.Sprintf("起動ディレクトリを取得できません: %v", err)
+			return m, nil
+		}
+		dir = wd
+	}
+
+	m.launchEditing = true
+	m.launchDir = dir
+	m.launchHistory = monitor.ManagedCommandsForDir(dir)
+	m.launchHistoryIndex = 0
+	m.launchInput = ""
+	if len(m.launchHistory) > 0 {
+		// 前回と同じコマンドをすぐ起動できるように入力しておく
+		m.launchInput = m.launchHistory[0]
+	}
+	return m, nil
+}
+
+// handleLaunchInput handles typing the command to launch
+func (m Model) handleLaunchInput(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.launchEditing = false
+		command := monitor.ParseManagedCommand(m.launchInput)
+		if len(command) == 0 {
+			return m, nil
+		}
+		m.lastCommandResult = "起動中: " + m.launchInput
+		return m, launchManagedProcessCmd(m.dbStore, m.launchDir, command)
+
+	case tea.KeyEsc:
+		m.launchEditing = false
+		return m, nil
+
+	case tea.KeyTab:
+		// 以前に実行したコマンドを順に切り替える
+		if len(m.launchHistory) > 0 {
+			m.launchHistoryIndex = (m.launchHistoryIndex + 1) % len(m.launchHistory)
+			m.launchInput = m.launchHistory[m.launchHistoryIndex]
+		}
+		return m, nil
+
+	case tea.KeyBackspace:
+		runes := []rune(m.launchInput)
+		if len(runes) > 0 {
+			m.launchInput = string(runes[:len(runes)-1])
+		}
+		return m, nil
+
+	case tea.KeyRunes, tea.KeySpace:
+		m.launchInput += string(msg.Runes)
+		return m, nil
+	}
+
+	return m, nil
+}
+
+// handleManagedLaunch shows the result of launching a process
+func (m Model) handleManagedLaunch(msg managedLaunchMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		m.lastCommandResult = fmt.Sprintf("起動失敗: %v", msg.err)
+	} else {
+		if msg.processes != nil {
+			monitor.RememberManagedProcesses(msg.processes)
+		}
+		m.lastCommandResult = fmt.Sprintf("%s を起動しました: %s（ログ: %s）", msg.process.Name, msg.process.CommandLine(), msg.process.LogFile)
+	}
+
+	return m, tea.Tick(5*time.Second, func(t time.Time) tea.Msg {
+		return clearCommandResultMsg{}
+	})
+}
+
+// handleManagedProcessRestart asks to restart the selected process with the command it was started with
+func (m Model) handleManagedProcessRestart() (Model, tea.Cmd) {
+	source, ok := m.selectedLogSource()
+	if !ok {
+		return m, nil
+	}
+
+	process, found := monitor.FindManagedProcess(source.ProjectDir)
+	if !found || !process.Running() {
+		m.lastCommandResult = "devmon run で起動したプロセスではないため再起動できません（n: devmon run で起動）"
+		return m, nil
+	}
+
+	m.showConfirmDialog = true
+	m.confirmAction = "restart"
+	m.confirmTarget = process.Key
+	m.confirmType = "managed_process"
+	return m, nil
+}
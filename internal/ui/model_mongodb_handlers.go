@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// handleMongoDatabaseDrop handles database drop
+func (m Model) handleMongoDatabaseDrop() (Model, tea.Cmd) {
+	if m.rightPanelCursor >= len(m.rightPanelItems) {
+		return m, nil
+	}
+
+	selectedItem := m.rightPanelItems[m.rightPanelCursor]
+
+	// データベース以外は何もしない
+	if selectedItem.Type != "database" {
+		return m, nil
+	}
+
+	// システムデータベースは削除不可
+	if monitor.IsMongoSystemDatabase(selectedItem.Name) {
+		return m, nil
+	}
+
+	// 確認ダイアログを表示
+	m.showConfirmDialog = true
+	m.confirmAction = "drop"
+	m.confirmTarget = selectedItem.Name
+	m.confirmType = "mongo_database"
+
+	return m, nil
+}
+
+// mongoStatusMsg is sent when MongoDB server status and current operations are fetched
+type mongoStatusMsg struct {
+	Status monitor.MongoServerStatus
+	Ops    []monitor.MongoOperation
+}
+
+// mongoCollectionsMsg is sent when collections of a MongoDB database are fetched
+type mongoCollectionsMsg struct {
+	Database    string
+	Collections []monitor.MongoCollection
+}
+
+// fetchMongoStatusCmd fetches MongoDB server status and current operations asynchronously
+func fetchMongoStatusCmd() tea.Cmd {
+	return func() tea.Msg {
+		return mongoStatusMsg{
+			Status: monitor.GetMongoServerStatus(),
+			Ops:    monitor.GetMongoCurrentOps(),
+		}
+	}
+}
+
+// fetchMongoCollectionsCmd fetches collections of a MongoDB database asynchronously
+func fetchMongoCollectionsCmd(databaseName string) tea.Cmd {
+	return func() tea.Msg {
+		return mongoCollectionsMsg{
+			Database:    databaseName,
+			Collections: monitor.GetMongoCollections(databaseName),
+		}
+	}
+}
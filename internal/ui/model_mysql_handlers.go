@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -18,18 +19,15 @@ func (m Model) handleMySQLDatabaseDrop() (Model, tea.Cmd) {
 	}
 
 	// システムデータベースは削除不可
-	systemDatabases := []string{"information_schema", "performance_schema", "mysql", "sys"}
-	for _, sysDB := range systemDatabases {
-		if selectedItem.Name == sysDB {
-			return m, nil
-		}
+	if monitor.IsMySQLSystemDatabase(selectedItem.Name) {
+		return m, nil
 	}
 
 	// 確認ダイアログを表示
 	m.showConfirmDialog = true
 	m.confirmAction = "drop"
 	m.confirmTarget = selectedItem.Name
-	m.confirmType = "database"
+	m.confirmType = "mysql_database"
 
 	return m, nil
 }
@@ -51,7 +49,61 @@ func (m Model) handleMySQLDatabaseOptimize() (Model, tea.Cmd) {
 	m.showConfirmDialog = true
 	m.confirmAction = "optimize"
 	m.confirmTarget = selectedItem.Name
-	m.confirmType = "database"
+	m.confirmType = "mysql_database"
 
 	return m, nil
 }
+
+// handleMySQLKillQuery handles KILL QUERY for a selected connection
+func (m Model) handleMySQLKillQuery() (Model, tea.Cmd) {
+	if m.rightPanelCursor >= len(m.rightPanelItems) {
+		return m, nil
+	}
+
+	selectedItem := m.rightPanelItems[m.rightPanelCursor]
+
+	// 接続以外は何もしない
+	if selectedItem.Type != "mysql_process" {
+		return m, nil
+	}
+
+	// 確認ダイアログを表示
+	m.showConfirmDialog = true
+	m.confirmAction = "kill_query"
+	m.confirmTarget = selectedItem.Name // 接続ID
+	m.confirmType = "mysql_process"
+
+	return m, nil
+}
+
+// mysqlStatusMsg is sent when MySQL server status and processlist are fetched
+type mysqlStatusMsg struct {
+	Status    monitor.MySQLServerStatus
+	Processes []monitor.MySQLProcess
+}
+
+// mysqlTablesMsg is sent when tables of a MySQL database are fetched
+type mysqlTablesMsg struct {
+	Database string
+	Tables   []monitor.MySQLTable
+}
+
+// fetchMySQLStatusCmd fetches MySQL server status and processlist asynchronously
+func fetchMySQLStatusCmd() tea.Cmd {
+	return func() tea.Msg {
+		return mysqlStatusMsg{
+			Status:    monitor.GetMySQLServerStatus(),
+			Processes: monitor.GetMySQLProcessList(),
+		}
+	}
+}
+
+// fetchMySQLTablesCmd fetches tables of a MySQL database asynchronously
+func fetchMySQLTablesCmd(databaseName string) tea.Cmd {
+	return func() tea.Msg {
+		return mysqlTablesMsg{
+			Database: databaseName,
+			Tables:   monitor.GetMySQLTables(databaseName),
+		}
+	}
+}
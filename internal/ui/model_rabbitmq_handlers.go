@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// handleRabbitMQPurge handles queue purge
+func (m Model) handleRabbitMQPurge() (Model, tea.Cmd) {
+	queue := m.getSelectedRabbitMQQueue()
+	if queue == nil {
+		return m, nil
+	}
+
+	// 確認ダイアログを表示
+	m.showConfirmDialog = true
+	m.confirmAction = "purge"
+	m.confirmTarget = monitor.RabbitMQQueueKey(queue.VHost, queue.Name)
+	m.confirmType = "rabbitmq_queue"
+
+	return m, nil
+}
+
+// rabbitMQDataMsg is sent when RabbitMQ overview and queues are fetched
+type rabbitMQDataMsg struct {
+	Overview monitor.RabbitMQOverview
+	Queues   []monitor.RabbitMQQueue
+}
+
+// fetchRabbitMQDataCmd fetches RabbitMQ overview and queues asynchronously
+func fetchRabbitMQDataCmd() tea.Cmd {
+	return func() tea.Msg {
+		return rabbitMQDataMsg{
+			Overview: monitor.GetRabbitMQOverview(),
+			Queues:   monitor.GetRabbitMQQueues(),
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -21,7 +22,122 @@ func (m Model) handleRedisFlushDB() (Model, tea.Cmd) {
 	m.showConfirmDialog = true
 	m.confirmAction = "flushdb"
 	m.confirmTarget = selectedItem.Name
-	m.confirmType = "database"
+	m.confirmType = "redis_database"
 
 	return m, nil
 }
+
+// handleRedisBrowseKeys opens the key browser for the selected database
+func (m Model) handleRedisBrowseKeys() (Model, tea.Cmd) {
+	if m.rightPanelCursor >= len(m.rightPanelItems) {
+		return m, nil
+	}
+
+	selectedItem := m.rightPanelItems[m.rightPanelCursor]
+
+	// データベース以外は何もしない
+	if selectedItem.Type != "database" {
+		return m, nil
+	}
+
+	m.redisBrowseDB = selectedItem.Name
+	m.cachedRedisKeys = []monitor.RedisKey{}
+	m.redisKeysLoading = true
+	m.redisKeysError = ""
+	m = m.updateRightPanelItems()
+
+	return m, fetchRedisKeysCmd(m.redisBrowseDB, m.redisKeyPattern)
+}
+
+// handleRedisKeyDelete handles key deletion
+func (m Model) handleRedisKeyDelete() (Model, tea.Cmd) {
+	if m.rightPanelCursor >= len(m.rightPanelItems) {
+		return m, nil
+	}
+
+	selectedItem := m.rightPanelItems[m.rightPanelCursor]
+
+	// キー以外は何もしない
+	if selectedItem.Type != "redis_key" {
+		return m, nil
+	}
+
+	// 確認ダイアログを表示
+	m.showConfirmDialog = true
+	m.confirmAction = "delete_key"
+	m.confirmTarget = m.redisBrowseDB + ":" + selectedItem.Name // "db0:key" 形式
+	m.confirmType = "redis_key"
+
+	return m, nil
+}
+
+// handleRedisPatternInput handles key input while editing the SCAN pattern
+func (m Model) handleRedisPatternInput(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		// パターンを確定して再検索
+		m.redisPatternEditing = false
+		m.redisKeyPattern = m.redisPatternInput
+		if m.redisBrowseDB == "" {
+			return m, nil
+		}
+		m.redisKeysLoading = true
+		return m, fetchRedisKeysCmd(m.redisBrowseDB, m.redisKeyPattern)
+
+	case tea.KeyEsc:
+		// 入力をキャンセル
+		m.redisPatternEditing = false
+		m.redisPatternInput = m.redisKeyPattern
+		return m, nil
+
+	case tea.KeyBackspace:
+		runes := []rune(m.redisPatternInput)
+		if len(runes) > 0 {
+			m.redisPatternInput = string(runes[:len(runes)-1])
+		}
+		return m, nil
+
+	case tea.KeyRunes, tea.KeySpace:
+		m.redisPatternInput += string(msg.Runes)
+		return m, nil
+	}
+
+	return m, nil
+}
+
+// redisInfoMsg is sent when Redis server info and slowlog are fetched
+type redisInfoMsg struct {
+	Info    monitor.RedisServerInfo
+	SlowLog []monitor.RedisSlowLogEntry
+}
+
+// redisKeysMsg is sent when keys of a Redis database are scanned
+type redisKeysMsg struct {
+	Database string
+	Pattern  string
+	Keys     []monitor.RedisKey
+	Err      error
+}
+
+// fetchRedisInfoCmd fetches Redis server info and slowlog asynchronously
+func fetchRedisInfoCmd() tea.Cmd {
+	return func() tea.Msg {
+		return redisInfoMsg{
+			Info:    monitor.GetRedisServerInfo(),
+			SlowLog: monitor.GetRedisSlowLog(10),
+		}
+	}
+}
+
+// fetchRedisKeysCmd scans keys of a Redis database asynchronously
+func fetchRedisKeysCmd(database, pattern string) tea.Cmd {
+	return func() tea.Msg {
+		keys, err := monitor.ScanRedisKeys(database, pattern)
+		return redisKeysMsg{
+			Database: database,
+			Pattern:  pattern,
+			Keys:     keys,
+			Err:      err,
+		}
+	}
+}
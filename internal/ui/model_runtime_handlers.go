@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// handleRuntimeProcessKill handles Go/Ruby/JVM/Deno/Bun/PHP process kill
+func (m Model) handleRuntimeProcessKill() (Model, tea.Cmd) {
+	if m.rightPanelCursor >= len(m.rightPanelItems) {
+		return m, nil
+	}
+
+	selectedItem := m.rightPanelItems[m.rightPanelCursor]
+
+	// プロセス以外は何もしない
+	if selectedItem.Type != "runtime_process" {
+		return m, nil
+	}
+
+	// 確認ダイアログを表示
+	m.showConfirmDialog = true
+	m.confirmAction = "kill"
+	m.confirmTarget = selectedItem.Name // PID
+	m.confirmType = "runtime_process"
+
+	return m, nil
+}
+
+// handleRuntimeProcessForceKill handles Go/Ruby/JVM/Deno/Bun/PHP process force kill
+func (m Model) handleRuntimeProcessForceKill() (Model, tea.Cmd) {
+	if m.rightPanelCursor >= len(m.rightPanelItems) {
+		return m, nil
+	}
+
+	selectedItem := m.rightPanelItems[m.rightPanelCursor]
+
+	// プロセス以外は何もしない
+	if selectedItem.Type != "runtime_process" {
+		return m, nil
+	}
+
+	// 確認ダイアログを表示
+	m.showConfirmDialog = true
+	m.confirmAction = "force_kill"
+	m.confirmTarget = selectedItem.Name // PID
+	m.confirmType = "runtime_process"
+
+	return m, nil
+}
+
+// handleViewRuntimeProcessLogs handles viewing Go/Ruby/JVM/Deno/Bun/PHP process logs
+func (m Model) handleViewRuntimeProcessLogs() (Model, tea.Cmd) {
+	process := m.getSelectedRuntimeProcess()
+	if process == nil {
+		return m, nil
+	}
+
+	return m, followProcessLogsCmd(process.ProjectDir, process.ProjectName)
+}
+
+// jvmHeapMsg is sent when heap usage of a JVM process is fetched
+type jvmHeapMsg struct {
+	PID  string
+	Heap string
+}
+
+// fetchJVMHeapCmd fetches heap usage of a JVM process via jcmd asynchronously
+func fetchJVMHeapCmd(pid string) tea.Cmd {
+	return func() tea.Msg {
+		return jvmHeapMsg{
+			PID:  pid,
+			Heap: monitor.GetJVMHeap(pid),
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"fmt"
+	"os/exec"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// shellReadyMsg is sent when an interactive shell command has been prepared
+type shellReadyMsg struct {
+	cmd        *exec.Cmd
+	targetName string
+	err        error
+}
+
+// shellExitedMsg is sent when the interactive shell exits and the TUI resumes
+type shellExitedMsg struct {
+	targetName string
+	err        error
+}
+
+// handleOpenShell opens an interactive shell for the selected container or process directory
+func (m Model) handleOpenShell() (Model, tea.Cmd) {
+	if m.rightPanelCursor >= len(m.rightPanelItems) {
+		return m, nil
+	}
+
+	var directory, targetName string
+	selectedMenuItem := m.menuItems[m.selectedItem]
+
+	switch selectedMenuItem.Name {
+	case "Docker":
+		// コンテナの場合は docker exec、Composeプロジェクトの場合は作業ディレクトリ
+		if container := m.getSelectedContainer(); container != nil {
+			if container.Status != "running" {
+				m.lastCommandResult = "シェルを開けません: コンテナが停止中です"
+				return m, nil
+			}
+			return m, prepareContainerShellCmd(container.ID, container.Name)
+		}
+		if item := m.rightPanelItems[m.rightPanelCursor]; item.Type == "project" {
+			if project := m.getComposeProject(item.Name); project != nil {
+				directory, targetName = project.WorkingDir, item.Name
+			}
+		}
+
+	case "Node.js":
+		if process := m.getSelectedNodeProcess(); process != nil {
+			directory, targetName = process.ProjectDir, process.ProjectName
+		}
+
+	case "Python":
+		if process := m.getSelectedPythonProcess(); process != nil {
+			directory, targetName = process.ProjectDir, process.ProcessType
+		}
+
+	default:
+		if isRuntimeMenu(selectedMenuItem.Name) {
+			if process := m.getSelectedRuntimeProcess(); process != nil {
+				directory, targetName = process.ProjectDir, process.ProjectName
+			}
+		}
+	}
+
+	if directory == "" {
+		m.lastCommandResult = "ディレクトリ情報が見つかりません"
+		return m, nil
+	}
+
+	cmd, err := monitor.NewDirectoryShellCommand(directory)
+	if err != nil {
+		m.lastCommandResult = "シェルを開けませんでした: " + err.Error()
+		return m, nil
+	}
+
+	return m, runShellCmd(cmd, targetName)
+}
+
+// prepareContainerShellCmd detects the container shell asynchronously (docker exec can be slow)
+func prepareContainerShellCmd(containerID, containerName string) tea.Cmd {
+	return func() tea.Msg {
+		cmd, err := monitor.NewContainerShellCommand(containerID)
+		return shellReadyMsg{cmd: cmd, targetName: containerName, err: err}
+	}
+}
+
+// runShellCmd suspends the TUI, runs the shell and resumes on exit
+func runShellCmd(cmd *exec.Cmd, targetName string) tea.Cmd {
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return shellExitedMsg{targetName: targetName, err: err}
+	})
+}
+
+// handleShellReady starts the prepared shell or reports the error
+func (m Model) handleShellReady(msg shellReadyMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		m.lastCommandResult = "シェルを開けませんでした: " + msg.err.Error()
+		return m, nil
+	}
+	return m, runShellCmd(msg.cmd, msg.targetName)
+}
+
+// handleShellExited reports the shell exit and refreshes the panel
+func (m Model) handleShellExited(msg shellExitedMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		m.lastCommandResult = fmt.Sprintf("シェル終了 (%s): %v", msg.targetName, msg.err)
+	} else {
+		m.lastCommandResult = fmt.Sprintf("シェルを終了しました: %s", msg.targetName)
+	}
+
+	// シェル内の操作でコンテナやプロセスの状態が変わっている可能性があるため更新
+	m = m.updateRightPanelItems()
+	return m, m.fetchSelectedServiceCmd()
+}
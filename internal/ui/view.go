@@ -87,11 +87,11 @@ func (m Model) renderLeftMenu(width, height int) string {
 
 		// AI項目の特別表示
 		if item.Type == "ai" {
-			// Ollama接続状態のマーク
+			// LLMサーバーの接続状態のマーク
 			statusMark := " ●" // デフォルト（未確認）
 			statusStyle := InfoStyle
 
-			if m.ollamaAvailable {
+			if m.aiAvailable {
 				statusMark = " ✓"
 				statusStyle = SuccessStyle
 			} else {
@@ -127,6 +127,11 @@ func (m Model) renderLeftMenu(width, height int) string {
 			line = ErrorStyle.Render(line)
 		}
 
+		// ログでエラーを検知した項目
+		if item.HasIssue {
+			line += WarningStyle.Render(" !")
+		}
+
 		menuLines = append(menuLines, line)
 	}
 
@@ -175,6 +180,9 @@ func (m Model) renderRightDetail(width, height int) string {
 		// Dockerの場合は特別処理
 		if selectedItem.Name == "Docker" {
 			content = m.renderDockerContent()
+		} else if isDockerResourceMenu(selectedItem.Name) {
+			// Dockerのボリューム・ネットワーク・イメージの場合は特別処理
+			content = m.renderDockerResourcesContent(selectedItem.Name)
 		} else if selectedItem.Name == "PostgreSQL" {
 			// PostgreSQLの場合は特別処理
 			content = m.renderPostgresContent()
@@ -184,12 +192,27 @@ func (m Model) renderRightDetail(width, height int) string {
 		} else if selectedItem.Name == "Redis" {
 			// Redisの場合は特別処理
 			content = m.renderRedisContent()
+		} else if selectedItem.Name == "MongoDB" {
+			// MongoDBの場合は特別処理
+			content = m.renderMongoContent()
+		} else if selectedItem.Name == "RabbitMQ" {
+			// RabbitMQの場合は特別処理
+			content = m.renderRabbitMQContent()
+		} else if selectedItem.Name == "Kafka" {
+			// Kafkaの場合は特別処理
+			content = m.renderKafkaContent()
+		} else if selectedItem.Name == "Elasticsearch" {
+			// Elasticsearchの場合は特別処理
+			content = m.renderElasticsearchContent()
 		} else if selectedItem.Name == "Node.js" {
 			// Node.jsの場合は特別処理
 			content = m.renderNodejsContent()
 		} else if selectedItem.Name == "Python" {
 			// Pythonの場合は特別処理
 			content = m.renderPythonContent()
+		} else if isRuntimeMenu(selectedItem.Name) {
+			// Go/Ruby/JVM/Deno/Bun/PHPの場合は特別処理
+			content = m.renderRuntimeContent(selectedItem.Name)
 		} else if selectedItem.Name == "ポート一覧" {
 			// ポート一覧の場合は特別処理
 			content = m.renderPortsContent()
@@ -238,32 +261,36 @@ func (m Model) renderAIAnalysis() string {
 	// ヘッダー部分：モデル情報と接続状態
 	header := m.renderAIHeader()
 
+	// 送信内容のプレビュー
+	if m.aiShowPreview {
+		return header + "\n\n" + m.renderAIPreview()
+	}
+
 	switch m.aiState {
 	case aiStateLoading:
+		// 続けて質問した場合やツールを使っている場合は、それまでの会話と呼び出したツールに続けて表示
+		if progress := m.renderAIConversation() + m.renderAIToolTrace(); progress != "" {
+			status := "生成中..."
+			if m.aiAgentEvents != nil {
+				status = "ツールで調査中..."
+			}
+			return header + "\n\n" + strings.TrimRight(progress+m.aiResponse, "\n") + "\n\n" + InfoStyle.Render(status)
+		}
 		// ストリーミング中は既に受信した内容を表示
 		if m.aiResponse != "" {
 			return header + "\n\n" + m.aiResponse + "\n\n" + InfoStyle.Render("生成中...")
 		}
 		return header + "\n\n" + `環境を分析中...
 
-Ollamaが環境情報を読み取っています。
+AIが環境情報を読み取っています。
 しばらくお待ちください。`
 
 	case aiStateSuccess:
-		baseContent := header + "\n\n" + m.aiResponse
+		baseContent := header + "\n\n" + strings.TrimRight(m.renderAIConversation()+m.renderAIToolTrace()+m.aiResponse, "\n")
 
 		// コマンド実行待ちの場合のプロンプト表示
 		if m.aiPendingCmd != "" {
-			prompt := fmt.Sprintf(`
-
-────────────────────────────────────────
-🤖 AIがアクションを提案しています:
-
-  $ %s
-
-[Enter] 実行する    [Esc] キャンセル
-────────────────────────────────────────`, m.aiPendingCmd)
-			baseContent += WarningStyle.Render(prompt)
+			baseContent += "\n\n" + m.renderAIPendingCmd()
 		}
 
 		// 実行結果の表示
@@ -284,7 +311,7 @@ Ollamaが環境情報を読み取っています。
 			baseContent += "\n\n" + resultStyle.Render(m.aiCmdResult)
 		}
 
-		baseContent += "\n\n[a] 再分析"
+		baseContent += "\n\n[a] 再分析    [c] 続けて質問    [P] 送信内容を確認"
 		return baseContent
 
 	case aiStateError:
@@ -293,14 +320,16 @@ Ollamaが環境情報を読み取っています。
 エラーが発生しました:
 %s
 
-[a] 再試行`, m.aiResponse)
+[a] 再試行    [c] 質問する`, m.aiResponse)
 
 	default: // aiStateIdle
 		return header + `
 
 環境分析の準備ができています。
 
-[a] キーを押して環境全体を分析`
+[a] キーを押して環境全体を分析
+[c] キーを押して質問（会話を続けて深掘りできます）
+[P] キーを押してAIに送る内容を確認（シークレットはマスクされます）`
 	}
 }
 
@@ -308,24 +337,70 @@ Ollamaが環境情報を読み取っています。
 func (m Model) renderAIHeader() string {
 	// 接続状態
 	statusText := ""
-	if m.ollamaAvailable {
+	if m.aiAvailable {
 		statusText = SuccessStyle.Render("● 接続中")
 	} else {
 		statusText = ErrorStyle.Render("● 未接続")
 	}
 
 	// モデル情報
-	modelText := ""
-	if len(m.availableModels) > 0 {
-		modelText = fmt.Sprintf("Model: %s", m.aiService.GetModel())
-		if len(m.availableModels) > 1 {
-			modelText += CommentStyle.Render(fmt.Sprintf(" (Tab: %d個利用可能)", len(m.availableModels)))
-		}
+	modelText := fmt.Sprintf("Model: %s", m.aiService.GetModel())
+	if len(m.aiProviderModels) > 1 {
+		modelText = fmt.Sprintf("Model: %s / %s", m.aiService.GetProvider(), m.aiService.GetModel())
+	}
+	if len(m.availableModels) > 1 {
+		modelText += CommentStyle.Render(fmt.Sprintf(" (Tab: %d個利用可能)", len(m.availableModels)))
+	}
+
+	// ツールを使った調査
+	if m.aiAgentMode {
+		modelText += "  " + SuccessStyle.Render("ツール: ON") + CommentStyle.Render(" (T: 切替)")
 	} else {
-		modelText = fmt.Sprintf("Model: %s", m.aiService.GetModel())
+		modelText += "  " + CommentStyle.Render("ツール: OFF (T: 切替)")
 	}
 
-	return fmt.Sprintf("AI Assistant  %s\n%s", statusText, modelText)
+	header := fmt.Sprintf("AI Assistant  %s\n%s", statusText, modelText) + m.renderAIProviderModels()
+
+	// ログで検知した問題
+	if len(m.logIssues) > 0 {
+		header += "\n\n" + WarningStyle.Render(fmt.Sprintf("ログで検知した問題（%d件）:", len(m.logIssues)))
+		for i, issue := range m.logIssues {
+			if i >= 5 {
+				header += "\n" + CommentStyle.Render(fmt.Sprintf("  ... 他%d件", len(m.logIssues)-i))
+				break
+			}
+			header += fmt.Sprintf("\n  %s %s", issue.Time.Format("15:04"), formatLogIssue(issue))
+		}
+	}
+	return header
+}
+
+// renderAIProviderModels lists the models of each provider when more than one provider is configured
+func (m Model) renderAIProviderModels() string {
+	if len(m.aiProviderModels) <= 1 {
+		return ""
+	}
+	var b strings.Builder
+	for _, provider := range m.aiProviderModels {
+		b.WriteString("\n  " + provider.Provider + ": ")
+		if provider.Err != nil {
+			b.WriteString(ErrorStyle.Render("接続できません"))
+			continue
+		}
+		if len(provider.Models) == 0 {
+			b.WriteString(CommentStyle.Render("モデルがありません"))
+			continue
+		}
+		names := make([]string, len(provider.Models))
+		for i, model := range provider.Models {
+			names[i] = model
+			if provider.Provider == m.aiService.GetProvider() && model == m.aiService.GetModel() {
+				names[i] = SuccessStyle.Render(model)
+			}
+		}
+		b.WriteString(strings.Join(names, ", "))
+	}
+	return b.String()
 }
 
 // renderServiceDetail renders service detail
@@ -337,6 +412,14 @@ func (m Model) renderServiceDetail(serviceName string) string {
 		return monitor.CheckMySQL()  // 🆕 追加
 	case "Redis":
 		return monitor.CheckRedis()  // 🆕 追加
+	case "MongoDB":
+		return monitor.CheckMongoDB()
+	case "RabbitMQ":
+		return monitor.CheckRabbitMQ()
+	case "Kafka":
+		return monitor.CheckKafka()
+	case "Elasticsearch":
+		return monitor.CheckElasticsearch()
 	case "Docker":
 		return monitor.CheckDocker()
 	case "Node.js":
@@ -344,6 +427,9 @@ func (m Model) renderServiceDetail(serviceName string) string {
 	case "Python":
 		return monitor.CheckPython()
 	default:
+		if isRuntimeMenu(serviceName) {
+			return monitor.CheckRuntime(serviceName)
+		}
 		return serviceName + " の詳細情報"
 	}
 }
@@ -496,6 +582,16 @@ func (m Model) renderFooter() string {
 		return HelpStyle.Render("Y: はい | N: いいえ")
 	}
 
+	// 起動するコマンドの入力中
+	if m.launchEditing {
+		return m.renderLaunchPrompt()
+	}
+
+	// AIへの質問の入力中
+	if m.aiChatEditing {
+		return m.renderAIChatPrompt()
+	}
+
 	// === 左パネル（メニュー）操作中 ===
 	// ここでは「グラフ表示」が可能です
 	if m.focusedPanel == "left" {
@@ -550,28 +646,65 @@ func (m Model) renderFooter() string {
 			}
 
 			dockerCommon := "Space: トグル | " + startStopText + " | r: 再起動 | d: 削除"
+			if m.selectedContainerID() != "" {
+				// コンテナ選択時のみ設定タブとdocker runの出力
+				dockerCommon += " | i: 設定 | D/E: docker run（E: シークレットを含む）"
+			}
+			if m.rightPanelCursor < len(m.rightPanelItems) && m.rightPanelItems[m.rightPanelCursor].Type == "service" {
+				// コンテナ未作成のComposeサービス
+				return HelpStyle.Render(navHelp + "s/u: 起動 | B: ビルド | L: ログ")
+			}
+			if project, _ := m.getSelectedComposeService(); project != "" {
+				// Composeコンテナ（サービス単位の操作あり）
+				return HelpStyle.Render(navHelp + dockerCommon + " | b: リビルド | L: ログ | o: VSCode | t: シェル | u/R/B: サービス起動/再起動/ビルド | +/-: スケール | S: サービスログ" + m.logMarkHelp())
+			}
 			if isCompose {
 				// Composeコンテナ
-				return HelpStyle.Render(navHelp + dockerCommon + " | b: リビルド | c: クリーン | L: ログ | o: VSCode")
+				if m.rightPanelCursor < len(m.rightPanelItems) && m.rightPanelItems[m.rightPanelCursor].Type == "project" {
+					// プロジェクト全体（L で全サービスのログを統合表示）
+					return HelpStyle.Render(navHelp + dockerCommon + " | b: リビルド | c: クリーン | L: 全サービスのログ | o: VSCode | t: シェル" + m.logMarkHelp())
+				}
+				return HelpStyle.Render(navHelp + dockerCommon + " | b: リビルド | c: クリーン | L: ログ | o: VSCode | t: シェル" + m.logMarkHelp())
 			} else {
 				// 単体コンテナ
-				return HelpStyle.Render(navHelp + dockerCommon + " | c: クリーン | L: ログ | o: VSCode")
+				return HelpStyle.Render(navHelp + dockerCommon + " | c: クリーン | L: ログ | o: VSCode | t: シェル" + m.logMarkHelp())
 			}
 
+		} else if selectedItem.Name == "Docker Volumes" || selectedItem.Name == "Docker Networks" {
+			return HelpStyle.Render(navHelp + "d: 削除 | p: 未使用をまとめて削除")
+
+		} else if selectedItem.Name == "Docker Images" {
+			return HelpStyle.Render(navHelp + fmt.Sprintf("d: 削除 | p: %d日以上未使用を削除 | c: ダングリング削除 | b: ビルドキャッシュ削除", monitor.UnusedImageDays))
+
 		} else if selectedItem.Name == "PostgreSQL" {
 			return HelpStyle.Render(navHelp + "d: 削除 | v: VACUUM | a: ANALYZE")
 
 		} else if selectedItem.Name == "Node.js" {
-			return HelpStyle.Render(navHelp + "x: 停止 | X: 強制停止 | L: ログ | o: VSCode")
+			return HelpStyle.Render(navHelp + "x: 停止 | X: 強制停止 | r: 再起動 | n: 起動 | L: ログ | o: VSCode | t: シェル | e: エディタ内部の表示切替" + m.logMarkHelp())
 
 		} else if selectedItem.Name == "MySQL" {
-			return HelpStyle.Render(navHelp + "d: 削除 | o: 最適化")
+			return HelpStyle.Render(navHelp + "d: 削除 | o: 最適化 | x: クエリ停止")
 
 		} else if selectedItem.Name == "Redis" {
-			return HelpStyle.Render(navHelp + "f: FLUSHDB")
+			if m.redisPatternEditing {
+				return HelpStyle.Render("パターン入力中 | Enter: 検索 | Esc: キャンセル")
+			}
+			if m.redisBrowseDB != "" {
+				return HelpStyle.Render(navHelp + "Enter: キー一覧 | /: パターン | d: キー削除 | f: FLUSHDB | Esc: 閉じる")
+			}
+			return HelpStyle.Render(navHelp + "Enter: キー一覧 | f: FLUSHDB")
 
-		} else if selectedItem.Name == "Python" {
-			return HelpStyle.Render(navHelp + "x: 停止 | X: 強制停止 | L: ログ | o: VSCode")
+		} else if selectedItem.Name == "MongoDB" {
+			return HelpStyle.Render(navHelp + "d: 削除")
+
+		} else if selectedItem.Name == "RabbitMQ" {
+			return HelpStyle.Render(navHelp + "p: パージ")
+
+		} else if selectedItem.Name == "Elasticsearch" {
+			return HelpStyle.Render(navHelp + "d: インデックス削除 | c: キャッシュクリア")
+
+		} else if selectedItem.Name == "Python" || isRuntimeMenu(selectedItem.Name) {
+			return HelpStyle.Render(navHelp + "x: 停止 | X: 強制停止 | r: 再起動 | n: 起動 | L: ログ | o: VSCode | t: シェル" + m.logMarkHelp())
 
 		} else if selectedItem.Name == "ポート一覧" || selectedItem.Name == "Top 10 プロセス" {
 			return HelpStyle.Render(navHelp + "x: 停止 | X: 強制停止")
@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/llm"
+)
+
+// aiChatVisibleMessages は表示する会話のメッセージ数（古いものは省略）
+const aiChatVisibleMessages = 6
+
+// renderAIConversation renders the earlier messages of the conversation
+// 最新の回答は aiResponse として続けて表示するため含めない
+func (m Model) renderAIConversation() string {
+	// ツールの呼び出しと結果は表示しない（最新の回答の分は renderAIToolTrace で表示）
+	var turns []llm.Message
+	for _, msg := range aiConversationTurns(m.aiHistory) {
+		if msg.Role == "tool" || len(msg.ToolCalls) > 0 {
+			continue
+		}
+		turns = append(turns, msg)
+	}
+	if n := len(turns); n > 0 && turns[n-1].Role == "assistant" && m.aiResponse != "" {
+		turns = turns[:n-1]
+	}
+	if len(turns) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	if len(turns) > aiChatVisibleMessages {
+		b.WriteString(CommentStyle.Render(fmt.Sprintf("（以前のやりとり %d件は省略）", len(turns)-aiChatVisibleMessages)))
+		b.WriteString("\n\n")
+		turns = turns[len(turns)-aiChatVisibleMessages:]
+	}
+	for _, msg := range turns {
+		switch msg.Role {
+		case "user":
+			b.WriteString(InfoStyle.Render("あなた: " + msg.Content))
+		case "system":
+			b.WriteString(CommentStyle.Render(msg.Content))
+		default:
+			b.WriteString(msg.Content)
+		}
+		b.WriteString("\n\n")
+	}
+	return b.String()
+}
+
+// renderAIToolTrace renders the tools called for the latest answer
+func (m Model) renderAIToolTrace() string {
+	if len(m.aiToolTrace) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	for _, trace := range m.aiToolTrace {
+		b.WriteString(InfoStyle.Render(fmt.Sprintf("🔧 %s(%s)", trace.Name, trace.Args)))
+		if trace.Err != "" {
+			b.WriteString(ErrorStyle.Render(" ✗ " + trace.Err))
+		} else {
+			b.WriteString(CommentStyle.Render(fmt.Sprintf(" → %s (%.1f秒)", trace.Summary, trace.Duration.Seconds())))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// renderAIChatPrompt renders the question being typed in the footer
+func (m Model) renderAIChatPrompt() string {
+	return HelpStyle.Render(fmt.Sprintf("質問 > %s█ | Enter: 送信 | Esc: キャンセル | /clear: リセット | /refresh: 最新の状態を反映 | /save [タイトル]: 保存", m.aiChatInput))
+}
@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/ai"
+)
+
+// renderAIPendingCmd renders the confirmation of the command suggested by the AI with its risk
+func (m Model) renderAIPendingCmd() string {
+	assessment := m.aiCmdAssessment
+	separator := "────────────────────────────────────────"
+
+	riskStyle := WarningStyle
+	switch assessment.Risk {
+	case ai.RiskSafe:
+		riskStyle = SuccessStyle
+	case ai.RiskDangerous:
+		riskStyle = ErrorStyle
+	}
+
+	var b strings.Builder
+	b.WriteString(WarningStyle.Render(fmt.Sprintf("%s\n🤖 AIがアクションを提案しています:\n\n  $ %s", separator, m.aiPendingCmd)))
+	b.WriteString("\n\n")
+	b.WriteString(riskStyle.Render("リスク: " + assessment.Risk.String()))
+	for _, reason := range assessment.Reasons {
+		b.WriteString("\n")
+		b.WriteString(riskStyle.Render("  ・" + reason))
+	}
+	if assessment.DryRun != "" {
+		b.WriteString("\n")
+		b.WriteString(InfoStyle.Render("ドライラン: $ " + assessment.DryRun))
+	}
+	b.WriteString("\n\n")
+
+	keys := "[Enter] 実行する    [Esc] キャンセル"
+	if assessment.Risk == ai.RiskDangerous {
+		b.WriteString(ErrorStyle.Render(fmt.Sprintf("実行するには %s と入力して Enter: %s█", aiCommandConfirmWord, m.aiCmdConfirmInput)))
+		b.WriteString("\n")
+	}
+	if assessment.DryRun != "" {
+		keys = "[Tab] ドライラン    " + keys
+	}
+	b.WriteString(WarningStyle.Render(keys + "\n" + separator))
+	return b.String()
+}
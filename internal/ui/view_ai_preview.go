@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/llm"
+)
+
+// renderAIPreview renders exactly what will be sent to the LLM
+func (m Model) renderAIPreview() string {
+	var b strings.Builder
+	b.WriteString(InfoStyle.Render("送信内容のプレビュー"))
+	b.WriteString(CommentStyle.Render(fmt.Sprintf("  (%s / %s)", m.aiService.GetProvider(), m.aiService.GetModel())))
+	b.WriteString("\n")
+
+	history := m.aiHistory
+	if len(history) == 0 {
+		if m.aiPreview == nil {
+			b.WriteString("\nシステム情報を収集しています...")
+			return b.String()
+		}
+		history = m.aiPreview.Conversation
+	}
+	request := m.aiService.PreviewRequest(history, m.aiAgentMode)
+
+	chars := 0
+	for _, msg := range request {
+		chars += len([]rune(msg.Content))
+	}
+	b.WriteString(fmt.Sprintf("メッセージ: %d件 / %d文字\n", len(request), chars))
+
+	switch {
+	case len(m.aiHistory) > 0:
+		b.WriteString(CommentStyle.Render("マスクした値: 会話の開始時にマスク済み"))
+	case m.aiPreview.Redactions.Total() > 0:
+		b.WriteString(WarningStyle.Render(fmt.Sprintf("マスクした値: %d件 (%s)", m.aiPreview.Redactions.Total(), m.aiPreview.Redactions)))
+	default:
+		b.WriteString(CommentStyle.Render("マスクした値: なし"))
+	}
+	b.WriteString("\n")
+	if len(m.aiHistory) > 0 {
+		b.WriteString(CommentStyle.Render("この後に [c] で入力した質問を追加して送ります"))
+		b.WriteString("\n")
+	}
+
+	for i, msg := range request {
+		b.WriteString("\n")
+		b.WriteString(InfoStyle.Render(fmt.Sprintf("── [%d] %s", i+1, aiPreviewRole(msg))))
+		b.WriteString("\n")
+		if msg.Content != "" {
+			b.WriteString(strings.TrimRight(msg.Content, "\n"))
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n")
+	if len(m.aiHistory) > 0 {
+		b.WriteString("[c] この内容に続けて質問    [P] 閉じる")
+	} else {
+		b.WriteString("[a] この内容で分析    [c] この内容で質問    [P] 閉じる")
+	}
+	b.WriteString(CommentStyle.Render("  (Ctrl+D/U: スクロール)"))
+	return b.String()
+}
+
+// aiPreviewRole describes the sender of a message in the preview
+func aiPreviewRole(msg llm.Message) string {
+	switch {
+	case msg.Role == "tool":
+		return "tool: " + msg.ToolName
+	case len(msg.ToolCalls) > 0:
+		names := make([]string, len(msg.ToolCalls))
+		for i, call := range msg.ToolCalls {
+			names[i] = call.Function.Name
+		}
+		return msg.Role + " (ツールの呼び出し: " + strings.Join(names, ", ") + ")"
+	}
+	return msg.Role
+}
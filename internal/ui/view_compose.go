@@ -0,0 +1,189 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
+)
+
+// renderComposeDependencyGraph renders depends_on, shared networks/volumes and healthchecks of a project
+func (m Model) renderComposeDependencyGraph(projectName string) string {
+	cache, exists := m.composeConfigCache[projectName]
+	if !exists {
+		return "\n\n  依存関係グラフ:\n    取得中..."
+	}
+	if cache.Config == nil {
+		return "\n\n  依存関係グラフ:\n    " + CommentStyle.Render(cache.Err)
+	}
+
+	config := cache.Config
+	var lines []string
+
+	for _, svc := range config.Services {
+		line := "    " + composeServiceStateText(projectName, svc, m.cachedContainers)
+
+		// 依存先と待ち合わせ条件
+		if len(svc.DependsOn) > 0 {
+			var deps []string
+			for _, dep := range svc.DependsOn {
+				deps = append(deps, fmt.Sprintf("%s (%s)", dep.Service, composeConditionText(dep.Condition)))
+			}
+			line += CommentStyle.Render(" ─▶ " + strings.Join(deps, ", "))
+		}
+
+		// 依存先が条件を満たしていないサービスを強調表示
+		if unhealthy := config.UnhealthyDependencies(svc.Name, m.cachedContainers); len(unhealthy) > 0 {
+			line += "\n      " + WarningStyle.Render("⚠ 依存先が不健全: "+strings.Join(unhealthy, ", "))
+		}
+
+		lines = append(lines, line)
+	}
+
+	details := "\n\n  依存関係グラフ:\n" + strings.Join(lines, "\n")
+	details += renderComposeSharedResources("共有ネットワーク", config.SharedResources(false))
+	details += renderComposeSharedResources("共有ボリューム", config.SharedResources(true))
+
+	return details
+}
+
+// renderComposeSharedResources renders resources shared by several services
+func renderComposeSharedResources(title string, resources map[string][]string) string {
+	if len(resources) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(resources))
+	for name := range resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	details := fmt.Sprintf("\n\n  %s:", title)
+	for _, name := range names {
+		details += fmt.Sprintf("\n    %s: %s", name, strings.Join(resources[name], ", "))
+	}
+	return details
+}
+
+// renderComposeServiceDetails renders details for a service that has no container yet
+func (m Model) renderComposeServiceDetails(projectName, serviceName string) string {
+	details := fmt.Sprintf(`
+────────────────────────────────────────────────────
+サービス詳細: %s
+────────────────────────────────────────────────────
+  プロジェクト: %s
+  ステータス: コンテナ未作成`,
+		serviceName,
+		projectName,
+	)
+
+	cache, exists := m.composeConfigCache[projectName]
+	if !exists || cache.Config == nil {
+		return details
+	}
+	svc := cache.Config.Service(serviceName)
+	if svc == nil {
+		return details
+	}
+
+	if svc.Image != "" {
+		details += fmt.Sprintf("\n  イメージ: %s", svc.Image)
+	}
+	if len(svc.Profiles) > 0 {
+		details += fmt.Sprintf("\n  プロファイル: %s", strings.Join(svc.Profiles, ", "))
+	}
+
+	return details + m.renderComposeServiceDependencies(projectName, serviceName)
+}
+
+// renderComposeServiceDependencies renders dependencies of a single compose service
+func (m Model) renderComposeServiceDependencies(projectName, serviceName string) string {
+	cache, exists := m.composeConfigCache[projectName]
+	if !exists || cache.Config == nil {
+		return ""
+	}
+	svc := cache.Config.Service(serviceName)
+	if svc == nil {
+		return ""
+	}
+
+	details := "\n\n  依存関係:"
+	if len(svc.DependsOn) == 0 {
+		details += "\n    依存先なし"
+	}
+	for _, dep := range svc.DependsOn {
+		depSvc := cache.Config.Service(dep.Service)
+		if depSvc == nil {
+			details += fmt.Sprintf("\n    %s (%s)", dep.Service, composeConditionText(dep.Condition))
+			continue
+		}
+		details += fmt.Sprintf("\n    %s %s", composeServiceStateText(projectName, *depSvc, m.cachedContainers),
+			CommentStyle.Render("("+composeConditionText(dep.Condition)+")"))
+	}
+
+	if unhealthy := cache.Config.UnhealthyDependencies(serviceName, m.cachedContainers); len(unhealthy) > 0 {
+		details += "\n    " + WarningStyle.Render("⚠ 依存先が不健全: "+strings.Join(unhealthy, ", "))
+	}
+
+	if svc.HasHealthcheck {
+		details += "\n    ヘルスチェック: あり"
+	}
+	if len(svc.Networks) > 0 {
+		details += fmt.Sprintf("\n    ネットワーク: %s", strings.Join(svc.Networks, ", "))
+	}
+	if len(svc.Volumes) > 0 {
+		details += fmt.Sprintf("\n    ボリューム: %s", strings.Join(svc.Volumes, ", "))
+	}
+
+	return details
+}
+
+// composeServiceStateText renders a service name with its running and health state
+func composeServiceStateText(projectName string, svc monitor.ComposeService, containers []monitor.DockerContainer) string {
+	running, total, health := monitor.ComposeServiceState(projectName, svc.Name, containers)
+
+	icon := "○"
+	style := CommentStyle
+	switch {
+	case total > 0 && running == total:
+		icon = "●"
+		style = SuccessStyle
+	case running > 0:
+		icon = "◐"
+		style = WarningStyle
+	}
+
+	text := style.Render(fmt.Sprintf("%s %s", icon, svc.Name))
+	if total > 1 {
+		text += CommentStyle.Render(fmt.Sprintf(" ×%d", total))
+	}
+
+	switch health {
+	case "healthy":
+		text += " " + SuccessStyle.Render("[healthy]")
+	case "unhealthy":
+		text += " " + ErrorStyle.Render("[unhealthy]")
+	case "starting":
+		text += " " + WarningStyle.Render("[starting]")
+	default:
+		if svc.HasHealthcheck {
+			text += " " + CommentStyle.Render("[ヘルスチェックあり]")
+		}
+	}
+
+	return text
+}
+
+// composeConditionText converts a depends_on condition to a short label
+func composeConditionText(condition string) string {
+	switch condition {
+	case "service_healthy":
+		return "healthy待ち"
+	case "service_completed_successfully":
+		return "完了待ち"
+	default:
+		return "起動待ち"
+	}
+}
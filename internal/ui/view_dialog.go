@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -11,7 +12,25 @@ import (
 func (m Model) renderWithConfirmDialog(mainView string) string {
 	var dialogContent string
 
-	if m.confirmType == "python_process" {
+	if m.confirmType == "managed_process" {
+		// devmon run で起動したプロセスの再起動
+		process, ok := monitor.LookupManagedProcess(m.confirmTarget)
+		if !ok {
+			return mainView
+		}
+
+		dialogContent = fmt.Sprintf(`プロセスを 再起動 しますか？
+
+停止してから同じコマンドで起動し直します
+
+名前: %s
+ディレクトリ: %s
+コマンド: %s
+PID: %d
+
+[Y] はい
+[N] いいえ`, process.Name, process.Dir, truncateCommand(process.CommandLine(), 60), process.PID)
+	} else if m.confirmType == "python_process" {
 		// Pythonプロセスの操作
 		process := m.getSelectedPythonProcess()
 		if process == nil {
@@ -65,6 +84,34 @@ PID: %s
 
 [Y] はい
 [N] いいえ`, actionJP, actionDetail, process.ProjectName, process.PID)
+	} else if m.confirmType == "runtime_process" {
+		// Go/Ruby/JVM/Deno/Bun/PHPプロセスの操作
+		process := m.getSelectedRuntimeProcess()
+		if process == nil {
+			return mainView
+		}
+
+		actionJP := ""
+		actionDetail := ""
+		switch m.confirmAction {
+		case "kill":
+			actionJP = "停止"
+			actionDetail = "このプロセスを停止します"
+		case "force_kill":
+			actionJP = "強制停止"
+			actionDetail = "⚠ このプロセスを強制停止します（SIGKILL）"
+		}
+
+		dialogContent = fmt.Sprintf(`プロセスを %s しますか？
+
+%s
+
+プロジェクト: %s
+種別: %s
+PID: %s
+
+[Y] はい
+[N] いいえ`, actionJP, actionDetail, process.ProjectName, process.Framework, process.PID)
 	} else if m.confirmType == "mysql_database" {
 		// MySQLデータベースの操作
 		actionJP := ""
@@ -86,6 +133,93 @@ PID: %s
 
 [Y] はい
 [N] いいえ`, actionJP, actionDetail, m.confirmTarget)
+	} else if m.confirmType == "mongo_database" {
+		// MongoDBデータベースの操作
+		database := m.getSelectedMongoDatabase()
+		if database == nil {
+			return mainView
+		}
+
+		dialogContent = fmt.Sprintf(`データベースを削除しますか？
+
+⚠ dropDatabase を実行します（データは復元できません）
+
+データベース名: %s
+コレクション数: %d個
+サイズ: %s
+
+[Y] はい
+[N] いいえ`, database.Name, database.Collections, database.Size)
+	} else if m.confirmType == "es_index" {
+		// Elasticsearchインデックスの操作
+		index := m.getSelectedElasticsearchIndex()
+		if index == nil {
+			return mainView
+		}
+
+		actionJP := ""
+		actionDetail := ""
+		switch m.confirmAction {
+		case "delete_index":
+			actionJP = "削除"
+			actionDetail = "⚠ このインデックスを削除します（データは復元できません）"
+		case "clear_cache":
+			actionJP = "キャッシュクリア"
+			actionDetail = "このインデックスのクエリ・フィールドデータキャッシュをクリアします"
+		}
+
+		dialogContent = fmt.Sprintf(`インデックスを %s しますか？
+
+%s
+
+インデックス: %s
+ドキュメント数: %s
+サイズ: %s
+
+[Y] はい
+[N] いいえ`, actionJP, actionDetail, index.Name, index.DocsCount, index.StoreSize)
+	} else if m.confirmType == "rabbitmq_queue" {
+		// RabbitMQキューのパージ
+		queue := m.getSelectedRabbitMQQueue()
+		if queue == nil {
+			return mainView
+		}
+
+		dialogContent = fmt.Sprintf(`キューをパージしますか？
+
+⚠ Ready状態のメッセージ %d件 を削除します（復元できません）
+  未ACKのメッセージ（%d件）は削除されません
+
+vhost: %s
+キュー: %s
+
+[Y] はい
+[N] いいえ`, queue.MessagesReady, queue.MessagesUnacked, queue.VHost, queue.Name)
+	} else if m.confirmType == "mysql_process" {
+		// MySQL接続のクエリ停止
+		process := m.getSelectedMySQLProcess()
+		if process == nil {
+			return mainView
+		}
+
+		query := process.Info
+		if len([]rune(query)) > 60 {
+			query = string([]rune(query)[:60]) + "..."
+		}
+		if query == "" {
+			query = "なし"
+		}
+
+		dialogContent = fmt.Sprintf(`実行中のクエリを停止しますか？
+
+KILL QUERY を実行します（接続は維持されます）
+
+接続ID: %s
+ユーザー: %s@%s
+クエリ: %s
+
+[Y] はい
+[N] いいえ`, process.ID, process.User, process.Host, query)
 	} else if m.confirmType == "redis_database" {
 		// Redisデータベースの操作
 		actionJP := ""
@@ -104,6 +238,23 @@ PID: %s
 
 [Y] はい
 [N] いいえ`, actionJP, actionDetail, m.confirmTarget)
+	} else if m.confirmType == "redis_key" {
+		// Redisキーの削除
+		key := m.getSelectedRedisKey()
+		if key == nil {
+			return mainView
+		}
+
+		dialogContent = fmt.Sprintf(`キーを削除しますか？
+
+⚠ DEL を実行します（データは復元できません）
+
+データベース: %s
+キー: %s
+型: %s
+
+[Y] はい
+[N] いいえ`, m.redisBrowseDB, key.Name, key.Type)
 	} else if m.confirmType == "port" {
 		// ポートの操作（プロセス停止）
 		port := m.getSelectedPort()
@@ -201,6 +352,18 @@ CPU: %.1f%%
 		case "clean_dangling":
 			actionJP = "ダングリングイメージを削除"
 			actionDetail = "⚠ 使用されていないイメージを削除します"
+		case "prune_volumes":
+			actionJP = "未使用のボリュームを削除"
+			actionDetail = "⚠ どのコンテナからも使われていないボリュームとそのデータを削除します"
+		case "prune_networks":
+			actionJP = "未使用のネットワークを削除"
+			actionDetail = "コンテナが接続されていないネットワークを削除します"
+		case "prune_unused_images":
+			actionJP = "未使用のイメージを削除"
+			actionDetail = fmt.Sprintf("⚠ コンテナがなく%d日以上使われていないイメージを削除します", monitor.UnusedImageDays)
+		case "prune_build_cache":
+			actionJP = "ビルドキャッシュを削除"
+			actionDetail = "次回のビルドは時間がかかるようになります"
 		}
 
 		dialogContent = fmt.Sprintf(`%s しますか？
@@ -209,6 +372,36 @@ CPU: %.1f%%
 
 [Y] はい
 [N] いいえ`, actionJP, actionDetail)
+	} else if m.confirmType == "docker_volume" || m.confirmType == "docker_network" || m.confirmType == "docker_image" {
+		// ボリューム・ネットワーク・イメージの削除
+		kindJP := ""
+		actionDetail := ""
+		switch m.confirmType {
+		case "docker_volume":
+			kindJP = "ボリューム"
+			actionDetail = "⚠ ボリューム内のデータは復元できません"
+			if volume := m.getSelectedDockerVolume(); volume != nil && !volume.Orphaned() {
+				actionDetail += fmt.Sprintf("\n⚠ 使用中のコンテナ: %s", strings.Join(volume.UsedBy, ", "))
+			}
+		case "docker_network":
+			kindJP = "ネットワーク"
+			actionDetail = "接続中のコンテナがある場合は削除できません"
+		case "docker_image":
+			kindJP = "イメージ"
+			actionDetail = "コンテナが使用中の場合は削除できません"
+			if image := m.getSelectedDockerImage(); image != nil {
+				actionDetail = fmt.Sprintf("%s (%s)\n%s", dockerImageTitle(image), image.Size, actionDetail)
+			}
+		}
+
+		dialogContent = fmt.Sprintf(`%sを削除しますか？
+
+%s
+
+対象: %s
+
+[Y] はい
+[N] いいえ`, kindJP, actionDetail, m.confirmTarget)
 	} else if m.confirmType == "project" {
 		// プロジェクト全体の操作
 		actionJP := ""
@@ -239,6 +432,41 @@ CPU: %.1f%%
 
 [Y] はい
 [N] いいえ`, actionJP, actionDetail, m.confirmTarget)
+	} else if m.confirmType == "service" {
+		// Composeサービス単位の操作（"プロジェクト:サービス[:レプリカ数]"）
+		parts := strings.Split(m.confirmTarget, ":")
+		if len(parts) < 2 {
+			return mainView
+		}
+
+		actionJP := ""
+		actionDetail := ""
+		switch m.confirmAction {
+		case "up_service":
+			actionJP = "起動"
+			actionDetail = "このサービスを起動します（依存先も起動されます）"
+		case "restart_service":
+			actionJP = "再起動"
+			actionDetail = "このサービスの全レプリカを再起動します"
+		case "build_service":
+			actionJP = "ビルド"
+			actionDetail = "このサービスのイメージをビルドします（コンテナは再作成されません）"
+		case "scale_service":
+			if len(parts) == 3 {
+				actionJP = fmt.Sprintf("%s台にスケール", parts[2])
+				actionDetail = "レプリカ数を変更します（既存のコンテナは再作成されません）"
+			}
+		}
+
+		dialogContent = fmt.Sprintf(`サービスを %s しますか？
+
+%s
+
+プロジェクト: %s (Compose)
+サービス: %s
+
+[Y] はい
+[N] いいえ`, actionJP, actionDetail, parts[0], parts[1])
 	} else {
 		// 個別コンテナの操作
 		container := m.getSelectedContainer()
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
@@ -13,7 +14,7 @@ func (m Model) renderDockerContent() string {
 	containers := m.cachedContainers
 
 	// キャッシュがない場合はローディング表示（Viewではブロッキング処理を行わない）
-	if len(containers) == 0 {
+	if len(containers) == 0 && len(m.cachedComposeProjects) == 0 {
 		return "データ取得中... (Docker)"
 	}
 
@@ -63,6 +64,10 @@ func (m Model) renderDockerContent() string {
 			// プロジェクトの詳細情報を取得
 			details := m.renderProjectDetails(selectedItem.Name)
 			return summary + containerList + "\n" + details
+		} else if selectedItem.Type == "service" {
+			// コンテナ未作成のサービスの詳細情報
+			details := m.renderComposeServiceDetails(selectedItem.ProjectName, selectedItem.Name)
+			return summary + containerList + "\n" + details
 		}
 	}
 
@@ -73,7 +78,8 @@ func (m Model) renderDockerContent() string {
 func (m Model) renderProjectDetails(projectName string) string {
 	// キャッシュから取得（Viewではブロッキング処理を行わない）
 	containers := m.cachedContainers
-	if len(containers) == 0 {
+	project := m.getComposeProject(projectName)
+	if len(containers) == 0 && project == nil {
 		return "データ取得中..."
 	}
 
@@ -85,7 +91,7 @@ func (m Model) renderProjectDetails(projectName string) string {
 		}
 	}
 
-	if len(projectContainers) == 0 {
+	if len(projectContainers) == 0 && project == nil {
 		return ""
 	}
 
@@ -118,12 +124,73 @@ func (m Model) renderProjectDetails(projectName string) string {
 		}
 		details += fmt.Sprintf("\n    %s %s (%s)", statusIcon, c.ComposeService, c.Image)
 	}
+	if len(projectContainers) == 0 {
+		details += "\n    (コンテナなし - 起動すると作成されます)"
+	}
+
+	// 起動時に使うCompose設定を表示
+	if project != nil {
+		details += renderComposeProjectConfig(project)
+	}
+
+	// サービス間の依存関係を表示
+	details += m.renderComposeDependencyGraph(projectName)
 
 	return details
 }
 
+// renderComposeProjectConfig renders compose files, env files and profiles of a project
+func renderComposeProjectConfig(project *monitor.ComposeProject) string {
+	details := fmt.Sprintf(`
+
+  Compose設定:
+    作業ディレクトリ: %s`, project.WorkingDir)
+
+	if len(project.ConfigFiles) == 0 {
+		details += "\n    Composeファイル: " + ErrorStyle.Render("見つかりません")
+	}
+	for i, f := range project.ConfigFiles {
+		label := "Composeファイル:"
+		if i > 0 {
+			label = "                "
+		}
+		details += fmt.Sprintf("\n    %s %s", label, composeDisplayPath(project.WorkingDir, f))
+	}
+
+	for i, f := range project.EnvFiles {
+		label := "環境ファイル:"
+		if i > 0 {
+			label = "             "
+		}
+		details += fmt.Sprintf("\n    %s %s", label, composeDisplayPath(project.WorkingDir, f))
+	}
+
+	if len(project.Profiles) > 0 {
+		details += fmt.Sprintf("\n    プロファイル: %s", strings.Join(project.Profiles, ", "))
+	}
+
+	if !project.LastSeen.IsZero() {
+		details += fmt.Sprintf("\n    最終確認: %s", project.LastSeen.Local().Format("2006-01-02 15:04"))
+	}
+
+	return details
+}
+
+// composeDisplayPath shortens a path inside the working directory
+func composeDisplayPath(workDir, path string) string {
+	if rel, err := filepath.Rel(workDir, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+	return path
+}
+
 // renderContainerDetails renders detailed information for a selected container
 func (m Model) renderContainerDetails(container *monitor.DockerContainer) string {
+	// 設定タブ（環境変数・リソース制限など）
+	if m.showContainerConfig {
+		return m.renderContainerConfig(container)
+	}
+
 	// キャッシュから取得
 	var stats monitor.DockerStats
 	var imageSize string
@@ -150,7 +217,7 @@ func (m Model) renderContainerDetails(container *monitor.DockerContainer) string
 ────────────────────────────────────────────────────
 コンテナ詳細: %s
 ────────────────────────────────────────────────────
-  ステータス: %s
+%s
 
   イメージ情報:
     名前: %s
@@ -160,7 +227,7 @@ func (m Model) renderContainerDetails(container *monitor.DockerContainer) string
     CPU使用率: %s
     メモリ使用量: %s`,
 		container.Name,
-		container.Status,
+		renderContainerState(container),
 		container.Image,
 		imageSize,
 		cpuPerc,
@@ -180,6 +247,9 @@ func (m Model) renderContainerDetails(container *monitor.DockerContainer) string
 			details += fmt.Sprintf(`
     プロジェクトディレクトリ: %s`, container.ProjectDir)
 		}
+
+		// サービスの依存関係を追加
+		details += m.renderComposeServiceDependencies(container.ComposeProject, container.ComposeService)
 	}
 
 	// ポート情報とURLを追加
@@ -200,7 +270,7 @@ func (m Model) renderSelectableContent(baseContent string) string {
 
 	// キャッシュから取得（Viewではブロッキング処理を行わない）
 	containers := m.cachedContainers
-	if len(containers) == 0 {
+	if len(containers) == 0 && len(m.cachedComposeProjects) == 0 {
 		return "データ取得中..."
 	}
 
@@ -238,7 +308,10 @@ func (m Model) renderSelectableContent(baseContent string) string {
 
 			statusText := fmt.Sprintf("[%d/%d稼働]", runningCount, totalCount)
 			statusStyle := CommentStyle
-			if runningCount > 0 && runningCount == totalCount {
+			if totalCount == 0 {
+				// コンテナが削除済みの記憶しているプロジェクト
+				statusText = "[停止中]"
+			} else if runningCount > 0 && runningCount == totalCount {
 				statusStyle = SuccessStyle
 			} else if runningCount > 0 {
 				statusStyle = WarningStyle
@@ -254,6 +327,18 @@ func (m Model) renderSelectableContent(baseContent string) string {
 			} else {
 				line = "  " + statusStyle.Render(projectText)
 			}
+		} else if item.Type == "service" {
+			// コンテナ未作成のサービスを表示（親プロジェクトが展開されている場合のみ）
+			shouldDisplay = m.isItemVisible(i)
+			if shouldDisplay {
+				serviceText := fmt.Sprintf("    ○ %s", item.Name)
+				noteText := "  (未作成)"
+				if i == m.rightPanelCursor {
+					line = HighlightStyle.Render("> "+serviceText) + CommentStyle.Render(noteText)
+				} else {
+					line = "  " + CommentStyle.Render(serviceText+noteText)
+				}
+			}
 		} else {
 			// コンテナを表示
 			container := containerMap[item.ContainerID]
@@ -282,20 +367,15 @@ func (m Model) renderSelectableContent(baseContent string) string {
 						indent = "    "
 					}
 
-					// ステータスアイコン
-					statusIcon := "●"
-					statusColor := ErrorStyle
-					if container.Status == "running" {
-						statusIcon = "●"
-						statusColor = SuccessStyle
-					} else {
-						statusIcon = "○"
-						statusColor = CommentStyle
-					}
+					// ステータスアイコン（ヘルスチェック・再起動ループ・異常終了で色分け）
+					statusIcon, statusColor := containerStatusIcon(container)
 
 					// コンテナ名とイメージ
 					containerText := fmt.Sprintf("%s%s %s", indent, statusIcon, container.Name)
-					imageText := fmt.Sprintf("  (%s)", container.Image)
+					imageText := fmt.Sprintf("  (%s)", container.Image) + containerListTags(container)
+					if m.isContainerLogMarked(container.ID) {
+						imageText += " [統合ログ]"
+					}
 
 					// カーソル位置なら強調表示
 					if i == m.rightPanelCursor {
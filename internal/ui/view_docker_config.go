@@ -0,0 +1,168 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
+)
+
+// renderContainerConfig renders the config tab (env, command, limits, networks, ports) of a container
+func (m Model) renderContainerConfig(container *monitor.DockerContainer) string {
+	header := fmt.Sprintf(`
+────────────────────────────────────────────────────
+コンテナ設定: %s  %s
+────────────────────────────────────────────────────`, container.Name, CommentStyle.Render("(i: 概要に戻る | D: docker run をコピー | E: シークレットの値を含めてコピー)"))
+
+	config := m.cachedContainerConfig
+	if m.containerConfigID != container.ID || (config == nil && m.containerConfigErr == "") {
+		return header + "\n  取得中..."
+	}
+	if config == nil {
+		return header + "\n  " + ErrorStyle.Render("✗ "+m.containerConfigErr)
+	}
+
+	lines := []string{header}
+
+	// コマンド
+	lines = append(lines,
+		"  イメージ: "+config.Image,
+		"  エントリーポイント: "+commandText(config.Entrypoint),
+		"  コマンド: "+commandText(config.Cmd),
+		"  作業ディレクトリ: "+valueOrUnknown(config.WorkingDir),
+	)
+	if config.User != "" {
+		lines = append(lines, "  ユーザー: "+config.User)
+	}
+
+	// 再起動ポリシー
+	restart := config.RestartPolicy
+	if config.RestartPolicy == "on-failure" && config.RestartMaxRetries > 0 {
+		restart += fmt.Sprintf("（最大%d回）", config.RestartMaxRetries)
+	}
+	if container.State.RestartCount > 0 {
+		restart += fmt.Sprintf("  現在の再起動回数: %d回", container.State.RestartCount)
+	}
+	lines = append(lines, "  再起動ポリシー: "+restart)
+	if config.Privileged {
+		lines = append(lines, "  "+WarningStyle.Render("⚠ 特権モード（--privileged）で実行されています"))
+	}
+
+	// リソース制限と現在の使用量
+	var stats monitor.DockerStats
+	if cache, exists := m.containerStatsCache[container.ID]; exists {
+		stats = cache.Stats
+	}
+	memUsed, _, _ := strings.Cut(stats.MemUsage, " / ")
+	memLimit := monitor.FormatMemoryLimit(config.MemoryLimit)
+	if memLimit == "" {
+		memLimit = "無制限"
+	}
+	cpuLimit := "無制限"
+	if cpus := config.CPULimit(); cpus > 0 {
+		cpuLimit = fmt.Sprintf("%gコア", cpus)
+	}
+	if config.CpusetCpus != "" {
+		cpuLimit += fmt.Sprintf("（CPU %s に固定）", config.CpusetCpus)
+	}
+	lines = append(lines,
+		"",
+		"  リソース制限:",
+		fmt.Sprintf("    メモリ: %s（使用中: %s）", memLimit, valueOrUnknown(strings.TrimSpace(memUsed))),
+		fmt.Sprintf("    CPU: %s（使用率: %s）", cpuLimit, valueOrUnknown(stats.CPUPerc)),
+	)
+	if reservation := monitor.FormatMemoryLimit(config.MemoryReservation); reservation != "" {
+		lines = append(lines, "    メモリ予約: "+reservation)
+	}
+	if container.State.OOMKilled && config.MemoryLimit > 0 {
+		lines = append(lines, "    "+ErrorStyle.Render("⚠ メモリ上限に達してOOMKilledされています"))
+	}
+
+	// ネットワークと公開ポート
+	lines = append(lines, "", fmt.Sprintf("  ネットワーク（%s）:", valueOrUnknown(config.NetworkMode)))
+	if len(config.Networks) == 0 {
+		lines = append(lines, "    なし")
+	}
+	for _, network := range config.Networks {
+		line := "    - " + network.Name
+		if network.IPAddress != "" {
+			line += "  " + network.IPAddress
+		}
+		if len(network.Aliases) > 0 {
+			line += CommentStyle.Render("  エイリアス: " + strings.Join(network.Aliases, ", "))
+		}
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, "", "  公開ポート:")
+	if len(config.Ports) == 0 {
+		lines = append(lines, "    なし")
+	}
+	for _, port := range config.Ports {
+		host := port.HostPort
+		if port.HostIP != "" {
+			host = port.HostIP + ":" + port.HostPort
+		}
+		lines = append(lines, fmt.Sprintf("    - %s -> %s", host, port.ContainerPort))
+	}
+
+	if len(config.Mounts) > 0 {
+		lines = append(lines, "", "  マウント:")
+		for _, mount := range config.Mounts {
+			source := mount.Source
+			if mount.Type == "volume" && mount.Name != "" {
+				source = mount.Name
+			}
+			line := fmt.Sprintf("    - [%s] %s -> %s", mount.Type, source, mount.Destination)
+			if !mount.ReadWrite {
+				line += " (ro)"
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	// 環境変数（シークレットらしい値はマスク、イメージ定義の値は薄く表示）
+	fromImage := 0
+	for _, env := range config.Env {
+		if env.FromImage {
+			fromImage++
+		}
+	}
+	lines = append(lines, "", fmt.Sprintf("  環境変数（%d個、うちイメージ定義%d個）:", len(config.Env), fromImage))
+	for _, env := range config.Env {
+		line := fmt.Sprintf("    %s=%s", env.Key, truncateCommand(env.MaskedValue(), 60))
+		switch {
+		case env.Secret:
+			lines = append(lines, WarningStyle.Render(line))
+		case env.FromImage:
+			lines = append(lines, CommentStyle.Render(line))
+		default:
+			lines = append(lines, line)
+		}
+	}
+
+	// ラベル
+	if len(config.Labels) > 0 {
+		keys := make([]string, 0, len(config.Labels))
+		for key := range config.Labels {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		lines = append(lines, "", fmt.Sprintf("  ラベル（%d個）:", len(keys)))
+		for _, key := range keys {
+			lines = append(lines, fmt.Sprintf("    %s=%s", key, truncateCommand(config.Labels[key], 60)))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// commandText joins command arguments for display
+func commandText(args []string) string {
+	if len(args) == 0 {
+		return "なし"
+	}
+	return truncateCommand(strings.Join(args, " "), 80)
+}
@@ -0,0 +1,351 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
+)
+
+// renderDockerDiskUsage renders a "docker system df" style summary
+func (m Model) renderDockerDiskUsage() string {
+	if len(m.cachedDockerDiskUsage) == 0 {
+		return "ディスク使用量:\n  取得中...\n"
+	}
+
+	lines := []string{"ディスク使用量:"}
+	for _, u := range m.cachedDockerDiskUsage {
+		lines = append(lines, fmt.Sprintf("  %-14s %3s個 (使用中 %s)  %8s  削減可能: %s",
+			u.Type, u.TotalCount, u.Active, u.Size, u.Reclaimable))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// renderDockerResourcesContent renders the volumes, networks or images panel
+func (m Model) renderDockerResourcesContent(menu string) string {
+	if _, fetched := m.dockerFetchedAt[menu]; !fetched {
+		return fmt.Sprintf("データ取得中... (%s)\n\nDockerが停止中の可能性があります", menu)
+	}
+
+	summary := m.renderDockerDiskUsage() + "\n"
+
+	var list, details string
+	switch menu {
+	case "Docker Volumes":
+		orphaned := 0
+		for _, v := range m.cachedDockerVolumes {
+			if v.Orphaned() {
+				orphaned++
+			}
+		}
+		summary += fmt.Sprintf("ボリューム一覧 (%d個, 未使用: %d個):\n", len(m.cachedDockerVolumes), orphaned)
+		list = m.renderSelectableDockerVolumes()
+		if volume := m.getSelectedDockerVolume(); volume != nil {
+			details = m.renderDockerVolumeDetails(volume)
+		}
+
+	case "Docker Networks":
+		summary += fmt.Sprintf("ネットワーク一覧 (%d個):\n", len(m.cachedDockerNetworks))
+		list = m.renderSelectableDockerNetworks()
+		if network := m.getSelectedDockerNetwork(); network != nil {
+			details = m.renderDockerNetworkDetails(network)
+		}
+
+	case "Docker Images":
+		unused := 0
+		for _, image := range m.cachedDockerImages {
+			if image.Unused() {
+				unused++
+			}
+		}
+		summary += fmt.Sprintf("イメージ一覧 (%d個, %d日以上未使用: %d個):\n", len(m.cachedDockerImages), monitor.UnusedImageDays, unused)
+		list = m.renderSelectableDockerImages()
+		if image := m.getSelectedDockerImage(); image != nil {
+			details = m.renderDockerImageDetails(image)
+		}
+	}
+
+	// 右パネルにフォーカスがある場合、選択された項目の詳細情報を追加
+	if m.focusedPanel == "right" && details != "" {
+		return summary + list + "\n" + details
+	}
+
+	return summary + list
+}
+
+// renderSelectableDockerVolumes renders volume list with selectable items highlighted
+func (m Model) renderSelectableDockerVolumes() string {
+	var newLines []string
+
+	for i, item := range m.rightPanelItems {
+		if item.Type != "docker_volume" {
+			continue
+		}
+
+		var volume *monitor.DockerVolume
+		for j := range m.cachedDockerVolumes {
+			if m.cachedDockerVolumes[j].Name == item.Name {
+				volume = &m.cachedDockerVolumes[j]
+				break
+			}
+		}
+		if volume == nil {
+			continue
+		}
+
+		volumeText := fmt.Sprintf("● %s", truncateCommand(volume.Name, 40))
+		style := SuccessStyle
+		infoText := fmt.Sprintf("  (%s, %d個のコンテナ)", valueOrUnknown(volume.Size), len(volume.UsedBy))
+		if volume.Orphaned() {
+			volumeText = fmt.Sprintf("○ %s", truncateCommand(volume.Name, 40))
+			style = WarningStyle
+			infoText = fmt.Sprintf("  (%s, 未使用)", valueOrUnknown(volume.Size))
+		}
+
+		var line string
+		if i == m.rightPanelCursor {
+			line = HighlightStyle.Render("> "+volumeText) + CommentStyle.Render(infoText)
+		} else {
+			line = "  " + style.Render(volumeText) + CommentStyle.Render(infoText)
+		}
+		newLines = append(newLines, line)
+	}
+
+	if len(newLines) == 0 {
+		return "  ボリュームがありません"
+	}
+	return strings.Join(newLines, "\n")
+}
+
+// renderDockerVolumeDetails renders detailed information for a selected volume
+func (m Model) renderDockerVolumeDetails(volume *monitor.DockerVolume) string {
+	details := fmt.Sprintf(`
+────────────────────────────────────────────────────
+ボリューム詳細: %s
+────────────────────────────────────────────────────
+  ドライバー: %s
+  サイズ: %s
+  マウントポイント: %s`,
+		volume.Name,
+		volume.Driver,
+		valueOrUnknown(volume.Size),
+		valueOrUnknown(volume.Mountpoint),
+	)
+
+	if volume.ComposeProject != "" {
+		details += fmt.Sprintf("\n  Composeプロジェクト: %s", volume.ComposeProject)
+	}
+
+	if volume.Orphaned() {
+		details += "\n\n  " + WarningStyle.Render("⚠ どのコンテナからも使われていません（p で未使用ボリュームをまとめて削除）")
+	} else {
+		details += "\n\n  使用中のコンテナ:"
+		for _, name := range volume.UsedBy {
+			details += fmt.Sprintf("\n    - %s", name)
+		}
+	}
+
+	return details
+}
+
+// renderSelectableDockerNetworks renders network list with selectable items highlighted
+func (m Model) renderSelectableDockerNetworks() string {
+	var newLines []string
+
+	for i, item := range m.rightPanelItems {
+		if item.Type != "docker_network" {
+			continue
+		}
+
+		var network *monitor.DockerNetwork
+		for j := range m.cachedDockerNetworks {
+			if m.cachedDockerNetworks[j].Name == item.Name {
+				network = &m.cachedDockerNetworks[j]
+				break
+			}
+		}
+		if network == nil {
+			continue
+		}
+
+		networkText := fmt.Sprintf("● %s (%s)", network.Name, network.Driver)
+		style := SuccessStyle
+		if len(network.Containers) == 0 {
+			networkText = fmt.Sprintf("○ %s (%s)", network.Name, network.Driver)
+			style = CommentStyle
+		}
+		infoText := fmt.Sprintf("  (%s, %d個のコンテナ)", strings.Join(network.Subnets, ", "), len(network.Containers))
+		if len(network.Subnets) == 0 {
+			infoText = fmt.Sprintf("  (%d個のコンテナ)", len(network.Containers))
+		}
+
+		var line string
+		if i == m.rightPanelCursor {
+			line = HighlightStyle.Render("> "+networkText) + CommentStyle.Render(infoText)
+		} else {
+			line = "  " + style.Render(networkText) + CommentStyle.Render(infoText)
+		}
+		newLines = append(newLines, line)
+	}
+
+	if len(newLines) == 0 {
+		return "  ネットワークがありません"
+	}
+	return strings.Join(newLines, "\n")
+}
+
+// renderDockerNetworkDetails renders detailed information for a selected network
+func (m Model) renderDockerNetworkDetails(network *monitor.DockerNetwork) string {
+	subnets := strings.Join(network.Subnets, ", ")
+	if subnets == "" {
+		subnets = "なし"
+	}
+	shortID := network.ID
+	if len(shortID) > 12 {
+		shortID = shortID[:12]
+	}
+
+	details := fmt.Sprintf(`
+────────────────────────────────────────────────────
+ネットワーク詳細: %s
+────────────────────────────────────────────────────
+  ID: %s
+  ドライバー: %s
+  スコープ: %s
+  サブネット: %s`,
+		network.Name,
+		shortID,
+		network.Driver,
+		network.Scope,
+		subnets,
+	)
+
+	if network.Builtin() {
+		details += "\n  " + CommentStyle.Render("デフォルトネットワークのため削除できません")
+	}
+
+	details += "\n\n  接続中のコンテナ:"
+	if len(network.Containers) == 0 {
+		details += "\n    なし"
+	}
+	for _, name := range network.Containers {
+		details += fmt.Sprintf("\n    - %s", name)
+	}
+
+	return details
+}
+
+// renderSelectableDockerImages renders image list with selectable items highlighted
+func (m Model) renderSelectableDockerImages() string {
+	var newLines []string
+
+	for i, item := range m.rightPanelItems {
+		if item.Type != "docker_image" {
+			continue
+		}
+
+		var image *monitor.DockerImage
+		for j := range m.cachedDockerImages {
+			if m.cachedDockerImages[j].ID == item.Name {
+				image = &m.cachedDockerImages[j]
+				break
+			}
+		}
+		if image == nil {
+			continue
+		}
+
+		imageText := fmt.Sprintf("● %s", dockerImageTitle(image))
+		style := SuccessStyle
+		usageText := "使用中"
+		switch {
+		case image.Dangling():
+			imageText = fmt.Sprintf("○ %s", dockerImageTitle(image))
+			style = WarningStyle
+			usageText = "dangling"
+		case image.Unused():
+			imageText = fmt.Sprintf("○ %s", dockerImageTitle(image))
+			style = WarningStyle
+			usageText = fmt.Sprintf("%d日未使用", image.UnusedDays())
+		case !image.Running:
+			style = CommentStyle
+			usageText = fmt.Sprintf("停止中のコンテナ%d個", len(image.Containers))
+			if len(image.Containers) == 0 {
+				usageText = "コンテナなし"
+			}
+		}
+		infoText := fmt.Sprintf("  (%s, %s)", image.Size, usageText)
+
+		var line string
+		if i == m.rightPanelCursor {
+			line = HighlightStyle.Render("> "+imageText) + CommentStyle.Render(infoText)
+		} else {
+			line = "  " + style.Render(imageText) + CommentStyle.Render(infoText)
+		}
+		newLines = append(newLines, line)
+	}
+
+	if len(newLines) == 0 {
+		return "  イメージがありません"
+	}
+	return strings.Join(newLines, "\n")
+}
+
+// renderDockerImageDetails renders detailed information for a selected image
+func (m Model) renderDockerImageDetails(image *monitor.DockerImage) string {
+	created := "不明"
+	if !image.CreatedAt.IsZero() {
+		created = image.CreatedAt.Local().Format("2006-01-02 15:04")
+	}
+
+	details := fmt.Sprintf(`
+────────────────────────────────────────────────────
+イメージ詳細: %s
+────────────────────────────────────────────────────
+  ID: %s
+  サイズ: %s
+  作成日時: %s`,
+		dockerImageTitle(image),
+		image.ID,
+		image.Size,
+		created,
+	)
+
+	if len(image.Tags) > 1 {
+		details += "\n  タグ:"
+		for _, tag := range image.Tags {
+			details += fmt.Sprintf("\n    - %s", tag)
+		}
+	}
+
+	details += "\n\n  使用状況:"
+	switch {
+	case image.Running:
+		details += fmt.Sprintf("\n    稼働中のコンテナあり（最終使用: %s）", image.LastUsedBy)
+	case !image.LastUsed.IsZero():
+		details += fmt.Sprintf("\n    最終使用: %s (%s, %d日前)", image.LastUsed.Local().Format("2006-01-02 15:04"), image.LastUsedBy, image.UnusedDays())
+	default:
+		details += fmt.Sprintf("\n    コンテナから使われた記録なし（作成から%d日）", image.UnusedDays())
+	}
+
+	if len(image.Containers) > 0 {
+		details += fmt.Sprintf("\n    コンテナ: %s", strings.Join(image.Containers, ", "))
+	}
+
+	if image.Unused() {
+		details += "\n\n  " + WarningStyle.Render(fmt.Sprintf("⚠ %d日以上使われていません（p で未使用イメージをまとめて削除）", monitor.UnusedImageDays))
+	}
+
+	return details
+}
+
+// dockerImageTitle returns the first tag or the ID for dangling images
+func dockerImageTitle(image *monitor.DockerImage) string {
+	if len(image.Tags) == 0 {
+		return "<none> " + image.ID
+	}
+	if len(image.Tags) > 1 {
+		return fmt.Sprintf("%s (+%dタグ)", image.Tags[0], len(image.Tags)-1)
+	}
+	return image.Tags[0]
+}
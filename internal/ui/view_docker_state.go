@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// containerStatusIcon returns the list icon and color for a container state
+func containerStatusIcon(container *monitor.DockerContainer) (string, lipgloss.Style) {
+	state := container.State
+
+	switch {
+	case state.State == "restarting":
+		return "↻", ErrorStyle
+	case container.Status == "running" && container.Health == "unhealthy":
+		return "●", ErrorStyle
+	case container.Status == "running" && (container.Health == "starting" || state.RestartLooping()):
+		return "◐", WarningStyle
+	case container.Status == "running":
+		return "●", SuccessStyle
+	case state.State == "paused":
+		return "‖", WarningStyle
+	case state.Crashed():
+		return "✗", ErrorStyle
+	default:
+		return "○", CommentStyle
+	}
+}
+
+// containerListTags returns short tags for the container list (health, restarts, exit code)
+func containerListTags(container *monitor.DockerContainer) string {
+	state := container.State
+
+	var tags []string
+	if container.Health != "" {
+		tags = append(tags, container.Health)
+	}
+	if state.RestartCount > 0 {
+		tags = append(tags, fmt.Sprintf("再起動%d回", state.RestartCount))
+	}
+	if state.Crashed() || state.State == "restarting" {
+		tags = append(tags, exitCodeText(state))
+	}
+
+	if len(tags) == 0 {
+		return ""
+	}
+	return " [" + strings.Join(tags, ", ") + "]"
+}
+
+// containerStateLabel returns the Japanese label of a container state
+func containerStateLabel(container *monitor.DockerContainer) string {
+	switch container.State.State {
+	case "running":
+		return "稼働中"
+	case "restarting":
+		return "再起動中"
+	case "paused":
+		return "一時停止中"
+	case "created":
+		return "作成済み（未起動）"
+	case "exited":
+		return "停止"
+	case "dead":
+		return "異常終了（dead）"
+	}
+
+	// inspectできなかった場合はdocker psの状態を使う
+	if container.Status == "running" {
+		return "稼働中"
+	}
+	return "停止"
+}
+
+// exitCodeText formats the exit code with its reason (例: "exit 137 (OOM)")
+func exitCodeText(state monitor.ContainerState) string {
+	text := fmt.Sprintf("exit %d", state.ExitCode)
+	if reason := state.ExitReason(); reason != "" {
+		text += fmt.Sprintf(" (%s)", reason)
+	}
+	return text
+}
+
+// renderContainerState renders the status, health check, exit code and restart count of a container
+func renderContainerState(container *monitor.DockerContainer) string {
+	state := container.State
+	icon, style := containerStatusIcon(container)
+
+	status := containerStateLabel(container)
+	if !state.StartedAt.IsZero() && container.Status == "running" {
+		status += fmt.Sprintf("（%s 起動）", state.StartedAt.Local().Format("01-02 15:04"))
+	} else if !state.FinishedAt.IsZero() && container.Status != "running" {
+		status += fmt.Sprintf("（%s 終了）", state.FinishedAt.Local().Format("01-02 15:04"))
+	}
+	lines := []string{"  ステータス: " + style.Render(icon+" "+status)}
+
+	// ヘルスチェック
+	switch container.Health {
+	case "healthy":
+		lines = append(lines, "  ヘルスチェック: "+SuccessStyle.Render("✓ healthy"))
+	case "unhealthy":
+		lines = append(lines, "  ヘルスチェック: "+ErrorStyle.Render(fmt.Sprintf("✗ unhealthy（連続%d回失敗）", state.FailingStreak)))
+	case "starting":
+		lines = append(lines, "  ヘルスチェック: "+WarningStyle.Render("◐ starting（初回チェック待ち）"))
+	}
+	if state.HealthOutput != "" && container.Health != "healthy" {
+		lines = append(lines, "    最終出力: "+CommentStyle.Render(truncateCommand(strings.Join(strings.Fields(state.HealthOutput), " "), 80)))
+	}
+
+	// 再起動回数
+	if state.RestartCount > 0 {
+		restartText := fmt.Sprintf("%d回", state.RestartCount)
+		if state.RestartLooping() {
+			lines = append(lines, "  再起動回数: "+ErrorStyle.Render("↻ "+restartText+"（再起動ループの可能性）"))
+		} else {
+			lines = append(lines, "  再起動回数: "+WarningStyle.Render(restartText))
+		}
+	}
+
+	// 終了コード（停止中、または再起動したことがある場合）
+	if container.Status != "running" || state.RestartCount > 0 {
+		exitText := exitCodeText(state)
+		if state.ExitCode == 0 && !state.OOMKilled {
+			lines = append(lines, "  最終終了コード: "+CommentStyle.Render(exitText))
+		} else {
+			lines = append(lines, "  最終終了コード: "+ErrorStyle.Render(exitText))
+		}
+	}
+	if state.OOMKilled {
+		lines = append(lines, "  "+ErrorStyle.Render("⚠ メモリ不足（OOMKilled）で強制終了されました"))
+	}
+	if state.Error != "" {
+		lines = append(lines, "  エラー: "+ErrorStyle.Render(state.Error))
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,206 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// renderElasticsearchContent renders Elasticsearch/OpenSearch cluster information
+func (m Model) renderElasticsearchContent() string {
+	// キャッシュから取得（Viewではブロッキング処理を行わない）
+	cluster := m.cachedESCluster
+
+	if !cluster.IsReachable {
+		if cluster.Error != "" {
+			return fmt.Sprintf("Elasticsearchに接続できません:\n  %s\n\n接続設定は %s で変更できます", cluster.Error, configPathText())
+		}
+		return "データ取得中... (Elasticsearch)\n\nElasticsearchが停止中の可能性があります"
+	}
+
+	// 統計情報を生成
+	summary := fmt.Sprintf(`統計情報:
+  %s %s (クラスタ: %s)
+  ヘルス: %s
+  ノード数: %d (データノード: %d)
+  シャード: アクティブ %d / 未割り当て %d
+`,
+		cluster.Distribution,
+		valueOrUnknown(cluster.Version),
+		valueOrUnknown(cluster.ClusterName),
+		renderClusterHealth(cluster.Health),
+		cluster.Nodes,
+		cluster.DataNodes,
+		cluster.ActiveShards,
+		cluster.UnassignedShards,
+	)
+	if cluster.Container != "" {
+		summary += fmt.Sprintf("  コンテナ: %s\n", cluster.Container)
+	}
+
+	// ノードのヒープ使用率
+	summary += "\nノード:\n" + m.renderElasticsearchNodes()
+
+	// 保留タスク
+	summary += "\n\n保留中のタスク:\n" + m.renderElasticsearchPendingTasks()
+
+	summary += "\n\nインデックス一覧:\n"
+
+	// インデックスリストを生成
+	indexList := m.renderSelectableElasticsearchContent()
+
+	// 右パネルにフォーカスがある場合、選択されたインデックスの詳細情報を追加
+	if m.focusedPanel == "right" && len(m.rightPanelItems) > 0 && m.rightPanelCursor < len(m.rightPanelItems) {
+		selectedItem := m.rightPanelItems[m.rightPanelCursor]
+
+		if selectedItem.Type == "es_index" {
+			// インデックスの詳細情報を取得
+			index := m.getSelectedElasticsearchIndex()
+			if index != nil {
+				details := m.renderElasticsearchIndexDetails(index)
+				return summary + indexList + "\n" + details
+			}
+		}
+	}
+
+	return summary + indexList
+}
+
+// renderClusterHealth renders health status with color
+func renderClusterHealth(health string) string {
+	return clusterHealthStyle(health).Render(valueOrUnknown(health))
+}
+
+// clusterHealthStyle returns a style for green/yellow/red health
+func clusterHealthStyle(health string) lipgloss.Style {
+	switch health {
+	case "green":
+		return SuccessStyle
+	case "yellow":
+		return WarningStyle
+	case "red":
+		return ErrorStyle
+	default:
+		return CommentStyle
+	}
+}
+
+// renderElasticsearchNodes renders heap usage per node
+func (m Model) renderElasticsearchNodes() string {
+	if len(m.cachedESNodes) == 0 {
+		return "  ノード情報を取得できません"
+	}
+
+	var lines []string
+	for _, node := range m.cachedESNodes {
+		heapText := fmt.Sprintf("ヒープ %s%% (%s / %s)", valueOrUnknown(node.HeapPercent), node.HeapCurrent, node.HeapMax)
+
+		// ヒープ使用率が高いノードは警告色
+		style := CommentStyle
+		var heapPercent int
+		if _, err := fmt.Sscanf(node.HeapPercent, "%d", &heapPercent); err == nil && heapPercent >= 85 {
+			style = WarningStyle
+		}
+
+		lines = append(lines, fmt.Sprintf("  ● %s  %s  %s",
+			node.Name,
+			style.Render(heapText),
+			CommentStyle.Render(fmt.Sprintf("CPU %s%% / load %s / role %s", node.CPU, node.Load1m, node.Roles)),
+		))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// renderElasticsearchPendingTasks renders cluster-level pending tasks
+func (m Model) renderElasticsearchPendingTasks() string {
+	if len(m.cachedESPendingTasks) == 0 {
+		return "  保留中のタスクはありません"
+	}
+
+	var lines []string
+	for _, task := range m.cachedESPendingTasks {
+		lines = append(lines, fmt.Sprintf("  #%d [%s] %s %s",
+			task.InsertOrder,
+			task.Priority,
+			WarningStyle.Render(task.Source),
+			CommentStyle.Render("("+task.TimeInQueue+")"),
+		))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// renderElasticsearchIndexDetails renders detailed information for a selected index
+func (m Model) renderElasticsearchIndexDetails(index *monitor.ElasticsearchIndex) string {
+	return fmt.Sprintf(`
+────────────────────────────────────────────────────
+インデックス詳細: %s
+────────────────────────────────────────────────────
+  ヘルス: %s
+  状態: %s
+  ドキュメント数: %s
+  サイズ: %s
+
+  シャード:
+    プライマリ: %s
+    レプリカ: %s`,
+		index.Name,
+		renderClusterHealth(index.Health),
+		valueOrUnknown(index.Status),
+		valueOrUnknown(index.DocsCount),
+		valueOrUnknown(index.StoreSize),
+		valueOrUnknown(index.PrimaryShards),
+		valueOrUnknown(index.Replicas),
+	)
+}
+
+// renderSelectableElasticsearchContent renders index list with selectable items highlighted
+func (m Model) renderSelectableElasticsearchContent() string {
+	var newLines []string
+
+	// キャッシュから取得（Viewではブロッキング処理を行わない）
+	indices := m.cachedESIndices
+
+	// 各インデックスを表示
+	for i, item := range m.rightPanelItems {
+		if item.Type != "es_index" {
+			continue
+		}
+
+		// インデックスを検索
+		var index *monitor.ElasticsearchIndex
+		for j := range indices {
+			if indices[j].Name == item.Name {
+				index = &indices[j]
+				break
+			}
+		}
+
+		if index == nil {
+			continue
+		}
+
+		// インデックス名・ドキュメント数・サイズ
+		indexText := fmt.Sprintf("● %s", index.Name)
+		sizeText := fmt.Sprintf("  (%s docs, %s)", valueOrUnknown(index.DocsCount), valueOrUnknown(index.StoreSize))
+
+		// カーソル位置なら強調表示
+		var line string
+		if i == m.rightPanelCursor {
+			line = HighlightStyle.Render("> "+indexText) + CommentStyle.Render(sizeText)
+		} else {
+			line = "  " + clusterHealthStyle(index.Health).Render(indexText) + CommentStyle.Render(sizeText)
+		}
+
+		newLines = append(newLines, line)
+	}
+
+	if len(newLines) == 0 {
+		return "  インデックスがありません"
+	}
+
+	return strings.Join(newLines, "\n")
+}
@@ -0,0 +1,155 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
+)
+
+// renderKafkaContent renders Kafka topic and consumer group information
+func (m Model) renderKafkaContent() string {
+	// キャッシュから取得（Viewではブロッキング処理を行わない）
+	status := m.cachedKafkaStatus
+
+	// キャッシュがない場合はローディング表示
+	if !status.IsReachable {
+		if status.Error != "" {
+			return fmt.Sprintf("Kafkaに接続できません:\n  %s\n\n接続設定は %s で変更できます", status.Error, configPathText())
+		}
+		return "データ取得中... (Kafka)\n\nKafka CLIの起動に数秒かかる場合があります"
+	}
+
+	// 統計情報を生成
+	summary := fmt.Sprintf(`統計情報:
+  トピック数: %d個
+  コンシューマーグループ数: %d個
+`, len(status.Topics), countKafkaGroups(status.Groups))
+	if status.Container != "" {
+		summary += fmt.Sprintf("  コンテナ: %s\n", status.Container)
+	}
+	summary += "\nトピック一覧:\n"
+
+	// トピックリストを生成
+	topicList := m.renderSelectableKafkaTopics()
+
+	// コンシューマーグループのラグを生成
+	groupList := "\n\nコンシューマーグループ (ラグ):\n" + renderKafkaGroupLags(status.Groups)
+
+	// 右パネルにフォーカスがある場合、選択されたトピックの詳細情報を追加
+	if m.focusedPanel == "right" && len(m.rightPanelItems) > 0 && m.rightPanelCursor < len(m.rightPanelItems) {
+		if topic := m.getSelectedKafkaTopic(); topic != nil {
+			return summary + topicList + groupList + "\n" + m.renderKafkaTopicDetails(topic)
+		}
+	}
+
+	return summary + topicList + groupList
+}
+
+// renderKafkaTopicDetails renders detailed information for a selected topic
+func (m Model) renderKafkaTopicDetails(topic *monitor.KafkaTopic) string {
+	details := fmt.Sprintf(`
+────────────────────────────────────────────────────
+トピック詳細: %s
+────────────────────────────────────────────────────
+  パーティション数: %d
+  レプリケーション係数: %s
+
+  購読しているコンシューマーグループ:`,
+		topic.Name,
+		topic.Partitions,
+		valueOrUnknown(topic.ReplicationFactor),
+	)
+
+	var groups []monitor.KafkaConsumerGroupLag
+	for _, g := range m.cachedKafkaStatus.Groups {
+		if g.Topic == topic.Name {
+			groups = append(groups, g)
+		}
+	}
+
+	if len(groups) == 0 {
+		return details + "\n    なし"
+	}
+
+	return details + "\n" + renderKafkaGroupLags(groups)
+}
+
+// renderSelectableKafkaTopics renders topic list with selectable items highlighted
+func (m Model) renderSelectableKafkaTopics() string {
+	var newLines []string
+
+	// キャッシュから取得（Viewではブロッキング処理を行わない）
+	topics := m.cachedKafkaStatus.Topics
+
+	for i, item := range m.rightPanelItems {
+		if item.Type != "kafka_topic" {
+			continue
+		}
+
+		// トピックを検索
+		var topic *monitor.KafkaTopic
+		for j := range topics {
+			if topics[j].Name == item.Name {
+				topic = &topics[j]
+				break
+			}
+		}
+
+		if topic == nil {
+			continue
+		}
+
+		// トピック名とパーティション数
+		topicText := fmt.Sprintf("● %s", topic.Name)
+		partitionText := fmt.Sprintf("  (%dパーティション)", topic.Partitions)
+
+		// カーソル位置なら強調表示
+		var line string
+		if i == m.rightPanelCursor {
+			line = HighlightStyle.Render("> "+topicText) + CommentStyle.Render(partitionText)
+		} else {
+			line = "  " + SuccessStyle.Render(topicText) + CommentStyle.Render(partitionText)
+		}
+
+		newLines = append(newLines, line)
+	}
+
+	if len(newLines) == 0 {
+		return "  トピックがありません"
+	}
+
+	return strings.Join(newLines, "\n")
+}
+
+// renderKafkaGroupLags renders consumer group lag per topic
+func renderKafkaGroupLags(groups []monitor.KafkaConsumerGroupLag) string {
+	if len(groups) == 0 {
+		return "  コンシューマーグループがありません"
+	}
+
+	var lines []string
+	for _, g := range groups {
+		groupText := fmt.Sprintf("● %s → %s", g.Group, g.Topic)
+		lagText := fmt.Sprintf("  (ラグ %d / コンシューマー %d / %dパーティション)", g.Lag, g.Consumers, g.Partitions)
+
+		// ラグが大きい、またはコンシューマーがいないグループは警告色
+		style := SuccessStyle
+		if g.Lag >= monitor.KafkaLagWarningThreshold || (g.Consumers == 0 && g.Lag > 0) {
+			style = WarningStyle
+		}
+
+		lines = append(lines, "  "+style.Render(groupText)+CommentStyle.Render(lagText))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// countKafkaGroups counts distinct consumer groups
+func countKafkaGroups(groups []monitor.KafkaConsumerGroupLag) int {
+	seen := make(map[string]bool)
+	for _, g := range groups {
+		seen[g.Group] = true
+	}
+	return len(seen)
+}
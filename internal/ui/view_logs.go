@@ -4,13 +4,13 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor/logs"
 	"github.com/charmbracelet/lipgloss"
 )
 
 // renderWithLogView renders main view with log viewer overlay
 func (m Model) renderWithLogView(mainView string) string {
-	// ログ内容を行に分割
-	logLines := strings.Split(m.logContent, "\n")
+	logLines := m.logViewLines()
 
 	// ログビューのサイズ設定（画面の80%）
 	logWidth := int(float64(m.width) * 0.8)
@@ -26,28 +26,14 @@ func (m Model) renderWithLogView(mainView string) string {
 
 	// タイトルとヘルプメッセージ
 	title := fmt.Sprintf("📋 ログ: %s", m.logTargetName)
-	helpMsg := "[Ctrl+D/U: スクロール | ESC: 閉じる]"
+	helpMsg := "[Ctrl+D/U: スクロール | Space: 一時停止/再開 | G: 最新へ | ESC: 閉じる]\n" +
+		"[/: 検索（正規表現） | n/N: 次/前の一致 | f: 一致行のみ表示 | e/E: 次/前のエラー | J: JSON整形]"
 
-	// 表示可能なログ行数（タイトル、ヘルプ、パディングを除く）
-	contentHeight := logHeight - 6
+	// 表示可能なログ行数（タイトル、ステータス、ヘルプ、パディングを除く）
+	contentHeight := m.logContentHeight()
 
-	// スクロール位置の調整
-	maxScroll := len(logLines) - contentHeight
-	if maxScroll < 0 {
-		maxScroll = 0
-	}
-
-	// 実際のスクロール位置を計算
-	scrollPos := m.logScroll
-	if scrollPos > maxScroll {
-		scrollPos = maxScroll
-	}
-	if scrollPos < 0 {
-		scrollPos = 0
-	}
-
-	// 表示するログ行を抽出
-	startLine := scrollPos
+	// 実際のスクロール位置を計算（自動スクロール中は最下部）
+	startLine := m.logCurrentScroll()
 	endLine := startLine + contentHeight
 	if endLine > len(logLines) {
 		endLine = len(logLines)
@@ -58,22 +44,88 @@ func (m Model) renderWithLogView(mainView string) string {
 	// ログ内容を構築
 	var logContent strings.Builder
 	logContent.WriteString(TitleStyle.Width(logWidth - 4).Render(title))
-	logContent.WriteString("\n\n")
+	logContent.WriteString("\n")
+	if len(m.logSources) > 0 {
+		logContent.WriteString(m.renderLogSourceLegend())
+		logContent.WriteString("\n")
+	}
+	logContent.WriteString("\n")
+
+	// 統合ログではソース名の幅を揃える
+	sourceWidth := 0
+	for _, name := range m.logSources {
+		if w := lipgloss.Width(name); w > sourceWidth {
+			sourceWidth = w
+		}
+	}
+
+	if len(logLines) == 0 && m.logStreamErr == "" {
+		logContent.WriteString(CommentStyle.Render("ログを待っています..."))
+		logContent.WriteString("\n")
+	}
+
+	// ログ行を表示（各行を幅に合わせてトリミングし、レベルで色分け）
+	for i, line := range visibleLines {
+		// 統合ログは「時刻 ソース | 」を先頭に付ける
+		prefix := ""
+		textWidth := logWidth - 4
+		if len(m.logSources) > 0 && line.Source != "" {
+			stamp := "            "
+			if !line.Time.IsZero() {
+				stamp = line.Time.Local().Format("15:04:05.000")
+			}
+			name := line.Source + strings.Repeat(" ", sourceWidth-lipgloss.Width(line.Source))
+			prefix = CommentStyle.Render(stamp+" ") + m.logSourceStyle(line.Source).Render(name) + CommentStyle.Render(" | ")
+			textWidth -= lipgloss.Width(stamp) + sourceWidth + 4
+		}
 
-	// ログ行を表示（各行を幅に合わせてトリミング）
-	for _, line := range visibleLines {
 		// 幅を超える行はトリミング
-		runes := []rune(line)
-		if len(runes) > logWidth-4 {
-			line = string(runes[:logWidth-4])
+		text := line.Text
+		runes := []rune(text)
+		if textWidth < 10 {
+			textWidth = 10
 		}
-		logContent.WriteString(line)
+		if len(runes) > textWidth {
+			text = string(runes[:textWidth])
+		}
+		logContent.WriteString(prefix)
+		logContent.WriteString(m.renderLogLine(text, line.Level, startLine+i == m.logMatchLine))
 		logContent.WriteString("\n")
 	}
 
-	// スクロール情報
+	// スクロール情報とフォローの状態
 	scrollInfo := fmt.Sprintf("\n[%d-%d / %d行]", startLine+1, endLine, len(logLines))
+	if m.logBuffer != nil && m.logBuffer.Dropped() > 0 {
+		scrollInfo += fmt.Sprintf(" (古い%d行を破棄)", m.logBuffer.Dropped())
+	}
 	logContent.WriteString(CommentStyle.Render(scrollInfo))
+	if errors, warnings := m.logLevelCounts(); errors > 0 || warnings > 0 {
+		logContent.WriteString(" ")
+		logContent.WriteString(ErrorStyle.Render(fmt.Sprintf("ERROR %d", errors)))
+		logContent.WriteString(CommentStyle.Render(" / "))
+		logContent.WriteString(WarningStyle.Render(fmt.Sprintf("WARN %d", warnings)))
+	}
+	logContent.WriteString("  ")
+	switch {
+	case m.logPaused:
+		logContent.WriteString(WarningStyle.Render(fmt.Sprintf("⏸ 一時停止中（新着 %d行）", m.logPendingLines)))
+	case m.logStreamErr != "":
+		logContent.WriteString(ErrorStyle.Render("■ " + m.logStreamErr))
+	case m.logAutoScroll:
+		logContent.WriteString(SuccessStyle.Render("● フォロー中"))
+	default:
+		logContent.WriteString(InfoStyle.Render("● フォロー中（スクロール中: G で最新へ）"))
+	}
+	logContent.WriteString("\n")
+	if status := m.logSearchStatus(); status != "" {
+		logContent.WriteString(HelpStyle.Render(status))
+		if m.logSearchErr != "" {
+			logContent.WriteString("  ")
+			logContent.WriteString(WarningStyle.Render(m.logSearchErr))
+		}
+	} else if m.logSearchErr != "" {
+		logContent.WriteString(WarningStyle.Render(m.logSearchErr))
+	}
 	logContent.WriteString("\n")
 	logContent.WriteString(CommentStyle.Render(helpMsg))
 
@@ -123,3 +175,59 @@ func (m Model) renderWithLogView(mainView string) string {
 
 	return strings.Join(mainLines, "\n")
 }
+
+var (
+	// INFOの行
+	logInfoStyle = lipgloss.NewStyle().Foreground(borderColor)
+	// 検索に一致した部分
+	logMatchStyle = lipgloss.NewStyle().Foreground(bgColor).Background(warningColor)
+	// 現在の一致行で一致した部分
+	logCurrentMatchStyle = lipgloss.NewStyle().Foreground(bgColor).Background(accentColor).Bold(true)
+)
+
+// renderLogLine colors a log line by level and highlights search matches
+func (m Model) renderLogLine(text string, level logs.Level, current bool) string {
+	style := logLevelStyle(level)
+	if m.logSearchRegex == nil {
+		return style.Render(text)
+	}
+
+	matches := m.logSearchRegex.FindAllStringIndex(text, -1)
+	if len(matches) == 0 {
+		return style.Render(text)
+	}
+
+	matchStyle := logMatchStyle
+	if current {
+		matchStyle = logCurrentMatchStyle
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, match := range matches {
+		if match[0] == match[1] {
+			continue
+		}
+		b.WriteString(style.Render(text[last:match[0]]))
+		b.WriteString(matchStyle.Render(text[match[0]:match[1]]))
+		last = match[1]
+	}
+	b.WriteString(style.Render(text[last:]))
+	return b.String()
+}
+
+// logLevelStyle returns the style of a log level
+func logLevelStyle(level logs.Level) lipgloss.Style {
+	switch level {
+	case logs.LevelError:
+		return ErrorStyle
+	case logs.LevelWarn:
+		return WarningStyle
+	case logs.LevelInfo:
+		return logInfoStyle
+	case logs.LevelDebug:
+		return CommentStyle
+	default:
+		return lipgloss.NewStyle()
+	}
+}
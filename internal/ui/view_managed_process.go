@@ -0,0 +1,36 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
+)
+
+// renderManagedProcessInfo renders how a process in dir was started with "devmon run" (該当しなければ空)
+func renderManagedProcessInfo(dir string) string {
+	process, ok := monitor.FindManagedProcess(dir)
+	if !ok || !process.Running() {
+		return ""
+	}
+
+	return fmt.Sprintf(`
+
+  devmon run で起動:
+    コマンド: %s
+    起動日時: %s
+    ログ: %s
+    (r: 同じコマンドで再起動)`,
+		truncateCommand(process.CommandLine(), 80),
+		process.StartedAt.Local().Format("2006-01-02 15:04:05"),
+		process.LogFile,
+	)
+}
+
+// renderLaunchPrompt renders the command input for launching a process
+func (m Model) renderLaunchPrompt() string {
+	help := "Enter: 起動 | Esc: キャンセル"
+	if len(m.launchHistory) > 1 {
+		help = fmt.Sprintf("Enter: 起動 | Tab: 履歴 (%d/%d) | Esc: キャンセル", m.launchHistoryIndex+1, len(m.launchHistory))
+	}
+	return HelpStyle.Render(fmt.Sprintf("起動 [%s] $ %s█ | %s", m.launchDir, m.launchInput, help))
+}
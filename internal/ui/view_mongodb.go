@@ -0,0 +1,185 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
+)
+
+// renderMongoContent renders MongoDB database information
+func (m Model) renderMongoContent() string {
+	// キャッシュから取得（Viewではブロッキング処理を行わない）
+	databases := m.cachedMongoDatabases
+	status := m.cachedMongoStatus
+
+	// キャッシュがない場合はローディング表示
+	if len(databases) == 0 && !status.IsReachable {
+		if status.Error != "" {
+			return fmt.Sprintf("MongoDBに接続できません:\n  %s\n\n接続設定は %s で変更できます", status.Error, configPathText())
+		}
+		return "データ取得中... (MongoDB)\n\nMongoDBが停止中の可能性があります"
+	}
+
+	// 統計情報を生成
+	summary := fmt.Sprintf(`統計情報:
+  データベース数: %d個
+%s
+データベース一覧:
+`, len(databases), m.renderMongoServerStatus())
+
+	// データベースリストを生成
+	databaseList := m.renderSelectableMongoContent()
+
+	// 実行中オペレーションを生成
+	opsList := "\n\n実行中のオペレーション:\n" + m.renderMongoCurrentOps()
+
+	// 右パネルにフォーカスがある場合、選択されたデータベースの詳細情報を追加
+	if m.focusedPanel == "right" && len(m.rightPanelItems) > 0 && m.rightPanelCursor < len(m.rightPanelItems) {
+		selectedItem := m.rightPanelItems[m.rightPanelCursor]
+
+		if selectedItem.Type == "database" {
+			// データベースの詳細情報を取得
+			database := m.getSelectedMongoDatabase()
+			if database != nil {
+				details := m.renderMongoDatabaseDetails(database)
+				return summary + databaseList + opsList + "\n" + details
+			}
+		}
+	}
+
+	return summary + databaseList + opsList
+}
+
+// renderMongoServerStatus renders server metrics from serverStatus
+func (m Model) renderMongoServerStatus() string {
+	status := m.cachedMongoStatus
+	if !status.IsReachable {
+		return ""
+	}
+
+	result := fmt.Sprintf(`  バージョン: %s
+  稼働時間: %s
+  接続数: %s
+  常駐メモリ: %s
+  オペレーション累計: %s
+`,
+		valueOrUnknown(status.Version),
+		valueOrUnknown(status.Uptime),
+		valueOrUnknown(status.Connections),
+		valueOrUnknown(status.ResidentMemory),
+		valueOrUnknown(status.OpCounters),
+	)
+
+	if status.Container != "" {
+		result += fmt.Sprintf("  コンテナ: %s\n", status.Container)
+	}
+
+	return result
+}
+
+// renderMongoCurrentOps renders in-progress operations
+func (m Model) renderMongoCurrentOps() string {
+	ops := m.cachedMongoOps
+	if len(ops) == 0 {
+		return "  実行中のオペレーションはありません"
+	}
+
+	var lines []string
+	for _, op := range ops {
+		opText := fmt.Sprintf("● #%s %s %s", op.OpID, op.Op, valueOrUnknown(op.Namespace))
+		infoText := fmt.Sprintf("  (%ds, %s)", op.SecsRunning, valueOrUnknown(op.Client))
+
+		// 長時間実行中のオペレーションは警告色
+		style := CommentStyle
+		if op.SecsRunning >= 5 {
+			style = WarningStyle
+		}
+
+		lines = append(lines, "  "+style.Render(opText)+CommentStyle.Render(infoText))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// renderMongoDatabaseDetails renders detailed information for a selected database
+func (m Model) renderMongoDatabaseDetails(database *monitor.MongoDatabase) string {
+	details := fmt.Sprintf(`
+────────────────────────────────────────────────────
+データベース詳細: %s
+────────────────────────────────────────────────────
+  サイズ: %s
+  コレクション数: %d個
+
+  コレクション一覧 (サイズ順):`,
+		database.Name,
+		database.Size,
+		database.Collections,
+	)
+
+	collections, exists := m.cachedMongoCollections[database.Name]
+	if !exists {
+		return details + "\n    取得中..."
+	}
+	if len(collections) == 0 {
+		return details + "\n    コレクションがありません"
+	}
+
+	details += fmt.Sprintf("\n    %-30s %12s %12s %12s", "コレクション", "ドキュメント数", "データ", "インデックス")
+	for _, c := range collections {
+		details += fmt.Sprintf("\n    %-30s %12d %12s %12s", c.Name, c.Count, c.Size, c.IndexSize)
+	}
+
+	return details
+}
+
+// renderSelectableMongoContent renders database list with selectable items highlighted
+func (m Model) renderSelectableMongoContent() string {
+	var newLines []string
+
+	// キャッシュから取得（Viewではブロッキング処理を行わない）
+	databases := m.cachedMongoDatabases
+	if len(databases) == 0 {
+		return "  データベースがありません"
+	}
+
+	// 各データベースを表示
+	for i, item := range m.rightPanelItems {
+		if item.Type != "database" {
+			continue
+		}
+
+		// データベースを検索
+		var database *monitor.MongoDatabase
+		for j := range databases {
+			if databases[j].Name == item.Name {
+				database = &databases[j]
+				break
+			}
+		}
+
+		if database == nil {
+			continue
+		}
+
+		// データベース名・サイズ・コレクション数
+		databaseText := fmt.Sprintf("● %s", database.Name)
+		sizeText := fmt.Sprintf("  (%s, %dコレクション)", database.Size, database.Collections)
+
+		// カーソル位置なら強調表示
+		var line string
+		if i == m.rightPanelCursor {
+			line = HighlightStyle.Render("> "+databaseText) + CommentStyle.Render(sizeText)
+		} else {
+			line = "  " + SuccessStyle.Render(databaseText) + CommentStyle.Render(sizeText)
+		}
+
+		newLines = append(newLines, line)
+	}
+
+	if len(newLines) == 0 {
+		return "  データベースがありません"
+	}
+
+	return strings.Join(newLines, "\n")
+}
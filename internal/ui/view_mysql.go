@@ -11,23 +11,30 @@ import (
 func (m Model) renderMySQLContent() string {
 	// キャッシュから取得（Viewではブロッキング処理を行わない）
 	databases := m.cachedMySQLDatabases
+	status := m.cachedMySQLStatus
 
 	// キャッシュがない場合はローディング表示
-	if len(databases) == 0 {
+	if len(databases) == 0 && !status.IsReachable {
+		if status.Error != "" {
+			return fmt.Sprintf("MySQLに接続できません:\n  %s\n\n接続設定は %s で変更できます", status.Error, configPathText())
+		}
 		return "データ取得中... (MySQL)\n\nMySQLが停止中の可能性があります"
 	}
 
 	// 統計情報を生成
 	summary := fmt.Sprintf(`統計情報:
   データベース数: %d個
-
+%s
 データベース一覧:
-`, len(databases))
+`, len(databases), m.renderMySQLServerStatus())
 
 	// データベースリストを生成
 	databaseList := m.renderSelectableMySQLContent()
 
-	// 右パネルにフォーカスがある場合、選択されたデータベースの詳細情報を追加
+	// プロセスリストを生成
+	processList := "\n\nプロセスリスト:\n" + m.renderSelectableMySQLProcesses()
+
+	// 右パネルにフォーカスがある場合、選択された項目の詳細情報を追加
 	if m.focusedPanel == "right" && len(m.rightPanelItems) > 0 && m.rightPanelCursor < len(m.rightPanelItems) {
 		selectedItem := m.rightPanelItems[m.rightPanelCursor]
 
@@ -36,12 +43,49 @@ func (m Model) renderMySQLContent() string {
 			database := m.getSelectedMySQLDatabase()
 			if database != nil {
 				details := m.renderMySQLDatabaseDetails(database)
-				return summary + databaseList + "\n" + details
+				return summary + databaseList + processList + "\n" + details
+			}
+		} else if selectedItem.Type == "mysql_process" {
+			// 接続の詳細情報を取得
+			process := m.getSelectedMySQLProcess()
+			if process != nil {
+				details := m.renderMySQLProcessDetails(process)
+				return summary + databaseList + processList + "\n" + details
 			}
 		}
 	}
 
-	return summary + databaseList
+	return summary + databaseList + processList
+}
+
+// renderMySQLServerStatus renders server metrics from SHOW GLOBAL STATUS
+func (m Model) renderMySQLServerStatus() string {
+	status := m.cachedMySQLStatus
+	if !status.IsReachable {
+		return ""
+	}
+
+	return fmt.Sprintf(`  バージョン: %s
+  稼働時間: %s
+  接続スレッド: %s (実行中: %s)
+  スロークエリ: %s
+  クエリ総数: %s
+
+InnoDB:
+  バッファプールヒット率: %s
+  バッファプール使用率: %s
+  行ロック待ち: %s
+`,
+		valueOrUnknown(status.Version),
+		valueOrUnknown(status.Uptime),
+		valueOrUnknown(status.ThreadsConnected),
+		valueOrUnknown(status.ThreadsRunning),
+		valueOrUnknown(status.SlowQueries),
+		valueOrUnknown(status.Questions),
+		valueOrUnknown(status.BufferPoolHitRate),
+		valueOrUnknown(status.BufferPoolUsage),
+		valueOrUnknown(status.RowLockWaits),
+	)
 }
 
 // renderMySQLDatabaseDetails renders detailed information for a selected database
@@ -50,14 +94,60 @@ func (m Model) renderMySQLDatabaseDetails(database *monitor.MySQLDatabase) strin
 ────────────────────────────────────────────────────
 データベース詳細: %s
 ────────────────────────────────────────────────────
-  サイズ: %s`,
+  サイズ: %s
+
+  テーブル一覧 (サイズ順):`,
 		database.Name,
 		database.Size,
 	)
 
+	tables, exists := m.cachedMySQLTables[database.Name]
+	if !exists {
+		return details + "\n    取得中..."
+	}
+	if len(tables) == 0 {
+		return details + "\n    テーブルがありません"
+	}
+
+	details += fmt.Sprintf("\n    %-30s %-8s %10s %12s %12s", "テーブル", "エンジン", "行数(概算)", "データ", "インデックス")
+	for _, t := range tables {
+		details += fmt.Sprintf("\n    %-30s %-8s %10s %12s %12s", t.Name, t.Engine, t.Rows, t.DataSize, t.IndexSize)
+	}
+
 	return details
 }
 
+// renderMySQLProcessDetails renders detailed information for a selected connection
+func (m Model) renderMySQLProcessDetails(process *monitor.MySQLProcess) string {
+	query := process.Info
+	if query == "" {
+		query = "なし"
+	}
+
+	return fmt.Sprintf(`
+────────────────────────────────────────────────────
+接続詳細: %s
+────────────────────────────────────────────────────
+  ユーザー: %s
+  ホスト: %s
+  データベース: %s
+  コマンド: %s
+  経過時間: %s秒
+  状態: %s
+
+  実行中のクエリ:
+    %s`,
+		process.ID,
+		process.User,
+		process.Host,
+		valueOrUnknown(process.DB),
+		process.Command,
+		process.Time,
+		valueOrUnknown(process.State),
+		query,
+	)
+}
+
 // renderSelectableMySQLContent renders database list with selectable items highlighted
 func (m Model) renderSelectableMySQLContent() string {
 	var newLines []string
@@ -108,3 +198,56 @@ func (m Model) renderSelectableMySQLContent() string {
 
 	return strings.Join(newLines, "\n")
 }
+
+// renderSelectableMySQLProcesses renders processlist with selectable items highlighted
+func (m Model) renderSelectableMySQLProcesses() string {
+	var newLines []string
+
+	// キャッシュから取得（Viewではブロッキング処理を行わない）
+	processes := m.cachedMySQLProcesses
+
+	for i, item := range m.rightPanelItems {
+		if item.Type != "mysql_process" {
+			continue
+		}
+
+		// 接続を検索
+		var process *monitor.MySQLProcess
+		for j := range processes {
+			if processes[j].ID == item.Name {
+				process = &processes[j]
+				break
+			}
+		}
+
+		if process == nil {
+			continue
+		}
+
+		// 接続ID・ユーザー・コマンド
+		processText := fmt.Sprintf("● #%s %s@%s", process.ID, process.User, valueOrUnknown(process.DB))
+		infoText := fmt.Sprintf("  (%s %ss)", process.Command, process.Time)
+
+		// 実行中のクエリがある接続は警告色
+		style := CommentStyle
+		if process.Info != "" {
+			style = WarningStyle
+		}
+
+		// カーソル位置なら強調表示
+		var line string
+		if i == m.rightPanelCursor {
+			line = HighlightStyle.Render("> "+processText) + CommentStyle.Render(infoText)
+		} else {
+			line = "  " + style.Render(processText) + CommentStyle.Render(infoText)
+		}
+
+		newLines = append(newLines, line)
+	}
+
+	if len(newLines) == 0 {
+		return "  接続がありません"
+	}
+
+	return strings.Join(newLines, "\n")
+}
@@ -17,12 +17,31 @@ func (m Model) renderNodejsContent() string {
 		return "データ取得中... (Node.js)\n\nプロセスが実行されていない可能性があります"
 	}
 
+	// 役割ごとに集計
+	var servers, tools, editors int
+	for _, proc := range processes {
+		switch proc.Role {
+		case monitor.NodeRoleServer:
+			servers++
+		case monitor.NodeRoleTool:
+			tools++
+		case monitor.NodeRoleEditor:
+			editors++
+		}
+	}
+
+	editorText := "非表示"
+	if m.showEditorNodeProcesses {
+		editorText = "表示中"
+	}
+
 	// 統計情報を生成
 	summary := fmt.Sprintf(`統計情報:
-  実行中のプロセス: %d個
+  実行中のプロセス: %d個 (サーバー: %d / ツール: %d)
+  エディタ内部のプロセス: %d個 (%s、e で切替)
 
 プロセス一覧:
-`, len(processes))
+`, len(processes), servers, tools, editors, editorText)
 
 	// プロセスリストを生成
 	processList := m.renderSelectableNodejsContent()
@@ -53,12 +72,19 @@ func (m Model) renderNodeProcessDetails(process *monitor.NodeProcess) string {
 		portText = ":" + portText
 	}
 
+	roleText := nodeRoleText(process.Role)
+	if roleText == "" {
+		roleText = "不明"
+	}
+
 	details := fmt.Sprintf(`
 ────────────────────────────────────────────────────
 プロセス詳細: %s
 ────────────────────────────────────────────────────
   PID: %s
+  種別: %s (%s)
   プロジェクトディレクトリ: %s
+  コマンド: %s
 
   リソース使用状況:
     稼働時間: %s
@@ -67,13 +93,24 @@ func (m Model) renderNodeProcessDetails(process *monitor.NodeProcess) string {
     ポート: %s`,
 		process.ProjectName,
 		process.PID,
+		process.Framework,
+		roleText,
 		process.ProjectDir,
+		truncateCommand(process.Command, 80),
 		process.Uptime,
 		process.CPUPerc,
 		process.MemUsage,
 		portText,
 	)
 
+	// ワーカーの場合は親プロセスを表示
+	if process.ParentPID != "" {
+		details += fmt.Sprintf("\n    親プロセス: PID %s", process.ParentPID)
+	}
+
+	// devmon run で起動したプロセスはコマンドとログの保存先を表示
+	details += renderManagedProcessInfo(process.ProjectDir)
+
 	// URLを追加（ポートがある場合）
 	if process.Port != "" {
 		details += fmt.Sprintf(`
@@ -85,6 +122,20 @@ func (m Model) renderNodeProcessDetails(process *monitor.NodeProcess) string {
 	return details
 }
 
+// nodeRoleText returns Japanese label for a Node.js process role
+func nodeRoleText(role string) string {
+	switch role {
+	case monitor.NodeRoleServer:
+		return "サーバー"
+	case monitor.NodeRoleTool:
+		return "ツール"
+	case monitor.NodeRoleEditor:
+		return "エディタ内部"
+	default:
+		return ""
+	}
+}
+
 // renderSelectableNodejsContent renders process list with selectable items highlighted
 func (m Model) renderSelectableNodejsContent() string {
 	var newLines []string
@@ -114,22 +165,44 @@ func (m Model) renderSelectableNodejsContent() string {
 			continue
 		}
 
-		// プロセス名とPID
-		processText := fmt.Sprintf("● %s", process.ProjectName)
-		pidText := fmt.Sprintf("  (PID: %s)", process.PID)
+		// プロセス名・フレームワークとPID
+		processText := fmt.Sprintf("● %s [%s]", process.ProjectName, process.Framework)
+		pidText := fmt.Sprintf("  (PID: %s", process.PID)
+		if roleText := nodeRoleText(process.Role); roleText != "" {
+			pidText += ", " + roleText
+		}
+		if process.Port != "" {
+			pidText += ", :" + process.Port
+		}
+		pidText += ")"
+
+		// ワーカーは親プロセスの下にインデントして表示
+		indent := ""
+		if process.ParentPID != "" {
+			indent = "  └─ "
+		}
+
+		// サーバー以外（ツール・エディタ内部）は控えめな色
+		style := SuccessStyle
+		if process.Role != monitor.NodeRoleServer {
+			style = InfoStyle
+		}
 
 		// カーソル位置なら強調表示
 		var line string
 		if i == m.rightPanelCursor {
-			line = HighlightStyle.Render("> "+processText) + CommentStyle.Render(pidText)
+			line = HighlightStyle.Render("> "+indent+processText) + CommentStyle.Render(pidText)
 		} else {
-			line = "  " + SuccessStyle.Render(processText) + CommentStyle.Render(pidText)
+			line = "  " + indent + style.Render(processText) + CommentStyle.Render(pidText)
 		}
 
 		newLines = append(newLines, line)
 	}
 
 	if len(newLines) == 0 {
+		if !m.showEditorNodeProcesses {
+			return "  プロセスがありません（エディタ内部のプロセスは e で表示できます）"
+		}
 		return "  プロセスがありません"
 	}
 
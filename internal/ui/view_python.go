@@ -74,6 +74,12 @@ func (m Model) renderPythonProcessDetails(process *monitor.PythonProcess) string
 		portText,
 	)
 
+	// インタプリタ・仮想環境・依存関係
+	details += "\n\n" + renderPythonEnvironment(process.Env)
+
+	// devmon run で起動したプロセスはコマンドとログの保存先を表示
+	details += renderManagedProcessInfo(process.ProjectDir)
+
 	// URLを追加（ポートがある場合）
 	if process.Port != "" {
 		details += fmt.Sprintf(`
@@ -135,3 +141,54 @@ func (m Model) renderSelectablePythonContent() string {
 
 	return strings.Join(newLines, "\n")
 }
+
+// renderPythonEnvironment renders interpreter, virtualenv and dependency sync status
+func renderPythonEnvironment(env monitor.PythonEnvironment) string {
+	envText := env.EnvType
+	if env.EnvPath != "" {
+		envText += " (" + env.EnvPath + ")"
+	} else {
+		envText = WarningStyle.Render("なし (システムのPython)")
+	}
+
+	dependencyFiles := "なし"
+	if len(env.DependencyFiles) > 0 {
+		dependencyFiles = strings.Join(env.DependencyFiles, ", ")
+	}
+
+	result := fmt.Sprintf(`  Python環境:
+    インタプリタ: %s
+    バージョン: %s
+    仮想環境: %s
+    依存定義: %s`,
+		valueOrUnknown(env.Interpreter),
+		valueOrUnknown(env.Version),
+		envText,
+		dependencyFiles,
+	)
+
+	// 依存関係の同期状態
+	switch {
+	case len(env.DependencyFiles) == 0:
+		return result
+	case !env.Checked:
+		result += "\n    依存関係: " + CommentStyle.Render("未確認（仮想環境が見つかりません）")
+	case env.InSync():
+		result += "\n    依存関係: " + SuccessStyle.Render(fmt.Sprintf("✓ 同期済み (%d個)", len(env.Dependencies)))
+	default:
+		result += "\n    依存関係: " + WarningStyle.Render("⚠ インストール済みパッケージと一致しません")
+		if len(env.Missing) > 0 {
+			result += "\n      未インストール: " + strings.Join(env.Missing, ", ")
+		}
+		// 件数が多い場合は先頭のみ表示
+		for i, mismatch := range env.Mismatched {
+			if i >= 5 {
+				result += fmt.Sprintf("\n      ... 他 %d件", len(env.Mismatched)-i)
+				break
+			}
+			result += "\n      バージョン不一致: " + mismatch
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,141 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
+)
+
+// renderRabbitMQContent renders RabbitMQ queue information
+func (m Model) renderRabbitMQContent() string {
+	// キャッシュから取得（Viewではブロッキング処理を行わない）
+	overview := m.cachedRabbitMQOverview
+
+	// キャッシュがない場合はローディング表示
+	if !overview.IsReachable {
+		if overview.Error != "" {
+			return fmt.Sprintf("RabbitMQ管理APIに接続できません:\n  %s\n\n管理プラグイン（rabbitmq_management）が有効か確認してください\n接続設定は %s で変更できます", overview.Error, configPathText())
+		}
+		return "データ取得中... (RabbitMQ)\n\nRabbitMQが停止中の可能性があります"
+	}
+
+	// 統計情報を生成
+	summary := fmt.Sprintf(`統計情報:
+  バージョン: %s
+  クラスタ: %s
+  接続数: %d / コンシューマー数: %d
+  メッセージ: Ready %d / Unacked %d
+  スループット: publish %.1f msg/s / deliver %.1f msg/s
+`,
+		valueOrUnknown(overview.Version),
+		valueOrUnknown(overview.ClusterName),
+		overview.Connections,
+		overview.Consumers,
+		overview.MessagesReady,
+		overview.MessagesUnacked,
+		overview.PublishRate,
+		overview.DeliverRate,
+	)
+	if overview.Container != "" {
+		summary += fmt.Sprintf("  コンテナ: %s\n", overview.Container)
+	}
+	summary += fmt.Sprintf("\nキュー一覧 (%d個):\n", len(m.cachedRabbitMQQueues))
+
+	// キューリストを生成
+	queueList := m.renderSelectableRabbitMQQueues()
+
+	// 右パネルにフォーカスがある場合、選択されたキューの詳細情報を追加
+	if m.focusedPanel == "right" && len(m.rightPanelItems) > 0 && m.rightPanelCursor < len(m.rightPanelItems) {
+		if queue := m.getSelectedRabbitMQQueue(); queue != nil {
+			return summary + queueList + "\n" + m.renderRabbitMQQueueDetails(queue)
+		}
+	}
+
+	return summary + queueList
+}
+
+// renderRabbitMQQueueDetails renders detailed information for a selected queue
+func (m Model) renderRabbitMQQueueDetails(queue *monitor.RabbitMQQueue) string {
+	details := fmt.Sprintf(`
+────────────────────────────────────────────────────
+キュー詳細: %s
+────────────────────────────────────────────────────
+  vhost: %s
+  状態: %s
+  メッセージ: %d (Ready %d / Unacked %d)
+  コンシューマー数: %d
+  スループット: publish %.1f msg/s / deliver %.1f msg/s`,
+		queue.Name,
+		queue.VHost,
+		valueOrUnknown(queue.State),
+		queue.Messages,
+		queue.MessagesReady,
+		queue.MessagesUnacked,
+		queue.Consumers,
+		queue.PublishRate,
+		queue.DeliverRate,
+	)
+
+	// 滞留の兆候を表示
+	if queue.Consumers == 0 && queue.MessagesReady > 0 {
+		details += "\n\n  " + WarningStyle.Render("⚠ コンシューマーがいないためメッセージが滞留しています")
+	} else if queue.MessagesReady >= monitor.RabbitMQQueueBacklogThreshold {
+		details += "\n\n  " + WarningStyle.Render("⚠ 処理が追いついていない可能性があります")
+	}
+
+	return details
+}
+
+// renderSelectableRabbitMQQueues renders queue list with selectable items highlighted
+func (m Model) renderSelectableRabbitMQQueues() string {
+	var newLines []string
+
+	// キャッシュから取得（Viewではブロッキング処理を行わない）
+	queues := m.cachedRabbitMQQueues
+
+	for i, item := range m.rightPanelItems {
+		if item.Type != "rabbitmq_queue" {
+			continue
+		}
+
+		// キューを検索
+		var queue *monitor.RabbitMQQueue
+		for j := range queues {
+			if queues[j].VHost == item.ProjectName && queues[j].Name == item.Name {
+				queue = &queues[j]
+				break
+			}
+		}
+
+		if queue == nil {
+			continue
+		}
+
+		// キュー名とメッセージ数
+		queueText := fmt.Sprintf("● %s", queue.Name)
+		countText := fmt.Sprintf("  (Ready %d / Unacked %d / コンシューマー %d)", queue.MessagesReady, queue.MessagesUnacked, queue.Consumers)
+
+		// 滞留しているキューは警告色
+		style := SuccessStyle
+		if (queue.Consumers == 0 && queue.MessagesReady > 0) || queue.MessagesReady >= monitor.RabbitMQQueueBacklogThreshold {
+			style = WarningStyle
+		}
+
+		// カーソル位置なら強調表示
+		var line string
+		if i == m.rightPanelCursor {
+			line = HighlightStyle.Render("> "+queueText) + CommentStyle.Render(countText)
+		} else {
+			line = "  " + style.Render(queueText) + CommentStyle.Render(countText)
+		}
+
+		newLines = append(newLines, line)
+	}
+
+	if len(newLines) == 0 {
+		return "  キューがありません"
+	}
+
+	return strings.Join(newLines, "\n")
+}
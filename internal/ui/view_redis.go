@@ -11,23 +11,38 @@ import (
 func (m Model) renderRedisContent() string {
 	// キャッシュから取得（Viewではブロッキング処理を行わない）
 	databases := m.cachedRedisDatabases
+	info := m.cachedRedisInfo
 
 	// キャッシュがない場合はローディング表示
-	if len(databases) == 0 {
+	if len(databases) == 0 && !info.IsReachable {
+		if info.Error != "" {
+			return fmt.Sprintf("Redisに接続できません:\n  %s\n\n接続設定は %s で変更できます", info.Error, configPathText())
+		}
 		return "データ取得中... (Redis)\n\nRedisが停止中の可能性があります"
 	}
 
 	// 統計情報を生成
 	summary := fmt.Sprintf(`統計情報:
   データベース数: %d個
-
+%s
 データベース一覧:
-`, len(databases))
+`, len(databases), m.renderRedisServerInfo())
 
 	// データベースリストを生成
 	databaseList := m.renderSelectableRedisContent()
 
-	// 右パネルにフォーカスがある場合、選択されたデータベースの詳細情報を追加
+	// スローログを生成
+	slowLog := "\n\nスローログ (直近10件):\n" + m.renderRedisSlowLog()
+
+	// キーブラウザを生成
+	keyBrowser := ""
+	if m.redisBrowseDB != "" {
+		keyBrowser = "\n\n" + m.renderRedisKeyBrowser()
+	}
+
+	content := summary + databaseList + slowLog + keyBrowser
+
+	// 右パネルにフォーカスがある場合、選択された項目の詳細情報を追加
 	if m.focusedPanel == "right" && len(m.rightPanelItems) > 0 && m.rightPanelCursor < len(m.rightPanelItems) {
 		selectedItem := m.rightPanelItems[m.rightPanelCursor]
 
@@ -36,12 +51,143 @@ func (m Model) renderRedisContent() string {
 			database := m.getSelectedRedisDatabase()
 			if database != nil {
 				details := m.renderRedisDatabaseDetails(database)
-				return summary + databaseList + "\n" + details
+				return content + "\n" + details
+			}
+		} else if selectedItem.Type == "redis_key" {
+			// キーの詳細情報を取得
+			key := m.getSelectedRedisKey()
+			if key != nil {
+				details := m.renderRedisKeyDetails(key)
+				return content + "\n" + details
+			}
+		}
+	}
+
+	return content
+}
+
+// renderRedisServerInfo renders metrics from INFO
+func (m Model) renderRedisServerInfo() string {
+	info := m.cachedRedisInfo
+	if !info.IsReachable {
+		return ""
+	}
+
+	return fmt.Sprintf(`  バージョン: %s
+  稼働時間: %s
+  接続クライアント: %s (ブロック中: %s)
+  処理数: %s ops/sec
+
+メモリ:
+  使用量: %s (ピーク: %s)
+  上限: %s (ポリシー: %s)
+  フラグメンテーション率: %s
+  退避されたキー: %s
+  期限切れキー: %s
+  キャッシュヒット率: %s
+`,
+		valueOrUnknown(info.Version),
+		valueOrUnknown(info.Uptime),
+		valueOrUnknown(info.ConnectedClients),
+		valueOrUnknown(info.BlockedClients),
+		valueOrUnknown(info.OpsPerSec),
+		valueOrUnknown(info.UsedMemory),
+		valueOrUnknown(info.UsedMemoryPeak),
+		valueOrUnknown(info.MaxMemory),
+		valueOrUnknown(info.MaxMemoryPolicy),
+		valueOrUnknown(info.Fragmentation),
+		valueOrUnknown(info.EvictedKeys),
+		valueOrUnknown(info.ExpiredKeys),
+		valueOrUnknown(info.HitRate),
+	)
+}
+
+// renderRedisSlowLog renders SLOWLOG GET entries
+func (m Model) renderRedisSlowLog() string {
+	entries := m.cachedRedisSlowLog
+	if len(entries) == 0 {
+		return "  スローログはありません"
+	}
+
+	var lines []string
+	for _, entry := range entries {
+		command := entry.Command
+		if len([]rune(command)) > 60 {
+			command = string([]rune(command)[:60]) + "..."
+		}
+		line := fmt.Sprintf("  %s %8s  %s",
+			CommentStyle.Render(entry.Timestamp.Format("01/02 15:04:05")),
+			entry.Duration.String(),
+			WarningStyle.Render(command),
+		)
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// renderRedisKeyBrowser renders the key browser for the selected database
+func (m Model) renderRedisKeyBrowser() string {
+	pattern := m.redisKeyPattern
+	if pattern == "" {
+		pattern = "*"
+	}
+	if m.redisPatternEditing {
+		pattern = m.redisPatternInput + "█"
+	}
+
+	header := fmt.Sprintf("キー一覧: %s  パターン: %s", m.redisBrowseDB, pattern)
+
+	if m.redisKeysError != "" {
+		return header + "\n  " + ErrorStyle.Render("取得失敗: "+m.redisKeysError)
+	}
+	if m.redisKeysLoading {
+		return header + "\n  取得中..."
+	}
+
+	var lines []string
+	for i, item := range m.rightPanelItems {
+		if item.Type != "redis_key" {
+			continue
+		}
+
+		// キーを検索
+		var key *monitor.RedisKey
+		for j := range m.cachedRedisKeys {
+			if m.cachedRedisKeys[j].Name == item.Name {
+				key = &m.cachedRedisKeys[j]
+				break
 			}
 		}
+
+		if key == nil {
+			continue
+		}
+
+		// キー名と型・TTL・サイズ
+		keyText := fmt.Sprintf("● %s", key.Name)
+		infoText := fmt.Sprintf("  (%s, TTL: %s, %s)", key.Type, key.TTL, valueOrUnknown(key.Size))
+
+		// カーソル位置なら強調表示
+		var line string
+		if i == m.rightPanelCursor {
+			line = HighlightStyle.Render("> "+keyText) + CommentStyle.Render(infoText)
+		} else {
+			line = "  " + InfoStyle.Render(keyText) + CommentStyle.Render(infoText)
+		}
+
+		lines = append(lines, line)
+	}
+
+	if len(lines) == 0 {
+		return header + "\n  一致するキーがありません"
 	}
 
-	return summary + databaseList
+	if len(lines) >= monitor.RedisKeyScanLimit {
+		header += CommentStyle.Render(fmt.Sprintf("  (先頭%d件のみ表示)", monitor.RedisKeyScanLimit))
+	}
+
+	return header + "\n" + strings.Join(lines, "\n")
 }
 
 // renderRedisDatabaseDetails renders detailed information for a selected database
@@ -50,7 +196,9 @@ func (m Model) renderRedisDatabaseDetails(database *monitor.RedisDatabase) strin
 ────────────────────────────────────────────────────
 データベース詳細: %s
 ────────────────────────────────────────────────────
-  キー数: %s`,
+  キー数: %s
+
+  Enter でキー一覧を表示します`,
 		database.Index,
 		database.KeysNum,
 	)
@@ -58,6 +206,24 @@ func (m Model) renderRedisDatabaseDetails(database *monitor.RedisDatabase) strin
 	return details
 }
 
+// renderRedisKeyDetails renders detailed information for a selected key
+func (m Model) renderRedisKeyDetails(key *monitor.RedisKey) string {
+	return fmt.Sprintf(`
+────────────────────────────────────────────────────
+キー詳細: %s
+────────────────────────────────────────────────────
+  データベース: %s
+  型: %s
+  TTL: %s
+  メモリ使用量: %s`,
+		key.Name,
+		m.redisBrowseDB,
+		valueOrUnknown(key.Type),
+		valueOrUnknown(key.TTL),
+		valueOrUnknown(key.Size),
+	)
+}
+
 // renderSelectableRedisContent renders database list with selectable items highlighted
 func (m Model) renderSelectableRedisContent() string {
 	var newLines []string
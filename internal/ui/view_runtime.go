@@ -0,0 +1,169 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masahide-S/bho_hacka_go/internal/monitor"
+)
+
+// renderRuntimeContent renders Go/Ruby/JVM/Deno/Bun/PHP process information
+func (m Model) renderRuntimeContent(runtime string) string {
+	// キャッシュから取得（Viewではブロッキング処理を行わない）
+	processes := m.cachedRuntimeProcesses
+
+	// キャッシュがない場合はローディング表示
+	if len(processes) == 0 || processes[0].Runtime != runtime {
+		return fmt.Sprintf("データ取得中... (%s)\n\nプロセスが実行されていない可能性があります", runtime)
+	}
+
+	// 統計情報を生成（メモリ合計はデーモンの肥大化に気付けるように表示）
+	var totalMemMB float64
+	for _, proc := range processes {
+		var memMB float64
+		if _, err := fmt.Sscanf(proc.MemUsage, "%fMB", &memMB); err == nil {
+			totalMemMB += memMB
+		}
+	}
+
+	summary := fmt.Sprintf(`統計情報:
+  実行中のプロセス: %d個
+  メモリ合計: %.1fMB
+
+プロセス一覧:
+`, len(processes), totalMemMB)
+
+	// プロセスリストを生成
+	processList := m.renderSelectableRuntimeContent()
+
+	// 右パネルにフォーカスがある場合、選択されたプロセスの詳細情報を追加
+	if m.focusedPanel == "right" && len(m.rightPanelItems) > 0 && m.rightPanelCursor < len(m.rightPanelItems) {
+		if process := m.getSelectedRuntimeProcess(); process != nil {
+			return summary + processList + "\n" + m.renderRuntimeProcessDetails(process)
+		}
+	}
+
+	return summary + processList
+}
+
+// renderRuntimeProcessDetails renders detailed information for a selected process
+func (m Model) renderRuntimeProcessDetails(process *monitor.RuntimeProcess) string {
+	portText := process.Port
+	if portText == "" {
+		portText = "なし"
+	} else {
+		portText = ":" + portText
+	}
+
+	projectDir := process.ProjectDir
+	if projectDir == "" {
+		projectDir = "不明"
+	}
+
+	details := fmt.Sprintf(`
+────────────────────────────────────────────────────
+プロセス詳細: %s
+────────────────────────────────────────────────────
+  PID: %s
+  種別: %s
+  プロジェクトディレクトリ: %s
+  コマンド: %s
+
+  リソース使用状況:
+    稼働時間: %s
+    CPU使用率: %s
+    メモリ使用: %s
+    ポート: %s`,
+		process.ProjectName,
+		process.PID,
+		process.Framework,
+		projectDir,
+		truncateCommand(process.Command, 80),
+		valueOrUnknown(process.Uptime),
+		process.CPUPerc,
+		process.MemUsage,
+		portText,
+	)
+
+	// JVMの場合はjcmdで取得したヒープ使用量を表示
+	if process.Runtime == "JVM" {
+		heap, ok := m.cachedJVMHeap[process.PID]
+		if !ok {
+			heap = "取得中...（jcmdが必要です）"
+		}
+		details += fmt.Sprintf("\n    ヒープ: %s", heap)
+	}
+
+	// devmon run で起動したプロセスはコマンドとログの保存先を表示
+	details += renderManagedProcessInfo(process.ProjectDir)
+
+	// URLを追加（ポートがある場合）
+	if process.Port != "" {
+		details += fmt.Sprintf(`
+
+  アクセス情報:
+    URL: http://localhost:%s`, process.Port)
+	}
+
+	return details
+}
+
+// renderSelectableRuntimeContent renders process list with selectable items highlighted
+func (m Model) renderSelectableRuntimeContent() string {
+	var newLines []string
+
+	// キャッシュから取得（Viewではブロッキング処理を行わない）
+	processes := m.cachedRuntimeProcesses
+
+	// 各プロセスを表示
+	for i, item := range m.rightPanelItems {
+		if item.Type != "runtime_process" {
+			continue
+		}
+
+		// プロセスを検索
+		var process *monitor.RuntimeProcess
+		for j := range processes {
+			if processes[j].PID == item.Name {
+				process = &processes[j]
+				break
+			}
+		}
+
+		if process == nil {
+			continue
+		}
+
+		// プロジェクト名と種別、PID・メモリ
+		processText := fmt.Sprintf("● %s (%s)", process.ProjectName, process.Framework)
+		if process.ProjectName == process.Framework {
+			processText = fmt.Sprintf("● %s", process.Framework)
+		}
+		pidText := fmt.Sprintf("  (PID: %s, %s)", process.PID, process.MemUsage)
+
+		// カーソル位置なら強調表示
+		var line string
+		if i == m.rightPanelCursor {
+			line = HighlightStyle.Render("> "+processText) + CommentStyle.Render(pidText)
+		} else {
+			line = "  " + SuccessStyle.Render(processText) + CommentStyle.Render(pidText)
+		}
+
+		newLines = append(newLines, line)
+	}
+
+	if len(newLines) == 0 {
+		return "  プロセスがありません"
+	}
+
+	return strings.Join(newLines, "\n")
+}
+
+// truncateCommand shortens a long command line for display
+func truncateCommand(command string, maxLen int) string {
+	runes := []rune(command)
+	if len(runes) <= maxLen {
+		return command
+	}
+	return string(runes[:maxLen-3]) + "..."
+}